@@ -0,0 +1,109 @@
+package gomib
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/internal/module"
+	"github.com/golangsnmp/gomib/internal/types"
+)
+
+// ModuleFile is the source file of one module found by [Closure].
+type ModuleFile struct {
+	Module  string // module name declared in the file
+	Path    string // location reported by the source
+	Content []byte
+	// Revision is the LAST-UPDATED value of the MODULE-IDENTITY, or
+	// empty for modules without one (SMIv1 modules, for example).
+	Revision string
+}
+
+// ClosureResult is the minimal file set needed to load a list of modules.
+type ClosureResult struct {
+	Files []ModuleFile // sorted by module name
+	// Missing lists requested and imported modules that were not found
+	// in any source.
+	Missing []string
+}
+
+// Closure finds the files of the modules named by [WithModules] and of
+// every module they import, transitively, searching the sources exactly
+// as [Load] does. Base SMI modules such as SNMPv2-SMI are built in and
+// have no file. Loading only the returned files, for example through
+// [FS], gives the same modules as loading from the original sources.
+//
+// Without WithModules, the closure of every module the sources list is
+// returned. As with Load, requested modules that are not found are
+// reported as [ErrMissingModules] alongside the partial result.
+func Closure(ctx context.Context, opts ...LoadOption) (*ClosureResult, error) {
+	cfg := newLoadConfig(opts)
+
+	sources := cfg.sources
+	if cfg.systemPaths {
+		sources = append(sources, discoverSystemSources(types.Logger{L: cfg.logger})...)
+	}
+	if len(sources) == 0 {
+		return nil, ErrNoSources
+	}
+
+	names := cfg.modules
+	if !cfg.hasModules {
+		for _, src := range sources {
+			list, err := src.ListModules()
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, list...)
+		}
+	}
+
+	result := &ClosureResult{}
+	modules, err := findModuleClosure(ctx, sources, names, cfg, func(mod *module.Module, f FindResult) {
+		result.Files = append(result.Files, ModuleFile{
+			Module:   mod.Name,
+			Path:     f.Path,
+			Content:  f.Content,
+			Revision: moduleRevision(mod),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(result.Files, func(a, b ModuleFile) int { return cmp.Compare(a.Module, b.Module) })
+
+	for _, mod := range modules {
+		for _, imp := range mod.Imports {
+			if _, ok := modules[imp.Module]; !ok {
+				result.Missing = append(result.Missing, imp.Module)
+			}
+		}
+	}
+	var requested []string
+	if cfg.hasModules {
+		for _, name := range names {
+			if _, ok := modules[name]; !ok {
+				requested = append(requested, name)
+				result.Missing = append(result.Missing, name)
+			}
+		}
+	}
+	slices.Sort(result.Missing)
+	result.Missing = slices.Compact(result.Missing)
+
+	if len(requested) > 0 {
+		return result, fmt.Errorf("%w: %s", ErrMissingModules, strings.Join(requested, ", "))
+	}
+	return result, nil
+}
+
+func moduleRevision(mod *module.Module) string {
+	for _, def := range mod.Definitions {
+		if mi, ok := def.(*module.ModuleIdentity); ok {
+			return mi.LastUpdated
+		}
+	}
+	return ""
+}
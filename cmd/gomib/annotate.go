@@ -0,0 +1,387 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+const annotateUsage = `gomib annotate - Decode snmpwalk and snmprec files using loaded MIBs
+
+Usage:
+  gomib annotate [options] [FILE...]
+
+Reads numeric snmpwalk output (snmpwalk -On) or .snmprec simulator data
+and rewrites each line with the object name, the instance index split
+into named components, enum labels, DISPLAY-HINT formatted values and
+units. Lines are processed as they are read, so large dumps and pipes
+are fine. With no FILE, or "-", reads stdin.
+
+OIDs not covered by any loaded MIB object are marked with
+"-- not in loaded MIBs" and counted in a summary on stderr.
+
+Options:
+  -m, --module MODULE   Module to load (repeatable, default: all)
+  --input FMT           Input format: auto, walk, snmprec (default: auto)
+  --strict              Exit 2 if any OID is not covered
+  -q, --quiet           Do not print the coverage summary
+  -h, --help            Show help
+
+Examples:
+  gomib annotate walk.txt
+  snmpwalk -On -v2c -c public host | gomib annotate
+  gomib annotate -m IF-MIB device.snmprec
+`
+
+const (
+	inputAuto    = "auto"
+	inputWalk    = "walk"
+	inputSnmprec = "snmprec"
+)
+
+func (c *cli) cmdAnnotate(args []string) int {
+	fs := flag.NewFlagSet("annotate", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, annotateUsage) }
+
+	var modules moduleList
+	fs.Var(&modules, "m", "module to load")
+	fs.Var(&modules, "module", "module to load")
+	input := fs.String("input", inputAuto, "input format: auto, walk, snmprec")
+	strict := fs.Bool("strict", false, "exit 2 if any OID is not covered")
+	quiet := fs.Bool("q", false, "do not print the coverage summary")
+	fs.BoolVar(quiet, "quiet", false, "do not print the coverage summary")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, annotateUsage)
+		return 0
+	}
+
+	switch *input {
+	case inputAuto, inputWalk, inputSnmprec:
+	default:
+		printError("unknown input format: %s", *input)
+		return exitError
+	}
+
+	m, err := c.loadMib(modules)
+	if err != nil {
+		printError("failed to load: %v", err)
+		return exitError
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	a := &annotator{m: m, input: *input}
+	out := bufio.NewWriter(os.Stdout)
+	for _, name := range files {
+		if err := a.annotateFile(out, name); err != nil {
+			_ = out.Flush()
+			printError("%v", err)
+			return exitError
+		}
+	}
+	if err := out.Flush(); err != nil {
+		printError("%v", err)
+		return exitError
+	}
+
+	if !*quiet && a.uncovered > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d OIDs not covered by loaded MIBs\n", a.uncovered, a.total)
+	}
+	if *strict && a.uncovered > 0 {
+		return exitStrictViolation
+	}
+	return exitOK
+}
+
+// annotator rewrites walk and snmprec lines, counting OIDs that no
+// loaded object covers.
+type annotator struct {
+	m     *mib.Mib
+	input string
+
+	total     int
+	uncovered int
+}
+
+func (a *annotator) annotateFile(w *bufio.Writer, name string) error {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if out, ok := a.annotateLine(line); ok {
+			line = out
+		}
+		if _, err := w.WriteString(line + "\n"); err != nil {
+			return err
+		}
+		if name == "-" {
+			// Keep interactive pipelines responsive.
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// annotateLine rewrites one input line. Lines that are not varbinds,
+// such as continuation lines of multi-line strings, are left unchanged.
+func (a *annotator) annotateLine(line string) (string, bool) {
+	vb, ok := parseVarBindLine(line, a.input)
+	if !ok {
+		return "", false
+	}
+	a.total++
+
+	// Plain OID nodes such as sysUpTimeInstance can sit below an object.
+	var obj *mib.Object
+	for nd := a.m.LongestPrefixByOID(vb.oid); nd != nil && obj == nil; nd = nd.Parent() {
+		obj = nd.Object()
+	}
+	if obj == nil || (!obj.IsScalar() && !obj.IsColumn()) {
+		a.uncovered++
+		return fmt.Sprintf("%s = %s  -- not in loaded MIBs", a.m.FormatOID(vb.oid), vb.typedValue()), true
+	}
+
+	name := annotateName(a.m, obj, vb.oid)
+	value := vb.text
+	if v, ok := vb.decode(); ok {
+		value = obj.FormatValue(v)
+		if vb.typ == "OID" {
+			value = a.m.FormatOID(v.(mib.OID))
+		}
+	}
+	if units := obj.Units(); units != "" && value != "" {
+		value += " " + units
+	}
+	if vb.typ == "" {
+		return name + " = " + value, true
+	}
+	return name + " = " + vb.typ + ": " + value, true
+}
+
+// annotateName renders an instance as MODULE::object[index=value,...],
+// or with a numeric suffix when the index cannot be decoded.
+func annotateName(m *mib.Mib, obj *mib.Object, oid mib.OID) string {
+	prefix := obj.Name()
+	if mod := obj.Module(); mod != nil {
+		prefix = mod.Name() + "::" + prefix
+	}
+	suffix := oid[len(obj.OID()):]
+	if !obj.IsColumn() || len(suffix) == 0 {
+		return m.FormatOID(oid)
+	}
+	values, err := obj.DecodeIndex(suffix)
+	if err != nil || len(values) == 0 {
+		return m.FormatOID(oid)
+	}
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = v.Object.Name() + "=" + strings.Trim(mib.FormatIndex(values[i:i+1]), "[]")
+	}
+	return prefix + "[" + strings.Join(parts, ", ") + "]"
+}
+
+// walkVarBind is one varbind read from a walk or snmprec line, with
+// the value type spelled as snmpwalk prints it.
+type walkVarBind struct {
+	oid  mib.OID
+	typ  string // "INTEGER", "STRING", "Hex-STRING", ... or "" if untyped
+	text string // the value text as it appeared in the input
+	raw  []byte // decoded octets for snmprec hex values
+}
+
+func (vb walkVarBind) typedValue() string {
+	if vb.typ == "" {
+		return vb.text
+	}
+	return vb.typ + ": " + vb.text
+}
+
+// decode converts the value text to a Go value accepted by
+// [mib.Object.FormatValue].
+func (vb walkVarBind) decode() (any, bool) {
+	switch vb.typ {
+	case "INTEGER":
+		// snmpwalk prints enums as "label(n)".
+		s := firstField(vb.text)
+		if i := strings.LastIndexByte(s, '('); i >= 0 && strings.HasSuffix(s, ")") {
+			s = s[i+1 : len(s)-1]
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		return n, err == nil
+	case "Counter32", "Gauge32", "Unsigned32", "Counter64":
+		n, err := strconv.ParseUint(firstField(vb.text), 10, 64)
+		return n, err == nil
+	case "Timeticks":
+		s := firstField(vb.text)
+		if strings.HasPrefix(s, "(") {
+			s = strings.TrimSuffix(s[1:], ")")
+		}
+		n, err := strconv.ParseUint(s, 10, 32)
+		return n, err == nil
+	case "STRING":
+		if vb.raw != nil {
+			return vb.raw, true
+		}
+		return []byte(unquoteWalkString(vb.text)), true
+	case "Hex-STRING":
+		if vb.raw != nil {
+			return vb.raw, true
+		}
+		b, err := hex.DecodeString(strings.Join(strings.Fields(vb.text), ""))
+		return b, err == nil
+	case "IpAddress":
+		ip := net.ParseIP(vb.text).To4()
+		return ip, ip != nil
+	case "OID":
+		oid, err := mib.ParseOID(vb.text)
+		return oid, err == nil
+	}
+	return nil, false
+}
+
+// firstField returns the value text up to the first space, dropping
+// the UNITS that snmpwalk appends to numbers.
+func firstField(s string) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), " ")
+	return s
+}
+
+// unquoteWalkString strips the quotes snmpwalk puts around strings and
+// undoes its backslash escaping.
+func unquoteWalkString(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// parseVarBindLine parses a line in the given input format, trying
+// walk and then snmprec syntax for inputAuto.
+func parseVarBindLine(line, input string) (walkVarBind, bool) {
+	switch input {
+	case inputWalk:
+		return parseWalkLine(line)
+	case inputSnmprec:
+		return parseSnmprecLine(line)
+	}
+	if vb, ok := parseWalkLine(line); ok {
+		return vb, true
+	}
+	return parseSnmprecLine(line)
+}
+
+// parseWalkLine parses a numeric snmpwalk line:
+//
+//	.1.3.6.1.2.1.1.3.0 = Timeticks: (123) 0:00:01.23
+func parseWalkLine(line string) (walkVarBind, bool) {
+	lhs, rhs, ok := strings.Cut(line, " = ")
+	if !ok || !strings.HasPrefix(lhs, ".") {
+		return walkVarBind{}, false
+	}
+	oid, err := mib.ParseOID(strings.TrimSpace(lhs))
+	if err != nil {
+		return walkVarBind{}, false
+	}
+	vb := walkVarBind{oid: oid, text: rhs}
+	if typ, val, ok := strings.Cut(rhs, ": "); ok && !strings.ContainsAny(typ, ` "`) {
+		vb.typ, vb.text = typ, val
+	}
+	return vb, true
+}
+
+// snmprecTypes maps snmprec BER tags to snmpwalk type names.
+var snmprecTypes = map[string]string{
+	"2":  "INTEGER",
+	"4":  "STRING",
+	"5":  "NULL",
+	"6":  "OID",
+	"64": "IpAddress",
+	"65": "Counter32",
+	"66": "Gauge32",
+	"67": "Timeticks",
+	"68": "Opaque",
+	"70": "Counter64",
+}
+
+// parseSnmprecLine parses a snmpsim .snmprec line:
+//
+//	1.3.6.1.2.1.1.5.0|4|router1
+//	1.3.6.1.2.1.2.2.1.6.1|4x|001122334455
+func parseSnmprecLine(line string) (walkVarBind, bool) {
+	fields := strings.SplitN(line, "|", 3)
+	if len(fields) != 3 {
+		return walkVarBind{}, false
+	}
+	oid, err := mib.ParseOID(fields[0])
+	if err != nil {
+		return walkVarBind{}, false
+	}
+	// Variation module tags look like "4:numeric"; only the BER tag matters.
+	tag, _, _ := strings.Cut(fields[1], ":")
+	isHex := strings.HasSuffix(tag, "x")
+	typ, ok := snmprecTypes[strings.TrimSuffix(tag, "x")]
+	if !ok {
+		return walkVarBind{}, false
+	}
+	vb := walkVarBind{oid: oid, typ: typ, text: fields[2]}
+	if isHex {
+		b, err := hex.DecodeString(fields[2])
+		if err != nil {
+			return walkVarBind{}, false
+		}
+		vb.raw = b
+		if typ == "STRING" {
+			vb.typ = "Hex-STRING"
+		} else {
+			vb.text = string(b)
+		}
+	}
+	if vb.typ == "STRING" {
+		vb.raw = []byte(vb.text)
+	}
+	return vb, true
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func TestParseWalkLine(t *testing.T) {
+	tests := []struct {
+		line string
+		ok   bool
+		oid  string
+		typ  string
+		text string
+	}{
+		{".1.3.6.1.2.1.1.3.0 = Timeticks: (123) 0:00:01.23", true, "1.3.6.1.2.1.1.3.0", "Timeticks", "(123) 0:00:01.23"},
+		{`.1.3.6.1.2.1.1.5.0 = STRING: "a: b"`, true, "1.3.6.1.2.1.1.5.0", "STRING", `"a: b"`},
+		{".1.3.6.1.2.1.2.2.1.6.1 = Hex-STRING: 00 11 22 33 44 55 ", true, "1.3.6.1.2.1.2.2.1.6.1", "Hex-STRING", "00 11 22 33 44 55 "},
+		{`.1.3.6.1.2.1.1.5.0 = ""`, true, "1.3.6.1.2.1.1.5.0", "", `""`},
+		{`.1.3.6.1.2.1.1.5.0 = "quoted: not a type"`, true, "1.3.6.1.2.1.1.5.0", "", `"quoted: not a type"`},
+		{".1.3.6.1.2.1.1.1.0 = No Such Object available on this agent at this OID", true, "1.3.6.1.2.1.1.1.0", "", "No Such Object available on this agent at this OID"},
+		// Not varbind lines.
+		{"", false, "", "", ""},
+		{"continuation of a multi-line string", false, "", "", ""},
+		{"1.3.6.1.2.1.1.5.0 = STRING: no leading dot", false, "", "", ""},
+		{"SNMPv2-MIB::sysName.0 = STRING: symbolic", false, "", "", ""},
+		{".1.3.x.1 = INTEGER: 1", false, "", "", ""},
+		{".1.3.6.1.2.1.1.5.0 STRING: no separator", false, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			vb, ok := parseWalkLine(tt.line)
+			testutil.Equal(t, tt.ok, ok, "ok")
+			if !ok {
+				return
+			}
+			testutil.Equal(t, tt.oid, vb.oid.String(), "oid")
+			testutil.Equal(t, tt.typ, vb.typ, "type")
+			testutil.Equal(t, tt.text, vb.text, "text")
+		})
+	}
+}
+
+func TestParseSnmprecLine(t *testing.T) {
+	tests := []struct {
+		line string
+		ok   bool
+		typ  string
+		text string
+		raw  string
+	}{
+		{"1.3.6.1.2.1.1.5.0|4|router1", true, "STRING", "router1", "router1"},
+		{"1.3.6.1.2.1.1.5.0|4|a|b", true, "STRING", "a|b", "a|b"},
+		{"1.3.6.1.2.1.2.2.1.6.1|4x|001122334455", true, "Hex-STRING", "001122334455", "\x00\x11\x22\x33\x44\x55"},
+		{"1.3.6.1.2.1.4.20.1.1.192.0.2.1|64x|c0000201", true, "IpAddress", "\xc0\x00\x02\x01", "\xc0\x00\x02\x01"},
+		{"1.3.6.1.2.1.2.2.1.10.1|65|1234", true, "Counter32", "1234", ""},
+		{"1.3.6.1.2.1.2.2.1.10.1|65:numeric|1234", true, "Counter32", "1234", ""},
+		{"1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.9", true, "OID", "1.3.6.1.4.1.9", ""},
+		// Malformed lines.
+		{"", false, "", "", ""},
+		{"1.3.6.1.2.1.1.5.0|4", false, "", "", ""},
+		{"1.3.6.1.2.1.1.5.0|99|unknown tag", false, "", "", ""},
+		{"1.3.6.1.2.1.1.5.0|4x|zz", false, "", "", ""},
+		{"1.3.6.1.2.1.1.5.0|4x|abc", false, "", "", ""},
+		{"not.an.oid|4|x", false, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			vb, ok := parseSnmprecLine(tt.line)
+			testutil.Equal(t, tt.ok, ok, "ok")
+			if !ok {
+				return
+			}
+			testutil.Equal(t, tt.typ, vb.typ, "type")
+			testutil.Equal(t, tt.text, vb.text, "text")
+			testutil.Equal(t, tt.raw, string(vb.raw), "raw")
+		})
+	}
+}
+
+func TestWalkVarBindDecode(t *testing.T) {
+	tests := []struct {
+		typ, text string
+		want      any
+		ok        bool
+	}{
+		{"INTEGER", "7", int64(7), true},
+		{"INTEGER", "up(1)", int64(1), true},
+		{"INTEGER", "-3 degrees", int64(-3), true},
+		{"INTEGER", "up", nil, false},
+		{"Counter32", "1234", uint64(1234), true},
+		{"Counter64", "18446744073709551615", uint64(18446744073709551615), true},
+		{"Gauge32", "100 Mbit/s", uint64(100), true},
+		{"Counter32", "-1", nil, false},
+		{"Timeticks", "(12345) 0:02:03.45", uint64(12345), true},
+		{"Timeticks", "(x) 0:00:00.00", nil, false},
+		{"STRING", `"eth0"`, "eth0", true},
+		{"STRING", `"say \"hi\""`, `say "hi"`, true},
+		{"STRING", "unquoted", "unquoted", true},
+		{"Hex-STRING", "00 1A FF ", "\x00\x1a\xff", true},
+		{"Hex-STRING", "0G", nil, false},
+		{"Hex-STRING", "ABC", nil, false},
+		{"IpAddress", "192.0.2.1", "192.0.2.1", true},
+		{"IpAddress", "2001:db8::1", nil, false},
+		{"IpAddress", "not an address", nil, false},
+		{"OID", ".1.3.6.1.4.1.9", "1.3.6.1.4.1.9", true},
+		{"OID", "SNMPv2-SMI::enterprises.9", nil, false},
+		{"NULL", "", nil, false},
+		{"", "untyped", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ+" "+tt.text, func(t *testing.T) {
+			v, ok := walkVarBind{typ: tt.typ, text: tt.text}.decode()
+			testutil.Equal(t, tt.ok, ok, "ok")
+			if !ok {
+				return
+			}
+			switch x := v.(type) {
+			case []byte:
+				testutil.Equal(t, tt.want.(string), string(x), "value")
+			case net.IP:
+				testutil.Equal(t, tt.want.(string), x.String(), "value")
+			case mib.OID:
+				testutil.Equal(t, tt.want.(string), x.String(), "value")
+			default:
+				testutil.Equal(t, tt.want, v, "value")
+			}
+		})
+	}
+}
+
+func TestAnnotateName(t *testing.T) {
+	m := loadTestMib(t, "IF-MIB", "SNMPv2-MIB")
+
+	tests := []struct {
+		object string
+		oid    string
+		want   string
+	}{
+		{"ifDescr", "1.3.6.1.2.1.2.2.1.2.3", "IF-MIB::ifDescr[ifIndex=3]"},
+		{"ifStackStatus", "1.3.6.1.2.1.31.1.2.1.3.5.0", "IF-MIB::ifStackStatus[ifStackHigherLayer=5, ifStackLowerLayer=0]"},
+		{"ifRcvAddressStatus", "1.3.6.1.2.1.31.1.4.1.2.1.6.0.17.34.51.68.85",
+			"IF-MIB::ifRcvAddressStatus[ifIndex=1, ifRcvAddressAddress=00:11:22:33:44:55]"},
+		// Scalars and undecodable indexes keep the numeric suffix.
+		{"sysDescr", "1.3.6.1.2.1.1.1.0", "SNMPv2-MIB::sysDescr.0"},
+		{"ifDescr", "1.3.6.1.2.1.2.2.1.2", "IF-MIB::ifDescr"},
+		{"ifRcvAddressStatus", "1.3.6.1.2.1.31.1.4.1.2.1.6.0", "IF-MIB::ifRcvAddressStatus.1.6.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.oid, func(t *testing.T) {
+			obj := m.Object(tt.object)
+			testutil.NotNil(t, obj, "Object(%s)", tt.object)
+			oid, err := mib.ParseOID(tt.oid)
+			testutil.NoError(t, err, "ParseOID")
+			testutil.Equal(t, tt.want, annotateName(m, obj, oid), "annotateName")
+		})
+	}
+}
+
+func TestAnnotateLine(t *testing.T) {
+	m := loadTestMib(t, "IF-MIB", "SNMPv2-MIB", "IP-MIB")
+
+	tests := []struct {
+		input string
+		line  string
+		want  string
+		ok    bool
+	}{
+		{inputWalk, ".1.3.6.1.2.1.2.2.1.8.2 = INTEGER: 1", "IF-MIB::ifOperStatus[ifIndex=2] = INTEGER: up(1)", true},
+		{inputWalk, ".1.3.6.1.2.1.2.2.1.6.2 = Hex-STRING: 00 11 22 33 44 55 ", "IF-MIB::ifPhysAddress[ifIndex=2] = Hex-STRING: 00:11:22:33:44:55", true},
+		{inputWalk, ".1.3.6.1.2.1.4.13.0 = INTEGER: 60 seconds", "IP-MIB::ipReasmTimeout.0 = INTEGER: 60 seconds", true},
+		{inputSnmprec, "1.3.6.1.2.1.1.5.0|4|router1", "SNMPv2-MIB::sysName.0 = STRING: router1", true},
+		{inputAuto, "1.3.6.1.2.1.2.2.1.7.1|2|2", "IF-MIB::ifAdminStatus[ifIndex=1] = INTEGER: down(2)", true},
+		// A value that does not decode is passed through.
+		{inputWalk, ".1.3.6.1.2.1.2.2.1.8.2 = INTEGER: bogus", "IF-MIB::ifOperStatus[ifIndex=2] = INTEGER: bogus", true},
+		// Unknown OIDs are marked.
+		{inputWalk, ".1.3.6.1.4.1.99999.1.0 = INTEGER: 5", "SNMPv2-SMI::enterprises.99999.1.0 = INTEGER: 5  -- not in loaded MIBs", true},
+		{inputSnmprec, "1.3.6.1.4.1.99999.1.0|4x|0102", "SNMPv2-SMI::enterprises.99999.1.0 = Hex-STRING: 0102  -- not in loaded MIBs", true},
+		{inputWalk, ".1.3.6.1.2.1.2.2.1.2 = STRING: \"table, not an instance\"", "IF-MIB::ifDescr = STRING: table, not an instance", true},
+		// Lines that are not varbinds are left alone.
+		{inputWalk, "  continued text", "", false},
+		{inputSnmprec, ".1.3.6.1.2.1.1.5.0 = STRING: walk syntax", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			a := &annotator{m: m, input: tt.input}
+			got, ok := a.annotateLine(tt.line)
+			testutil.Equal(t, tt.ok, ok, "ok")
+			testutil.Equal(t, tt.want, got, "annotated line")
+		})
+	}
+
+	a := &annotator{m: m, input: inputAuto}
+	for _, line := range []string{
+		".1.3.6.1.2.1.1.5.0 = STRING: \"r1\"",
+		".1.3.6.1.4.1.99999.1.0 = INTEGER: 5",
+		"garbage",
+	} {
+		a.annotateLine(line)
+	}
+	testutil.Equal(t, 2, a.total, "total")
+	testutil.Equal(t, 1, a.uncovered, "uncovered")
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+const complianceUsage = `gomib compliance - Check a device walk against a MODULE-COMPLIANCE
+
+Usage:
+  gomib compliance [options] COMPLIANCE FILE...
+
+Reports which MANDATORY-GROUPS and conditional GROUPs the walked agent
+implements, which readable objects are missing, and which returned
+values violate the compliance's OBJECT SYNTAX refinements. Objects
+whose MIN-ACCESS is not-accessible or accessible-for-notify are not
+expected in the walk. FILE is numeric snmpwalk output or .snmprec data;
+"-" reads stdin.
+
+COMPLIANCE is a name (ifCompliance3) or qualified (IF-MIB::ifCompliance3).
+Without -m, a qualified name loads only its module and dependencies;
+otherwise all modules are loaded.
+
+Options:
+  -m, --module MODULE   Module to load (repeatable)
+  --input FMT           Input format: auto, walk, snmprec (default: auto)
+  --format FMT          Output format: text, json (default: text)
+  --full                List present objects as well as missing ones
+  -h, --help            Show help
+
+Exit status is 0 when all mandatory groups are implemented and no value
+violates a refinement, 2 when not, and 1 on errors.
+
+Examples:
+  gomib compliance IF-MIB::ifCompliance3 walk.txt
+  gomib compliance --format json snmpBasicComplianceRev2 device.snmprec
+`
+
+func (c *cli) cmdCompliance(args []string) int {
+	fs := flag.NewFlagSet("compliance", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, complianceUsage) }
+
+	var modules moduleList
+	fs.Var(&modules, "m", "module to load")
+	fs.Var(&modules, "module", "module to load")
+	input := fs.String("input", inputAuto, "input format: auto, walk, snmprec")
+	format := fs.String("format", formatText, "output format: text, json")
+	full := fs.Bool("full", false, "list present objects")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, complianceUsage)
+		return 0
+	}
+
+	if fs.NArg() < 2 {
+		printError("compliance name and walk file required")
+		fmt.Fprint(os.Stderr, complianceUsage)
+		return 1
+	}
+	switch *input {
+	case inputAuto, inputWalk, inputSnmprec:
+	default:
+		printError("unknown input format: %s", *input)
+		return exitError
+	}
+	if *format != formatText && *format != formatJSON {
+		printError("unknown format: %s", *format)
+		return exitError
+	}
+
+	name := fs.Arg(0)
+	modName, compName, qualified := strings.Cut(name, "::")
+	if !qualified {
+		modName, compName = "", name
+	} else if len(modules) == 0 {
+		modules = moduleList{modName}
+	}
+
+	m, err := c.loadMib(modules)
+	if err != nil {
+		printError("failed to load: %v", err)
+		return exitError
+	}
+
+	var comp *mib.Compliance
+	if modName != "" {
+		if mod := m.Module(modName); mod != nil {
+			comp = mod.Compliance(compName)
+		}
+	} else {
+		comp = m.Compliance(compName)
+	}
+	if comp == nil {
+		printError("compliance not found: %s", name)
+		return exitError
+	}
+
+	var walk []mib.VarBind
+	for _, file := range fs.Args()[1:] {
+		vbs, err := readVarBinds(file, *input)
+		if err != nil {
+			printError("%v", err)
+			return exitError
+		}
+		walk = append(walk, vbs...)
+	}
+
+	report := m.CheckCompliance(comp, walk)
+	if *format == formatJSON {
+		err = writeComplianceJSON(os.Stdout, report, len(walk))
+	} else {
+		err = writeComplianceText(os.Stdout, m, report, len(walk), *full)
+	}
+	if err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	if !report.Compliant() {
+		return exitStrictViolation
+	}
+	return exitOK
+}
+
+// readVarBinds reads every varbind from a walk or snmprec file, decoding
+// values to the Go types [mib.Object.FormatValue] accepts. A value that
+// cannot be decoded is left nil: its instance still counts towards group
+// coverage, but its value is not checked against refinements.
+func readVarBinds(name, input string) ([]mib.VarBind, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var vbs []mib.VarBind
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		vb, ok := parseVarBindLine(sc.Text(), input)
+		if !ok {
+			continue
+		}
+		value, ok := vb.decode()
+		if !ok {
+			value = nil
+		}
+		vbs = append(vbs, mib.VarBind{OID: vb.oid, Value: value})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return vbs, nil
+}
+
+func writeComplianceText(w io.Writer, m *mib.Mib, r *mib.ComplianceReport, count int, full bool) error {
+	bw := bufio.NewWriter(w)
+	verdict := "COMPLIANT"
+	if !r.Compliant() {
+		verdict = "NOT COMPLIANT"
+	}
+	fmt.Fprintf(bw, "%s: %s (%d varbinds)\n", qualifiedName(r.Compliance.Module(), r.Compliance.Name()), verdict, count)
+
+	for _, mandatory := range []bool{true, false} {
+		heading := "Mandatory groups:"
+		if !mandatory {
+			heading = "Conditional groups:"
+		}
+		printed := false
+		for _, g := range r.Groups {
+			if g.Mandatory != mandatory {
+				continue
+			}
+			if !printed {
+				fmt.Fprintf(bw, "\n%s\n", heading)
+				printed = true
+			}
+			state := g.State()
+			fmt.Fprintf(bw, "  %-13s %s", state, qualifiedName(g.Group.Module(), g.Group.Name()))
+			if state != mib.GroupUnverifiable {
+				fmt.Fprintf(bw, " (%d/%d)", len(g.Present), len(g.Present)+len(g.Missing))
+			}
+			fmt.Fprintln(bw)
+			if len(g.Missing) > 0 && state != mib.GroupMissing {
+				fmt.Fprintf(bw, "  %13s missing: %s\n", "", objectNameList(g.Missing))
+			}
+			if full && len(g.Present) > 0 {
+				fmt.Fprintf(bw, "  %13s present: %s\n", "", objectNameList(g.Present))
+			}
+		}
+	}
+
+	if len(r.Violations) > 0 {
+		fmt.Fprintf(bw, "\nViolations:\n")
+		for _, v := range r.Violations {
+			fmt.Fprintf(bw, "  %s = %s: %s\n", annotateName(m, v.Object, v.OID), v.Object.FormatValue(v.Value), v.Reason)
+		}
+	}
+	if len(r.Unresolved) > 0 {
+		fmt.Fprintf(bw, "\nUnresolved references: %s\n", strings.Join(r.Unresolved, ", "))
+	}
+	return bw.Flush()
+}
+
+type complianceJSON struct {
+	Compliance string                    `json:"compliance"`
+	Compliant  bool                      `json:"compliant"`
+	VarBinds   int                       `json:"varbinds"`
+	Groups     []complianceGroupJSON     `json:"groups"`
+	Violations []complianceViolationJSON `json:"violations,omitempty"`
+	Unresolved []string                  `json:"unresolved,omitempty"`
+}
+
+type complianceGroupJSON struct {
+	Group     string   `json:"group"`
+	Mandatory bool     `json:"mandatory"`
+	State     string   `json:"state"`
+	Present   []string `json:"present,omitempty"`
+	Missing   []string `json:"missing,omitempty"`
+}
+
+type complianceViolationJSON struct {
+	Object string `json:"object"`
+	OID    string `json:"oid"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+func writeComplianceJSON(w io.Writer, r *mib.ComplianceReport, count int) error {
+	out := complianceJSON{
+		Compliance: qualifiedName(r.Compliance.Module(), r.Compliance.Name()),
+		Compliant:  r.Compliant(),
+		VarBinds:   count,
+		Groups:     []complianceGroupJSON{},
+		Unresolved: r.Unresolved,
+	}
+	for _, g := range r.Groups {
+		out.Groups = append(out.Groups, complianceGroupJSON{
+			Group:     qualifiedName(g.Group.Module(), g.Group.Name()),
+			Mandatory: g.Mandatory,
+			State:     g.State().String(),
+			Present:   objectNames(g.Present),
+			Missing:   objectNames(g.Missing),
+		})
+	}
+	for _, v := range r.Violations {
+		out.Violations = append(out.Violations, complianceViolationJSON{
+			Object: qualifiedName(v.Object.Module(), v.Object.Name()),
+			OID:    v.OID.String(),
+			Value:  v.Object.FormatValue(v.Value),
+			Reason: v.Reason,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func qualifiedName(mod *mib.Module, name string) string {
+	if mod == nil {
+		return name
+	}
+	return mod.Name() + "::" + name
+}
+
+func objectNames(objs []*mib.Object) []string {
+	if len(objs) == 0 {
+		return nil
+	}
+	names := make([]string, len(objs))
+	for i, o := range objs {
+		names[i] = o.Name()
+	}
+	return names
+}
+
+func objectNameList(objs []*mib.Object) string {
+	return strings.Join(objectNames(objs), ", ")
+}
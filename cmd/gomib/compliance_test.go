@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+// complianceWalk implements every object of ifGeneralInformationGroup
+// for one interface, with ifAdminStatus outside the ifCompliance3
+// refinement.
+const complianceWalk = `.1.3.6.1.2.1.2.1.0 = INTEGER: 1
+.1.3.6.1.2.1.2.2.1.1.1 = INTEGER: 1
+.1.3.6.1.2.1.2.2.1.2.1 = STRING: "eth0"
+.1.3.6.1.2.1.2.2.1.3.1 = INTEGER: ethernetCsmacd(6)
+.1.3.6.1.2.1.2.2.1.5.1 = Gauge32: 1000000000
+.1.3.6.1.2.1.2.2.1.6.1 = Hex-STRING: 00 11 22 33 44 55
+.1.3.6.1.2.1.2.2.1.7.1 = INTEGER: testing(3)
+.1.3.6.1.2.1.2.2.1.8.1 = INTEGER: up(1)
+.1.3.6.1.2.1.2.2.1.9.1 = Timeticks: (0) 0:00:00.00
+.1.3.6.1.2.1.31.1.1.1.1.1 = STRING: "eth0"
+.1.3.6.1.2.1.31.1.1.1.14.1 = INTEGER: enabled(1)
+.1.3.6.1.2.1.31.1.1.1.15.1 = Gauge32: 1000
+.1.3.6.1.2.1.31.1.1.1.17.1 = INTEGER: true(1)
+.1.3.6.1.2.1.31.1.1.1.18.1 = STRING: "uplink"
+.1.3.6.1.2.1.31.1.5.0 = Timeticks: (0) 0:00:00.00
+`
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	testutil.NoError(t, os.WriteFile(path, []byte(content), 0o644), "write %s", name)
+	return path
+}
+
+func TestReadVarBinds(t *testing.T) {
+	t.Run("walk", func(t *testing.T) {
+		path := writeTestFile(t, "walk.txt", `.1.3.6.1.2.1.1.5.0 = STRING: "router1"
+  continuation line
+.1.3.6.1.2.1.2.2.1.7.1 = INTEGER: bogus
+garbage
+.1.3.6.1.2.1.2.2.1.10.1 = Counter32: 1234
+`)
+		vbs, err := readVarBinds(path, inputAuto)
+		testutil.NoError(t, err, "readVarBinds")
+		testutil.Len(t, vbs, 3, "varbinds")
+		testutil.Equal(t, "1.3.6.1.2.1.1.5.0", vbs[0].OID.String(), "first OID")
+		testutil.Equal(t, "router1", string(vbs[0].Value.([]byte)), "string value")
+		testutil.Nil(t, vbs[1].Value, "undecodable value")
+		testutil.Equal(t, any(uint64(1234)), vbs[2].Value, "counter value")
+	})
+
+	t.Run("snmprec", func(t *testing.T) {
+		path := writeTestFile(t, "device.snmprec", "1.3.6.1.2.1.1.5.0|4|router1\n1.3.6.1.2.1.2.2.1.6.1|4x|001122334455\nnot a record\n")
+		vbs, err := readVarBinds(path, inputSnmprec)
+		testutil.NoError(t, err, "readVarBinds")
+		testutil.Len(t, vbs, 2, "varbinds")
+		testutil.Equal(t, "\x00\x11\x22\x33\x44\x55", string(vbs[1].Value.([]byte)), "hex value")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readVarBinds(filepath.Join(t.TempDir(), "nope.txt"), inputAuto)
+		testutil.Error(t, err, "readVarBinds")
+	})
+}
+
+func TestComplianceReportOutput(t *testing.T) {
+	m := loadTestMib(t, "IF-MIB")
+	comp := m.Compliance("ifCompliance3")
+	testutil.NotNil(t, comp, "ifCompliance3")
+
+	walk := strings.ReplaceAll(complianceWalk, `.1.3.6.1.2.1.31.1.1.1.18.1 = STRING: "uplink"`+"\n", "")
+	vbs, err := readVarBinds(writeTestFile(t, "walk.txt", walk), inputWalk)
+	testutil.NoError(t, err, "readVarBinds")
+	report := m.CheckCompliance(comp, vbs)
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		testutil.NoError(t, writeComplianceText(&out, m, report, len(vbs), false), "writeComplianceText")
+		got := out.String()
+		for _, want := range []string{
+			"IF-MIB::ifCompliance3: NOT COMPLIANT (14 varbinds)\n",
+			"\nMandatory groups:\n  partial       IF-MIB::ifGeneralInformationGroup (14/15)\n",
+			"                missing: ifAlias\n",
+			"  unverifiable  IF-MIB::linkUpDownNotificationsGroup\n",
+			"\nConditional groups:\n",
+			"  missing       IF-MIB::ifFixedLengthGroup (0/",
+			"\nViolations:\n  IF-MIB::ifAdminStatus[ifIndex=1] = testing(3): value 3 is not an enumeration allowed by the compliance\n",
+		} {
+			testutil.Contains(t, got, want, "text output")
+		}
+		testutil.False(t, strings.Contains(got, "present:"), "present objects listed without --full")
+		testutil.Greater(t, strings.Index(got, "ifFixedLengthGroup"), strings.Index(got, "Conditional groups:"),
+			"conditional group listed under its heading")
+
+		out.Reset()
+		testutil.NoError(t, writeComplianceText(&out, m, report, len(vbs), true), "writeComplianceText --full")
+		testutil.Contains(t, out.String(), "                present: ifIndex, ifDescr,", "present objects with --full")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		testutil.NoError(t, writeComplianceJSON(&out, report, len(vbs)), "writeComplianceJSON")
+		var got complianceJSON
+		testutil.NoError(t, json.Unmarshal(out.Bytes(), &got), "unmarshal")
+
+		testutil.Equal(t, "IF-MIB::ifCompliance3", got.Compliance, "compliance")
+		testutil.False(t, got.Compliant, "compliant")
+		testutil.Equal(t, 14, got.VarBinds, "varbinds")
+		testutil.Equal(t, len(report.Groups), len(got.Groups), "groups")
+		g := got.Groups[0]
+		testutil.Equal(t, "IF-MIB::ifGeneralInformationGroup", g.Group, "first group")
+		testutil.True(t, g.Mandatory, "mandatory")
+		testutil.Equal(t, "partial", g.State, "state")
+		testutil.SliceEqual(t, []string{"ifAlias"}, g.Missing, "missing")
+		testutil.Len(t, got.Violations, 1, "violations")
+		testutil.Equal(t, complianceViolationJSON{
+			Object: "IF-MIB::ifAdminStatus",
+			OID:    "1.3.6.1.2.1.2.2.1.7.1",
+			Value:  "testing(3)",
+			Reason: "value 3 is not an enumeration allowed by the compliance",
+		}, got.Violations[0], "violation")
+	})
+}
+
+func TestCmdComplianceExitStatus(t *testing.T) {
+	stdout, stderr := os.Stdout, os.Stderr
+	devnull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	testutil.NoError(t, err, "open %s", os.DevNull)
+	os.Stdout, os.Stderr = devnull, devnull
+	t.Cleanup(func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		devnull.Close()
+	})
+
+	compliant := writeTestFile(t, "compliant.txt",
+		strings.ReplaceAll(complianceWalk, "INTEGER: testing(3)", "INTEGER: up(1)"))
+	violating := writeTestFile(t, "violating.txt", complianceWalk)
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"compliant", []string{"IF-MIB::ifCompliance3", compliant}, exitOK},
+		{"compliant json", []string{"--format", "json", "IF-MIB::ifCompliance3", compliant}, exitOK},
+		{"unqualified with -m and two files", []string{"-m", "IF-MIB", "ifCompliance3", compliant, compliant}, exitOK},
+		{"violation", []string{"IF-MIB::ifCompliance3", violating}, exitStrictViolation},
+		{"unknown compliance", []string{"IF-MIB::noSuchCompliance", compliant}, exitError},
+		{"missing walk file", []string{"IF-MIB::ifCompliance3", compliant + ".missing"}, exitError},
+		{"no walk file", []string{"IF-MIB::ifCompliance3"}, exitError},
+		{"bad input format", []string{"--input", "csv", "IF-MIB::ifCompliance3", compliant}, exitError},
+		{"bad output format", []string{"--format", "xml", "IF-MIB::ifCompliance3", compliant}, exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &cli{paths: []string{"../../testdata/corpus/primary"}}
+			testutil.Equal(t, tt.want, c.cmdCompliance(tt.args), "exit status")
+		})
+	}
+}
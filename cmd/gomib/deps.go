@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+const depsUsage = `gomib deps - Show the module import graph
+
+Usage:
+  gomib deps [options] [MODULE...]
+
+Loads the named modules (or all modules) and shows what they import,
+directly and transitively. Modules that are imported but could not be
+found are marked missing, with the import chain that needs them.
+
+Options:
+  --format FMT   Output format: text, dot, mermaid, json (default: text)
+  --reverse      Show dependents (who imports MODULE) instead
+  -h, --help     Show help
+
+Examples:
+  gomib deps IF-MIB
+  gomib deps --reverse -p ./mibs SNMPv2-TC
+  gomib deps --format dot VENDOR-MIB | dot -Tsvg > deps.svg
+  gomib deps --format mermaid IF-MIB
+`
+
+func (c *cli) cmdDeps(args []string) int {
+	fs := flag.NewFlagSet("deps", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, depsUsage) }
+
+	format := fs.String("format", formatText, "output format: text, dot, mermaid, json")
+	reverse := fs.Bool("reverse", false, "show dependents instead of dependencies")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, depsUsage)
+		return 0
+	}
+
+	switch *format {
+	case formatText, "dot", "mermaid", formatJSON:
+	default:
+		printError("unknown format: %s", *format)
+		return exitError
+	}
+
+	modules := fs.Args()
+	loadList := modules
+	if *reverse {
+		// Dependents can be anywhere on the search path.
+		loadList = nil
+	}
+	m, err := c.loadMib(loadList)
+	if err != nil {
+		printError("failed to load: %v", err)
+		return exitError
+	}
+	g := m.DependencyGraph()
+
+	for _, name := range modules {
+		if m.Module(name) == nil {
+			printError("module not found: %s", name)
+			return exitError
+		}
+	}
+	roots := modules
+	if len(roots) == 0 {
+		roots = depsRoots(g, *reverse)
+	}
+	edges := g.Dependencies
+	if *reverse {
+		edges = g.Dependents
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	switch *format {
+	case formatText:
+		writeDepsText(w, g, roots, edges, *reverse)
+	case "dot":
+		writeDepsDOT(w, g, roots, edges)
+	case "mermaid":
+		writeDepsMermaid(w, g, roots, edges)
+	case formatJSON:
+		err = writeDepsJSON(w, g, roots, edges)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// depsRoots returns the modules nothing else depends on (or, in reverse
+// mode, that depend on nothing), so that every module appears in a tree.
+func depsRoots(g *mib.DependencyGraph, reverse bool) []string {
+	var roots []string
+	for _, name := range g.Modules() {
+		if reverse && len(g.Dependencies(name)) == 0 || !reverse && len(g.Dependents(name)) == 0 {
+			roots = append(roots, name)
+		}
+	}
+	return roots
+}
+
+// depsReachable returns the modules reachable from roots, in a stable
+// breadth-first order.
+func depsReachable(roots []string, edges func(string) []string) []string {
+	seen := make(map[string]bool)
+	var order []string
+	queue := slices.Clone(roots)
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		order = append(order, cur)
+		queue = append(queue, edges(cur)...)
+	}
+	return order
+}
+
+func depsLabel(g *mib.DependencyGraph, name string) string {
+	switch {
+	case g.IsMissing(name):
+		return name + " [missing]"
+	case len(g.UnresolvedImports(name)) > 0:
+		return name + " (unresolved: " + strings.Join(g.UnresolvedImports(name), ", ") + ")"
+	}
+	return name
+}
+
+func writeDepsText(w io.Writer, g *mib.DependencyGraph, roots []string, edges func(string) []string, reverse bool) {
+	expanded := make(map[string]bool)
+	var walk func(name string, depth int)
+	walk = func(name string, depth int) {
+		indent := strings.Repeat("  ", depth)
+		children := edges(name)
+		if expanded[name] && len(children) > 0 {
+			fmt.Fprintf(w, "%s%s ...\n", indent, depsLabel(g, name))
+			return
+		}
+		expanded[name] = true
+		fmt.Fprintf(w, "%s%s\n", indent, depsLabel(g, name))
+		for _, child := range children {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+
+	if !reverse {
+		var chains []string
+		for _, missing := range g.Missing() {
+			for _, root := range roots {
+				if path := g.Path(root, missing); path != nil {
+					chains = append(chains, strings.Join(path, " -> "))
+					break
+				}
+			}
+		}
+		if len(chains) > 0 {
+			fmt.Fprintf(w, "\nMissing modules:\n")
+			for _, chain := range chains {
+				fmt.Fprintf(w, "  %s\n", chain)
+			}
+		}
+	}
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		fmt.Fprintf(w, "\nImport cycles:\n")
+		for _, cycle := range cycles {
+			fmt.Fprintf(w, "  %s\n", strings.Join(cycle, " <-> "))
+		}
+	}
+}
+
+func writeDepsDOT(w io.Writer, g *mib.DependencyGraph, roots []string, edges func(string) []string) {
+	fmt.Fprintln(w, "digraph deps {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box, fontname=\"Helvetica\"];")
+	for _, name := range depsReachable(roots, edges) {
+		if g.IsMissing(name) {
+			fmt.Fprintf(w, "  %q [color=red, style=\"filled,dashed\", fillcolor=\"#fde2e2\"];\n", name)
+		} else if len(g.UnresolvedImports(name)) > 0 {
+			fmt.Fprintf(w, "  %q [color=orange];\n", name)
+		}
+		for _, dep := range edges(name) {
+			fmt.Fprintf(w, "  %q -> %q;\n", name, dep)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func writeDepsMermaid(w io.Writer, g *mib.DependencyGraph, roots []string, edges func(string) []string) {
+	id := func(name string) string {
+		return strings.NewReplacer("-", "_", ".", "_").Replace(name)
+	}
+	fmt.Fprintln(w, "graph LR")
+	var missing []string
+	for _, name := range depsReachable(roots, edges) {
+		if g.IsMissing(name) {
+			missing = append(missing, id(name))
+		}
+		children := edges(name)
+		if len(children) == 0 {
+			fmt.Fprintf(w, "  %s[\"%s\"]\n", id(name), name)
+		}
+		for _, dep := range children {
+			fmt.Fprintf(w, "  %s[\"%s\"] --> %s[\"%s\"]\n", id(name), name, id(dep), dep)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintln(w, "  classDef missing fill:#fde2e2,stroke:#c00,stroke-dasharray:4")
+		fmt.Fprintf(w, "  class %s missing\n", strings.Join(missing, ","))
+	}
+}
+
+type depsModuleJSON struct {
+	Name       string   `json:"name"`
+	Imports    []string `json:"imports,omitempty"`
+	Dependents []string `json:"dependents,omitempty"`
+	Missing    bool     `json:"missing,omitempty"`
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+type depsJSON struct {
+	Roots   []string         `json:"roots"`
+	Modules []depsModuleJSON `json:"modules"`
+	Order   []string         `json:"order"`
+	Missing []string         `json:"missing,omitempty"`
+	Cycles  [][]string       `json:"cycles,omitempty"`
+}
+
+func writeDepsJSON(w io.Writer, g *mib.DependencyGraph, roots []string, edges func(string) []string) error {
+	reachable := depsReachable(roots, edges)
+	out := depsJSON{Roots: roots, Modules: []depsModuleJSON{}, Order: []string{}}
+	inSet := make(map[string]bool)
+	for _, name := range reachable {
+		inSet[name] = true
+		out.Modules = append(out.Modules, depsModuleJSON{
+			Name:       name,
+			Imports:    g.Dependencies(name),
+			Dependents: g.Dependents(name),
+			Missing:    g.IsMissing(name),
+			Unresolved: g.UnresolvedImports(name),
+		})
+		if g.IsMissing(name) {
+			out.Missing = append(out.Missing, name)
+		}
+	}
+	slices.SortFunc(out.Modules, func(a, b depsModuleJSON) int { return strings.Compare(a.Name, b.Name) })
+	slices.Sort(out.Missing)
+	for _, name := range g.Order() {
+		if inSet[name] {
+			out.Order = append(out.Order, name)
+		}
+	}
+	for _, cycle := range g.Cycles() {
+		if slices.ContainsFunc(cycle, func(n string) bool { return inSet[n] }) {
+			out.Cycles = append(out.Cycles, cycle)
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
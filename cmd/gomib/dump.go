@@ -9,13 +9,20 @@ import (
 	"github.com/golangsnmp/gomib/mib"
 )
 
-const dumpUsage = `gomib dump - Output modules or subtrees as JSON
+const dumpUsage = `gomib dump - Output modules or subtrees as JSON or smidump formats
 
 Usage:
   gomib dump [options] MODULE...
 
 Options:
-  -o, --oid OID          Dump subtree starting at OID
+  --format FORMAT        Output format (default: json):
+                           json         gomib's JSON model
+                           tree         registration tree (smidump -f tree)
+                           identifiers  identifier list (smidump -f identifiers)
+                           types        type derivation tree (smidump -f types)
+                           xml          SMI XML schema (smidump -f xml)
+                           metrics      definition statistics (smidump -f metrics)
+  -o, --oid OID          Dump subtree starting at OID (json only)
   --compact              Minified JSON (no indentation)
   --no-tree              Omit tree structure from output
   --no-descriptions      Omit description fields (smaller output)
@@ -26,6 +33,8 @@ Examples:
   gomib dump -o 1.3.6.1.2.1.2 IF-MIB
   gomib dump --compact IF-MIB
   gomib dump IF-MIB | jq '.objects'
+  gomib dump --format tree IF-MIB
+  gomib dump --format xml IF-MIB > IF-MIB.xml
 `
 
 func (c *cli) cmdDump(args []string) int {
@@ -34,6 +43,7 @@ func (c *cli) cmdDump(args []string) int {
 
 	oidFilter := fs.String("o", "", "dump subtree starting at OID")
 	fs.StringVar(oidFilter, "oid", "", "dump subtree starting at OID")
+	format := fs.String("format", "json", "output format")
 	compact := fs.Bool("compact", false, "minified JSON")
 	noTree := fs.Bool("no-tree", false, "omit tree structure")
 	noDescriptions := fs.Bool("no-descriptions", false, "omit descriptions")
@@ -56,12 +66,34 @@ func (c *cli) cmdDump(args []string) int {
 		return 1
 	}
 
+	smidump, ok := smidumpFormats[*format]
+	if !ok && *format != "json" {
+		printError("unknown format: %s", *format)
+		return 1
+	}
+	if ok && *oidFilter != "" {
+		printError("--oid applies only to the json format")
+		return 1
+	}
+
 	m, err := c.loadMib(modules)
 	if err != nil {
 		printError("failed to load: %v", err)
 		return exitError
 	}
 
+	if smidump != nil {
+		for _, name := range modules {
+			mod := m.Module(name)
+			if mod == nil {
+				printError("module not found: %s", name)
+				return exitError
+			}
+			smidump(os.Stdout, m, mod)
+		}
+		return 0
+	}
+
 	opts := JSONOptions{
 		Compact:       *compact,
 		IncludeTree:   !*noTree,
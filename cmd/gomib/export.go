@@ -0,0 +1,307 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/golangsnmp/gomib/cmd/internal/cliutil"
+	"github.com/golangsnmp/gomib/export"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+const exportUsage = `gomib export - Generate configuration for external tools
+
+Usage:
+  gomib export FORMAT [options] SELECTOR...
+
+Formats:
+  prometheus   snmp_exporter generator.yml module (or snmp.yml with --snmp-yml)
+  otel         OpenTelemetry Collector snmpreceiver configuration
+  jsonschema   JSON Schema document with one schema per row and scalar group
+  openapi      OpenAPI 3.1 document with the same schemas as components
+
+Selectors name the tables and scalars to export:
+  ifTable, IF-MIB::ifXTable   an object (a row selects its table)
+  IF-MIB                      every table and scalar in a module
+  1.3.6.1.2.1.31              every table and scalar in an OID subtree
+
+Options:
+  -m, --module MODULE      Module to load (repeatable; default: modules named by
+                           selectors, or all modules if none are)
+  -o, --output FILE        Write to FILE instead of stdout
+  -h, --help               Show help
+
+Prometheus options:
+  --snmp-yml               Emit a fully expanded snmp.yml module instead of generator.yml
+  --name NAME              Module name (default: first object's module, e.g. if_mib)
+  --lookup INDEX=COLUMN    Add an index lookup (repeatable; INDEX may be comma-separated)
+  --drop-source-indexes    Drop the source index labels of --lookup lookups
+  --auto-lookups           Add Name/Descr lookups for indexes such as ifIndex
+  --enum-type TYPE         Enum value type: EnumAsStateSet, EnumAsInfo or gauge
+
+OTel options:
+  --receiver ID            Receiver ID (default: snmp)
+  --endpoint URL           Agent endpoint (default: udp://localhost:161)
+  --snmp-version VERSION   SNMP version: v1, v2c or v3 (default: v2c)
+  --community STRING       Community string (default: public)
+  --interval DURATION      Collection interval (default: 60s)
+  --resources              Map table indexes to resource attributes
+
+JSON Schema / OpenAPI options:
+  --title TITLE            Document title (default: first object's module)
+  --version VERSION        OpenAPI info.version (default: module LAST-UPDATED)
+
+Examples:
+  gomib export prometheus -m IF-MIB ifTable ifXTable
+  gomib export prometheus --lookup ifIndex=ifAlias IF-MIB::ifXTable
+  gomib export prometheus --snmp-yml --auto-lookups -o snmp.yml IF-MIB
+  gomib export otel -m IF-MIB -m ENTITY-MIB ifXTable entPhysicalTable
+  gomib export openapi -m IF-MIB --title "Interfaces API" ifTable ifXTable
+`
+
+func (c *cli) cmdExport(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		if c.helpFlag || (len(args) > 0 && (args[0] == "-h" || args[0] == "--help")) {
+			_, _ = fmt.Fprint(os.Stdout, exportUsage)
+			return 0
+		}
+		printError("no export format specified")
+		fmt.Fprint(os.Stderr, exportUsage)
+		return 1
+	}
+	format, args := args[0], args[1:]
+
+	switch format {
+	case "prometheus":
+		return c.exportPrometheus(args)
+	case "otel":
+		return c.exportOTel(args)
+	case "jsonschema", "openapi":
+		return c.exportJSONSchema(args, format == "openapi")
+	default:
+		printError("unknown export format: %s", format)
+		fmt.Fprint(os.Stderr, exportUsage)
+		return 1
+	}
+}
+
+type lookupList []export.PrometheusLookup
+
+func (l *lookupList) String() string { return fmt.Sprintf("%v", *l) }
+func (l *lookupList) Set(value string) error {
+	idx, col, ok := strings.Cut(value, "=")
+	if !ok || idx == "" || col == "" {
+		return fmt.Errorf("expected INDEX=COLUMN, got %q", value)
+	}
+	*l = append(*l, export.PrometheusLookup{
+		SourceIndexes: strings.Split(idx, ","),
+		Lookup:        col,
+	})
+	return nil
+}
+
+func (c *cli) exportPrometheus(args []string) int {
+	fs := flag.NewFlagSet("export prometheus", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, exportUsage) }
+
+	var modules moduleList
+	fs.Var(&modules, "m", "module to load")
+	fs.Var(&modules, "module", "module to load")
+	output := fs.String("o", "", "output file")
+	fs.StringVar(output, "output", "", "output file")
+	snmpYml := fs.Bool("snmp-yml", false, "emit snmp.yml")
+	name := fs.String("name", "", "module name")
+	var lookups lookupList
+	fs.Var(&lookups, "lookup", "index lookup INDEX=COLUMN")
+	dropSource := fs.Bool("drop-source-indexes", false, "drop lookup source indexes")
+	autoLookups := fs.Bool("auto-lookups", false, "add Name/Descr lookups")
+	enumType := fs.String("enum-type", "", "enum value type")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, exportUsage)
+		return 0
+	}
+
+	m, objs, code := c.exportSelection(modules, fs.Args())
+	if code >= 0 {
+		return code
+	}
+
+	if *dropSource {
+		for i := range lookups {
+			lookups[i].DropSourceIndexes = true
+		}
+	}
+	opts := export.PrometheusOptions{
+		Module:      *name,
+		Lookups:     lookups,
+		AutoLookups: *autoLookups,
+		EnumType:    *enumType,
+	}
+
+	write := export.WritePrometheusGenerator
+	if *snmpYml {
+		write = export.WritePrometheusSnmp
+	}
+	return writeExport(*output, func(w io.Writer) error {
+		return write(w, m, objs, opts)
+	})
+}
+
+func (c *cli) exportOTel(args []string) int {
+	fs := flag.NewFlagSet("export otel", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, exportUsage) }
+
+	var modules moduleList
+	fs.Var(&modules, "m", "module to load")
+	fs.Var(&modules, "module", "module to load")
+	output := fs.String("o", "", "output file")
+	fs.StringVar(output, "output", "", "output file")
+	receiver := fs.String("receiver", "", "receiver ID")
+	endpoint := fs.String("endpoint", "", "agent endpoint")
+	version := fs.String("snmp-version", "", "SNMP version")
+	community := fs.String("community", "", "community string")
+	interval := fs.String("interval", "", "collection interval")
+	resources := fs.Bool("resources", false, "map indexes to resource attributes")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, exportUsage)
+		return 0
+	}
+
+	m, objs, code := c.exportSelection(modules, fs.Args())
+	if code >= 0 {
+		return code
+	}
+
+	opts := export.OTelOptions{
+		Receiver:           *receiver,
+		Endpoint:           *endpoint,
+		Version:            *version,
+		Community:          *community,
+		CollectionInterval: *interval,
+		ResourceIndexes:    *resources,
+	}
+	return writeExport(*output, func(w io.Writer) error {
+		return export.WriteOTelReceiver(w, m, objs, opts)
+	})
+}
+
+func (c *cli) exportJSONSchema(args []string, openAPI bool) int {
+	fs := flag.NewFlagSet("export jsonschema", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, exportUsage) }
+
+	var modules moduleList
+	fs.Var(&modules, "m", "module to load")
+	fs.Var(&modules, "module", "module to load")
+	output := fs.String("o", "", "output file")
+	fs.StringVar(output, "output", "", "output file")
+	title := fs.String("title", "", "document title")
+	version := fs.String("version", "", "OpenAPI info.version")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, exportUsage)
+		return 0
+	}
+
+	m, objs, code := c.exportSelection(modules, fs.Args())
+	if code >= 0 {
+		return code
+	}
+
+	opts := export.JSONSchemaOptions{OpenAPI: openAPI, Title: *title, Version: *version}
+	return writeExport(*output, func(w io.Writer) error {
+		return export.WriteJSONSchema(w, m, objs, opts)
+	})
+}
+
+// exportSelection loads the MIB and resolves the selectors. It returns
+// an exit code of -1 on success.
+func (c *cli) exportSelection(modules, selectors []string) (*mib.Mib, []*mib.Object, int) {
+	if len(selectors) == 0 {
+		printError("no selectors specified")
+		fmt.Fprint(os.Stderr, exportUsage)
+		return nil, nil, 1
+	}
+
+	m, err := c.loadMib(exportModules(modules, selectors))
+	if err != nil {
+		printError("failed to load: %v", err)
+		return nil, nil, exitError
+	}
+
+	objs, err := export.Select(m, selectors...)
+	if err != nil {
+		printError("%v", err)
+		return nil, nil, exitError
+	}
+	return m, objs, -1
+}
+
+// writeExport runs write against the output file, or stdout.
+func writeExport(output string, write func(io.Writer) error) int {
+	out, closeOut, err := cliutil.GetOutput(output)
+	if err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	defer closeOut()
+
+	if err := write(out); err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// exportModules returns the modules to load for an export: the -m
+// modules, or else the modules named by the selectors themselves.
+func exportModules(modules, selectors []string) []string {
+	if len(modules) > 0 {
+		return modules
+	}
+	var out []string
+	for _, sel := range selectors {
+		if mod, _, ok := strings.Cut(sel, "::"); ok {
+			out = append(out, mod)
+		} else if looksLikeModule(sel) {
+			out = append(out, sel)
+		}
+	}
+	return out
+}
+
+// looksLikeModule reports whether s has the shape of a module name:
+// upper-case letters, digits and hyphens.
+func looksLikeModule(s string) bool {
+	if s == "" || s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
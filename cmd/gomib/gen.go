@@ -0,0 +1,199 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golangsnmp/gomib/export"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+const genUsage = `gomib gen - Generate source code from MIB modules
+
+Usage:
+  gomib gen LANGUAGE [options] MODULE...
+
+Languages:
+  go           Go structs, enums, index parsers and OID constants
+  proto        proto3 messages and enums, field numbers from column arcs
+  yang         YANG 1.1 modules translated per RFC 6643, one per MIB module
+
+Options:
+  -o, --output FILE    Write to FILE instead of stdout
+  --package NAME       Package name (default: derived from the first module,
+                       e.g. ifmib for Go, if_mib for proto)
+  --go-package PATH    proto: option go_package
+  --dir DIR            yang: write each module to DIR/MODULE.yang
+  -h, --help           Show help
+
+Examples:
+  gomib gen go IF-MIB > ifmib/ifmib.go
+  gomib gen go --package mibs -o mibs/mibs.go IF-MIB ENTITY-MIB
+  gomib gen proto --go-package example.com/pb/ifmib -o if_mib.proto IF-MIB
+  gomib gen yang IF-MIB > IF-MIB.yang
+  gomib gen yang --dir yang IF-MIB IP-MIB ENTITY-MIB
+`
+
+func (c *cli) cmdGen(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		if c.helpFlag || (len(args) > 0 && (args[0] == "-h" || args[0] == "--help")) {
+			_, _ = fmt.Fprint(os.Stdout, genUsage)
+			return 0
+		}
+		printError("no language specified")
+		fmt.Fprint(os.Stderr, genUsage)
+		return 1
+	}
+	lang, args := args[0], args[1:]
+
+	switch lang {
+	case "go":
+		return c.genGo(args)
+	case "proto":
+		return c.genProto(args)
+	case "yang":
+		return c.genYang(args)
+	default:
+		printError("unknown language: %s", lang)
+		fmt.Fprint(os.Stderr, genUsage)
+		return 1
+	}
+}
+
+func (c *cli) genGo(args []string) int {
+	fs := flag.NewFlagSet("gen go", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, genUsage) }
+
+	output := fs.String("o", "", "output file")
+	fs.StringVar(output, "output", "", "output file")
+	pkg := fs.String("package", "", "package name")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, genUsage)
+		return 0
+	}
+
+	mods, code := c.genModules(fs.Args())
+	if code >= 0 {
+		return code
+	}
+	opts := export.GoOptions{Package: *pkg}
+	return writeExport(*output, func(w io.Writer) error {
+		return export.WriteGo(w, mods, opts)
+	})
+}
+
+func (c *cli) genProto(args []string) int {
+	fs := flag.NewFlagSet("gen proto", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, genUsage) }
+
+	output := fs.String("o", "", "output file")
+	fs.StringVar(output, "output", "", "output file")
+	pkg := fs.String("package", "", "proto package")
+	goPkg := fs.String("go-package", "", "go_package option")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, genUsage)
+		return 0
+	}
+
+	mods, code := c.genModules(fs.Args())
+	if code >= 0 {
+		return code
+	}
+	opts := export.ProtoOptions{Package: *pkg, GoPackage: *goPkg}
+	return writeExport(*output, func(w io.Writer) error {
+		return export.WriteProto(w, mods, opts)
+	})
+}
+
+func (c *cli) genYang(args []string) int {
+	fs := flag.NewFlagSet("gen yang", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, genUsage) }
+
+	output := fs.String("o", "", "output file")
+	fs.StringVar(output, "output", "", "output file")
+	dir := fs.String("dir", "", "output directory")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, genUsage)
+		return 0
+	}
+
+	mods, code := c.genModules(fs.Args())
+	if code >= 0 {
+		return code
+	}
+	if *dir == "" {
+		if len(mods) > 1 {
+			printError("yang writes one module per file; use --dir for several modules")
+			return 1
+		}
+		return writeExport(*output, func(w io.Writer) error {
+			return export.WriteYANG(w, mods[0])
+		})
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	for _, mod := range mods {
+		path := filepath.Join(*dir, mod.Name()+".yang")
+		if code := writeExport(path, func(w io.Writer) error {
+			return export.WriteYANG(w, mod)
+		}); code != exitOK {
+			return code
+		}
+	}
+	return exitOK
+}
+
+// genModules loads the named modules. It returns an exit code of -1 on
+// success.
+func (c *cli) genModules(names []string) ([]*mib.Module, int) {
+	if len(names) == 0 {
+		printError("no modules specified")
+		fmt.Fprint(os.Stderr, genUsage)
+		return nil, 1
+	}
+
+	m, err := c.loadMib(names)
+	if err != nil {
+		printError("failed to load: %v", err)
+		return nil, exitError
+	}
+
+	mods := make([]*mib.Module, 0, len(names))
+	for _, name := range names {
+		mod := m.Module(name)
+		if mod == nil {
+			printError("module not found: %s", name)
+			return nil, exitError
+		}
+		mods = append(mods, mod)
+	}
+	return mods, -1
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"cmp"
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/golangsnmp/gomib"
 	"github.com/golangsnmp/gomib/mib"
@@ -19,6 +22,7 @@ Options:
   --permissive  Use permissive mode for vendor MIBs
   --level N     Set strictness level (0-6, lower is stricter)
   --stats       Show detailed statistics
+  --timing      Show resolver phase times and the slowest modules to parse
   -h, --help    Show help
 
 Strictness Levels:
@@ -35,6 +39,7 @@ Examples:
   gomib load --strict IF-MIB           # RFC compliance mode
   gomib load --permissive IF-MIB       # Vendor MIB mode
   gomib load --stats IF-MIB            # Show detailed stats
+  gomib load --timing IF-MIB           # Find slow files
 `
 
 func (c *cli) cmdLoad(args []string) int {
@@ -45,6 +50,7 @@ func (c *cli) cmdLoad(args []string) int {
 	permissive := fs.Bool("permissive", false, "use permissive mode for vendor MIBs")
 	level := fs.Int("level", -1, "set strictness level (0-6)")
 	stats := fs.Bool("stats", false, "show detailed statistics")
+	timing := fs.Bool("timing", false, "show phase and parse times")
 	help := fs.Bool("h", false, "show help")
 	fs.BoolVar(help, "help", false, "show help")
 
@@ -73,6 +79,16 @@ func (c *cli) cmdLoad(args []string) int {
 		opts = append(opts, gomib.WithStrictness(mib.StrictnessLevel(*level)))
 	}
 
+	var events []gomib.Event
+	if *timing {
+		opts = append(opts, gomib.WithProgress(func(e gomib.Event) {
+			switch e.Kind {
+			case gomib.EventModuleParsed, gomib.EventPhaseEnd, gomib.EventComplete:
+				events = append(events, e)
+			}
+		}))
+	}
+
 	m, loadErr := c.loadMibWithOpts(modules, opts...)
 	if loadErr != nil && m == nil {
 		printError("failed to load: %v", loadErr)
@@ -86,6 +102,10 @@ func (c *cli) cmdLoad(args []string) int {
 			len(m.Modules()), len(m.Types()), len(m.Objects()), len(m.Notifications()))
 	}
 
+	if *timing {
+		printTiming(events)
+	}
+
 	diags := m.Diagnostics()
 	hasSevere := false
 	hasErrors := false
@@ -163,6 +183,35 @@ func printDiagnostic(d mib.Diagnostic) {
 	}
 }
 
+// slowestModules is how many modules --timing lists.
+const slowestModules = 10
+
+func printTiming(events []gomib.Event) {
+	var parsed []gomib.Event
+	var parseTotal time.Duration
+	fmt.Println()
+	fmt.Println("Timing:")
+	for _, e := range events {
+		switch e.Kind {
+		case gomib.EventModuleParsed:
+			parsed = append(parsed, e)
+			parseTotal += e.Duration
+		case gomib.EventPhaseEnd:
+			fmt.Printf("  %-15s %10s  (%d)\n", e.Phase+":", e.Duration.Round(time.Microsecond), e.Count)
+		case gomib.EventComplete:
+			fmt.Printf("  %-15s %10s\n", "total:", e.Duration.Round(time.Microsecond))
+		}
+	}
+	fmt.Printf("  %-15s %10s  (%d modules)\n", "parsing:", parseTotal.Round(time.Microsecond), len(parsed))
+
+	slices.SortFunc(parsed, func(a, b gomib.Event) int { return cmp.Compare(b.Duration, a.Duration) })
+	fmt.Println()
+	fmt.Println("Slowest modules:")
+	for _, e := range parsed[:min(slowestModules, len(parsed))] {
+		fmt.Printf("  %10s  %8d bytes  %s\n", e.Duration.Round(time.Microsecond), e.Bytes, e.Module)
+	}
+}
+
 func printDetailedStats(m *mib.Mib) {
 	fmt.Println("Statistics:")
 	fmt.Printf("  Modules:        %d\n", len(m.Modules()))
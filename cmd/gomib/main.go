@@ -28,18 +28,28 @@ Usage:
   gomib <command> [options] [arguments]
 
 Commands:
-  load    Load and resolve MIB modules
-  lint    Check modules for issues (linter mode)
-  get     Query OID or name lookups
-  dump    Output modules or subtrees as JSON
-  trace   Trace symbol resolution for debugging
-  paths   Show MIB search paths
-  list    List available module names
-  find    Search for names across loaded MIBs
-  version Show version
+  load        Load and resolve MIB modules
+  lint        Check modules for issues (linter mode)
+  get         Query OID or name lookups
+  dump        Output modules or subtrees as JSON
+  trace       Trace symbol resolution for debugging
+  paths       Show MIB search paths
+  list        List available module names
+  find        Search for names across loaded MIBs
+  translate   Translate OIDs and names (snmptranslate-compatible)
+  annotate    Decode snmpwalk and snmprec files
+  compliance  Check a device walk against a MODULE-COMPLIANCE
+  deps        Show the module import graph
+  vendor      Copy the files needed to load modules into a directory
+  export      Generate configuration for external tools
+  gen         Generate source code from modules
+  version     Show version
 
 Common options:
   -p, --path PATH   Add MIB search path (repeatable)
+  --patch FILE      Apply a MIB patch file while loading (repeatable)
+  --remote URL      Fetch missing modules from an HTTP repository, e.g.
+                    https://mibs.example/asn1/{name} (repeatable)
   -v, --verbose     Enable debug logging
   -vv               Enable trace logging (implies -v)
   -h, --help        Show help
@@ -56,6 +66,8 @@ Examples:
 type cli struct {
 	verbose  int
 	paths    []string
+	patches  []string
+	remotes  []string
 	helpFlag bool
 }
 
@@ -93,6 +105,20 @@ func run() int {
 			c.paths = append(c.paths, arg[2:])
 		case strings.HasPrefix(arg, "--path="):
 			c.paths = append(c.paths, arg[7:])
+		case arg == "--patch":
+			if i+1 < len(args) {
+				i++
+				c.patches = append(c.patches, args[i])
+			}
+		case strings.HasPrefix(arg, "--patch="):
+			c.patches = append(c.patches, arg[len("--patch="):])
+		case arg == "--remote":
+			if i+1 < len(args) {
+				i++
+				c.remotes = append(c.remotes, args[i])
+			}
+		case strings.HasPrefix(arg, "--remote="):
+			c.remotes = append(c.remotes, arg[len("--remote="):])
 		case len(arg) > 0 && arg[0] == '-':
 			cmdArgs = append(cmdArgs, arg)
 		default:
@@ -131,6 +157,20 @@ func run() int {
 		return c.cmdList(cmdArgs)
 	case "find":
 		return c.cmdFind(cmdArgs)
+	case "translate":
+		return c.cmdTranslate(cmdArgs)
+	case "annotate":
+		return c.cmdAnnotate(cmdArgs)
+	case "compliance":
+		return c.cmdCompliance(cmdArgs)
+	case "deps":
+		return c.cmdDeps(cmdArgs)
+	case "vendor":
+		return c.cmdVendor(cmdArgs)
+	case "export":
+		return c.cmdExport(cmdArgs)
+	case "gen":
+		return c.cmdGen(cmdArgs)
 	case "version":
 		printVersion()
 		return 0
@@ -183,12 +223,35 @@ func (c *cli) buildSources() ([]gomib.Source, bool, error) {
 }
 
 func (c *cli) loadMibWithOpts(modules []string, extraOpts ...gomib.LoadOption) (*mib.Mib, error) {
+	opts, err := c.loadOptions(modules, extraOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return gomib.Load(context.Background(), opts...)
+}
+
+// loadOptions returns the Load options for the global flags, restricted
+// to modules when any are given.
+func (c *cli) loadOptions(modules []string, extraOpts ...gomib.LoadOption) ([]gomib.LoadOption, error) {
 	var opts []gomib.LoadOption
 
 	sources, useSystem, err := c.buildSources()
 	if err != nil {
 		return nil, err
 	}
+	if len(c.remotes) > 0 {
+		// Remote repositories are the last resort, after system paths.
+		if useSystem {
+			sources, useSystem = gomib.DiscoverSystemSources(), false
+		}
+		for _, u := range c.remotes {
+			src, err := gomib.Remote(u)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, src)
+		}
+	}
 	if useSystem {
 		opts = append(opts, gomib.WithSystemPaths())
 	} else {
@@ -198,20 +261,31 @@ func (c *cli) loadMibWithOpts(modules []string, extraOpts ...gomib.LoadOption) (
 	if logger := c.setupLogger(); logger != nil {
 		opts = append(opts, gomib.WithLogger(logger))
 	}
+	for _, path := range c.patches {
+		p, err := gomib.ReadPatch(path)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, gomib.WithPatches(p))
+	}
 	opts = append(opts, extraOpts...)
 
 	if len(modules) > 0 {
 		opts = append(opts, gomib.WithModules(modules...))
 	}
-	return gomib.Load(context.Background(), opts...)
+	return opts, nil
 }
 
 func printVersion() {
-	version := "(devel)"
+	fmt.Printf("gomib %s\n", buildVersion())
+}
+
+// buildVersion returns the module version gomib was built from.
+func buildVersion() string {
 	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
-		version = info.Main.Version
+		return info.Main.Version
 	}
-	fmt.Printf("gomib %s\n", version)
+	return "(devel)"
 }
 
 func printError(format string, args ...any) {
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// smidumpFormats are the dump formats modelled on smidump's output
+// drivers of the same name.
+var smidumpFormats = map[string]func(io.Writer, *mib.Mib, *mib.Module){
+	"tree":        writeSmiTree,
+	"identifiers": writeSmiIdentifiers,
+	"types":       writeSmiTypes,
+	"xml":         writeSmiXML,
+	"metrics":     writeSmiMetrics,
+}
+
+// smiHeader writes the comment line smidump starts its text formats with.
+func smiHeader(w io.Writer, mod *mib.Module, what string) {
+	fmt.Fprintf(w, "# %s %s (generated by gomib %s)\n\n", mod.Name(), what, buildVersion())
+}
+
+// smiModuleNodes returns the nodes defined by mod in OID order: OID
+// assignments, objects, notifications and conformance definitions.
+func smiModuleNodes(mod *mib.Module) []*mib.Node {
+	nodes := mod.Nodes()
+	for _, obj := range mod.Objects() {
+		nodes = append(nodes, obj.Node())
+	}
+	for _, n := range mod.Notifications() {
+		nodes = append(nodes, n.Node())
+	}
+	for _, g := range mod.Groups() {
+		nodes = append(nodes, g.Node())
+	}
+	for _, c := range mod.Compliances() {
+		nodes = append(nodes, c.Node())
+	}
+	for _, c := range mod.Capabilities() {
+		nodes = append(nodes, c.Node())
+	}
+	slices.SortFunc(nodes, func(a, b *mib.Node) int { return a.OID().Compare(b.OID()) })
+	return slices.Compact(nodes)
+}
+
+// smiModuleTypes returns the types defined by mod, leaving out the
+// SEQUENCE types of conceptual rows (which have no base type), as
+// libsmi does not record them.
+func smiModuleTypes(mod *mib.Module) []*mib.Type {
+	var types []*mib.Type
+	for _, t := range mod.Types() {
+		if b := t.EffectiveBase(); b != mib.BaseSequence && b != mib.BaseUnknown {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// smiTypeName returns the type name libsmi reports for an object: the
+// name of its textual convention or SMI type, or the libsmi base type
+// for an anonymous refinement such as an inline enumeration.
+func smiTypeName(obj *mib.Object) string {
+	t := obj.Type()
+	if t == nil {
+		return ""
+	}
+	if t.Parent() != nil || t.Name() != "" && smiApplicationType(t) {
+		if obj.EffectiveEnums() != nil && t.Enums() == nil && t.EffectiveEnums() == nil {
+			return "Enumeration"
+		}
+		return t.Name()
+	}
+	return smiBaseName(t, obj.EffectiveEnums() != nil)
+}
+
+// smiApplicationType reports whether t is an SMI application type
+// (Counter32, TimeTicks, ...), which libsmi keeps by name.
+func smiApplicationType(t *mib.Type) bool {
+	switch t.Name() {
+	case "Counter32", "Counter64", "Gauge32", "TimeTicks", "IpAddress", "Opaque", "Unsigned32", "Integer32",
+		"Counter", "Gauge", "NetworkAddress":
+		return true
+	}
+	return false
+}
+
+// smiBaseName returns the libsmi basetype name for t.
+func smiBaseName(t *mib.Type, enumerated bool) string {
+	switch t.EffectiveBase() {
+	case mib.BaseInteger32:
+		if enumerated {
+			return "Enumeration"
+		}
+		return "Integer32"
+	case mib.BaseOctetString, mib.BaseIpAddress, mib.BaseOpaque:
+		return "OctetString"
+	case mib.BaseObjectIdentifier:
+		return "ObjectIdentifier"
+	case mib.BaseBits:
+		return "Bits"
+	case mib.BaseCounter64:
+		return "Unsigned64"
+	case mib.BaseUnknown, mib.BaseSequence:
+		return "Unknown"
+	}
+	return "Unsigned32"
+}
+
+// smiAccessFlags returns the three-character access column of
+// smidump's tree format.
+func smiAccessFlags(a mib.Access) string {
+	switch a {
+	case mib.AccessAccessibleForNotify:
+		return "--n"
+	case mib.AccessReadOnly:
+		return "r-n"
+	case mib.AccessReadWrite, mib.AccessReadCreate:
+		return "rwn"
+	case mib.AccessWriteOnly:
+		return "-wn"
+	}
+	return "---"
+}
+
+// writeSmiTree writes the registration tree of mod: every node of the
+// module with the path of ancestors leading to it.
+func writeSmiTree(w io.Writer, m *mib.Mib, mod *mib.Module) {
+	smiHeader(w, mod, "registration tree")
+
+	keep := make(map[*mib.Node]bool)
+	for _, nd := range smiModuleNodes(mod) {
+		for p := nd; p != nil && !keep[p]; p = p.Parent() {
+			keep[p] = true
+		}
+	}
+	for _, top := range m.Root().Children() {
+		if keep[top] {
+			writeSmiTreeNode(w, top, "", keep, 0, true)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+func writeSmiTreeNode(w io.Writer, nd *mib.Node, prefix string, keep map[*mib.Node]bool, typeWidth int, root bool) {
+	branch := "+--"
+	if root {
+		branch = "--"
+	}
+	name := nd.Name()
+	if name == "" {
+		name = fmt.Sprint(nd.Arc())
+	}
+	obj := nd.Object()
+	switch {
+	case obj != nil && (obj.IsScalar() || obj.IsColumn()):
+		fmt.Fprintf(w, "%s+-- %s %-*s %s(%d)\n", prefix, smiAccessFlags(obj.Access()), typeWidth, smiTypeName(obj), name, nd.Arc())
+		return
+	case obj != nil && obj.IsRow():
+		var names []string
+		for _, idx := range obj.EffectiveIndexes() {
+			names = append(names, idx.Object.Name())
+		}
+		fmt.Fprintf(w, "%s%s%s(%d) [%s]\n", prefix, branch, name, nd.Arc(), strings.Join(names, ","))
+	case nd.Notification() != nil:
+		var names []string
+		for _, o := range nd.Notification().Objects() {
+			names = append(names, o.Name())
+		}
+		fmt.Fprintf(w, "%s%s%s(%d) [%s]\n", prefix, branch, name, nd.Arc(), strings.Join(names, ","))
+	case root:
+		fmt.Fprintf(w, "%s--%s(%s)\n", prefix, name, nd.OID())
+	default:
+		fmt.Fprintf(w, "%s+--%s(%d)\n", prefix, name, nd.Arc())
+	}
+
+	var children []*mib.Node
+	width := 0
+	for _, c := range nd.Children() {
+		if !keep[c] {
+			continue
+		}
+		children = append(children, c)
+		if o := c.Object(); o != nil && (o.IsScalar() || o.IsColumn()) {
+			width = max(width, len(smiTypeName(o)))
+		}
+	}
+	childPrefix := prefix + "  "
+	if !root {
+		childPrefix = prefix + "|  "
+		if isLastChild(nd, keep) {
+			childPrefix = prefix + "   "
+		}
+	}
+	for _, c := range children {
+		writeSmiTreeNode(w, c, childPrefix, keep, width, false)
+	}
+}
+
+// isLastChild reports whether nd is the last kept child of its parent.
+func isLastChild(nd *mib.Node, keep map[*mib.Node]bool) bool {
+	parent := nd.Parent()
+	if parent == nil {
+		return true
+	}
+	var last *mib.Node
+	for _, c := range parent.Children() {
+		if keep[c] {
+			last = c
+		}
+	}
+	return last == nd
+}
+
+// writeSmiIdentifiers writes one line per type and node defined by mod,
+// with its kind and OID.
+func writeSmiIdentifiers(w io.Writer, _ *mib.Mib, mod *mib.Module) {
+	smiHeader(w, mod, "list of identifiers")
+
+	type ident struct{ name, kind, oid string }
+	var idents []ident
+	for _, t := range smiModuleTypes(mod) {
+		idents = append(idents, ident{t.Name(), "type", ""})
+	}
+	for _, nd := range smiModuleNodes(mod) {
+		idents = append(idents, ident{nd.Name(), nd.Kind().String(), nd.OID().String()})
+	}
+	width := 0
+	for _, id := range idents {
+		width = max(width, len(id.name))
+	}
+	for _, id := range idents {
+		line := fmt.Sprintf("%s %-*s %-12s %s", mod.Name(), width, id.name, id.kind, id.oid)
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeSmiTypes writes the derivation tree of the types defined by
+// mod, rooted at their base types, with each type's restrictions.
+func writeSmiTypes(w io.Writer, _ *mib.Mib, mod *mib.Module) {
+	smiHeader(w, mod, "type derivation tree")
+
+	children := make(map[*mib.Type][]*mib.Type)
+	var roots []*mib.Type
+	seen := make(map[*mib.Type]bool)
+	for _, t := range smiModuleTypes(mod) {
+		for c := t; c != nil && !seen[c]; c = c.Parent() {
+			seen[c] = true
+			if p := c.Parent(); p != nil {
+				children[p] = append(children[p], c)
+			} else {
+				roots = append(roots, c)
+			}
+		}
+	}
+	for i, root := range roots {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, " %s\n", smiTypeLabel(root, mod))
+		writeSmiTypeChildren(w, children, root, " ", mod)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeSmiTypeChildren(w io.Writer, children map[*mib.Type][]*mib.Type, t *mib.Type, prefix string, mod *mib.Module) {
+	kids := children[t]
+	for i, c := range kids {
+		fmt.Fprintf(w, "%s|\n%s+--%s\n", prefix, prefix, smiTypeLabel(c, mod))
+		next := prefix + "|  "
+		if i == len(kids)-1 {
+			next = prefix + "   "
+		}
+		writeSmiTypeChildren(w, children, c, next, mod)
+	}
+}
+
+// smiTypeLabel returns a type's name, qualified when it belongs to
+// another module, followed by its own restrictions and status.
+func smiTypeLabel(t *mib.Type, mod *mib.Module) string {
+	name := t.Name()
+	if t.Parent() == nil && !smiApplicationType(t) {
+		name = smiBaseName(t, len(t.Enums()) > 0)
+	}
+	if t.Module() != nil && t.Module() != mod && t.Parent() != nil {
+		name = t.Module().Name() + "::" + name
+	}
+	var parts []string
+	parts = append(parts, name)
+	if rs := t.Ranges(); len(rs) > 0 {
+		parts = append(parts, "["+joinSmiRanges(rs)+"]")
+	}
+	if rs := t.Sizes(); len(rs) > 0 {
+		parts = append(parts, "("+joinSmiRanges(rs)+")")
+	}
+	if nv := t.Enums(); len(nv) > 0 {
+		parts = append(parts, "{"+joinNamedValues(nv)+"}")
+	}
+	if nv := t.Bits(); len(nv) > 0 {
+		parts = append(parts, "{"+joinNamedValues(nv)+"}")
+	}
+	if s := t.Status(); t.Parent() != nil && s != mib.StatusCurrent {
+		parts = append(parts, "<"+s.String()+">")
+	}
+	return strings.Join(parts, " ")
+}
+
+func joinSmiRanges(rs []mib.Range) string {
+	parts := make([]string, len(rs))
+	for i, r := range rs {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, " | ")
+}
+
+func joinNamedValues(nvs []mib.NamedValue) string {
+	parts := make([]string, len(nvs))
+	for i, nv := range nvs {
+		parts[i] = fmt.Sprintf("%s(%d)", nv.Label, nv.Value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// writeSmiMetrics writes counts of the definitions in mod by kind and
+// status, object access, table index complexity and type usage.
+func writeSmiMetrics(w io.Writer, _ *mib.Mib, mod *mib.Module) {
+	smiHeader(w, mod, "metrics")
+
+	type counts struct{ total, current, deprecated, obsolete int }
+	add := func(c *counts, s mib.Status) {
+		c.total++
+		switch s {
+		case mib.StatusDeprecated:
+			c.deprecated++
+		case mib.StatusObsolete:
+			c.obsolete++
+		default:
+			c.current++
+		}
+	}
+	categories := []string{"types", "tables", "rows", "columns", "scalars", "notifications", "groups", "compliances", "capabilities"}
+	byCategory := make(map[string]*counts)
+	for _, c := range categories {
+		byCategory[c] = &counts{}
+	}
+	for _, t := range smiModuleTypes(mod) {
+		add(byCategory["types"], t.Status())
+	}
+	for _, obj := range mod.Objects() {
+		switch obj.Kind() {
+		case mib.KindTable:
+			add(byCategory["tables"], obj.Status())
+		case mib.KindRow:
+			add(byCategory["rows"], obj.Status())
+		case mib.KindColumn:
+			add(byCategory["columns"], obj.Status())
+		case mib.KindScalar:
+			add(byCategory["scalars"], obj.Status())
+		}
+	}
+	for _, n := range mod.Notifications() {
+		add(byCategory["notifications"], n.Status())
+	}
+	for _, g := range mod.Groups() {
+		add(byCategory["groups"], g.Status())
+	}
+	for _, c := range mod.Compliances() {
+		add(byCategory["compliances"], c.Status())
+	}
+	for _, c := range mod.Capabilities() {
+		add(byCategory["capabilities"], c.Status())
+	}
+
+	fmt.Fprintf(w, "# %-18s %7s %8s %11s %9s\n", "DEFINITIONS", "TOTAL", "CURRENT", "DEPRECATED", "OBSOLETE")
+	var total counts
+	for _, name := range categories {
+		c := byCategory[name]
+		fmt.Fprintf(w, "  %-18s %7d %8d %11d %9d\n", name, c.total, c.current, c.deprecated, c.obsolete)
+		total.total += c.total
+		total.current += c.current
+		total.deprecated += c.deprecated
+		total.obsolete += c.obsolete
+	}
+	fmt.Fprintf(w, "  %-18s %7d %8d %11d %9d\n\n", "total", total.total, total.current, total.deprecated, total.obsolete)
+
+	accesses := []mib.Access{
+		mib.AccessNotAccessible, mib.AccessAccessibleForNotify, mib.AccessReadOnly,
+		mib.AccessReadWrite, mib.AccessReadCreate, mib.AccessWriteOnly,
+	}
+	scalars := make(map[mib.Access]int)
+	columns := make(map[mib.Access]int)
+	for _, obj := range mod.Objects() {
+		switch obj.Kind() {
+		case mib.KindScalar:
+			scalars[obj.Access()]++
+		case mib.KindColumn:
+			columns[obj.Access()]++
+		}
+	}
+	fmt.Fprintf(w, "# %-22s %7s %8s\n", "ACCESS", "SCALARS", "COLUMNS")
+	for _, a := range accesses {
+		fmt.Fprintf(w, "  %-22s %7d %8d\n", a, scalars[a], columns[a])
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "# %-30s %7s %7s %s\n", "TABLE", "COLUMNS", "INDEXES", "LINKAGE")
+	for _, table := range sortedObjects(mod.Tables()) {
+		row := table.Entry()
+		if row == nil {
+			continue
+		}
+		linkage := "index"
+		switch {
+		case row.Augments() != nil:
+			linkage = "augments " + row.Augments().Name()
+		case slices.ContainsFunc(row.EffectiveIndexes(), func(idx mib.IndexEntry) bool { return idx.Object.Row() != row }):
+			linkage = "index, external"
+		}
+		fmt.Fprintf(w, "  %-30s %7d %7d %s\n", table.Name(), len(row.Columns()), len(row.EffectiveIndexes()), linkage)
+	}
+	fmt.Fprintln(w)
+
+	usage := make(map[string]int)
+	for _, obj := range mod.Objects() {
+		if obj.IsScalar() || obj.IsColumn() {
+			usage[smiTypeName(obj)]++
+		}
+	}
+	typeNames := make([]string, 0, len(usage))
+	for name := range usage {
+		typeNames = append(typeNames, name)
+	}
+	slices.SortFunc(typeNames, func(a, b string) int {
+		if usage[a] != usage[b] {
+			return usage[b] - usage[a]
+		}
+		return strings.Compare(a, b)
+	})
+	fmt.Fprintf(w, "# %-30s %7s\n", "TYPE USAGE", "OBJECTS")
+	for _, name := range typeNames {
+		fmt.Fprintf(w, "  %-30s %7d\n", name, usage[name])
+	}
+	fmt.Fprintln(w)
+}
+
+func sortedObjects(objs []*mib.Object) []*mib.Object {
+	slices.SortFunc(objs, func(a, b *mib.Object) int { return a.OID().Compare(b.OID()) })
+	return objs
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the smidump golden files")
+
+// TestSmidumpGolden compares each smidump format for IF-MIB with
+// testdata/smidump/IF-MIB.<format>. After an intended change, regenerate
+// the files with go test -run SmidumpGolden -update and compare the diff
+// with smidump -f <format> IF-MIB. The version in the generated-by
+// comments is replaced with "VERSION".
+func TestSmidumpGolden(t *testing.T) {
+	m := loadTestMib(t, "IF-MIB")
+	mod := m.Module("IF-MIB")
+	testutil.NotNil(t, mod, "IF-MIB")
+
+	for _, format := range []string{"tree", "identifiers", "types", "metrics", "xml"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			smidumpFormats[format](&out, m, mod)
+			got := bytes.ReplaceAll(out.Bytes(), []byte("gomib "+buildVersion()), []byte("gomib VERSION"))
+
+			path := filepath.Join("testdata", "smidump", "IF-MIB."+format)
+			if *updateGolden {
+				testutil.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755), "MkdirAll")
+				testutil.NoError(t, os.WriteFile(path, got, 0o644), "write golden")
+			}
+			want, err := os.ReadFile(path)
+			testutil.NoError(t, err, "read golden")
+			testutil.Equal(t, string(want), string(got), "smidump -f %s output", format)
+		})
+	}
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// smiXMLNamespace is the namespace of the SMI XML schema (smi.xsd)
+// shipped with libsmi.
+const smiXMLNamespace = "http://www.ibr.cs.tu-bs.de/projects/nmrg/smi.xsd"
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// xmlWriter writes indented XML elements.
+type xmlWriter struct {
+	w      io.Writer
+	indent int
+}
+
+func (x *xmlWriter) line(format string, args ...any) {
+	fmt.Fprintf(x.w, "%s%s\n", strings.Repeat("  ", x.indent), fmt.Sprintf(format, args...))
+}
+
+func (x *xmlWriter) open(format string, args ...any) {
+	x.line(format, args...)
+	x.indent++
+}
+
+func (x *xmlWriter) close(tag string) {
+	x.indent--
+	x.line("</%s>", tag)
+}
+
+// text writes a single-line element, omitted when value is empty.
+func (x *xmlWriter) text(tag, value string) {
+	if value != "" {
+		x.line("<%s>%s</%s>", tag, xmlEscaper.Replace(value), tag)
+	}
+}
+
+// block writes a multi-line text element such as a description, with
+// the MIB's own indentation replaced by the element's.
+func (x *xmlWriter) block(tag, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	lines := strings.Split(value, "\n")
+	common := -1
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if common < 0 || n < common {
+			common = n
+		}
+	}
+	pad := strings.Repeat("  ", x.indent+2)
+	x.line("<%s>", tag)
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if i > 0 {
+			line = line[min(max(common, 0), len(line)):]
+		}
+		if line == "" {
+			fmt.Fprintln(x.w)
+			continue
+		}
+		fmt.Fprintf(x.w, "%s%s\n", pad, xmlEscaper.Replace(line))
+	}
+	x.line("</%s>", tag)
+}
+
+// attrs formats name/value pairs as XML attributes.
+func attrs(kv ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(kv); i += 2 {
+		fmt.Fprintf(&b, ` %s="%s"`, kv[i], xmlEscaper.Replace(kv[i+1]))
+	}
+	return b.String()
+}
+
+// smiXMLAccess returns the access keyword of the SMI XML schema.
+func smiXMLAccess(a mib.Access) string {
+	switch a {
+	case mib.AccessNotAccessible:
+		return "noaccess"
+	case mib.AccessAccessibleForNotify:
+		return "notifyonly"
+	case mib.AccessReadOnly:
+		return "readonly"
+	case mib.AccessReadWrite, mib.AccessReadCreate, mib.AccessWriteOnly:
+		return "readwrite"
+	case mib.AccessNotImplemented:
+		return "not-implemented"
+	}
+	return "<unknown>"
+}
+
+// smiXMLDate converts a revision date to libsmi's "YYYY-MM-DD HH:MM".
+func smiXMLDate(s string) string {
+	switch {
+	case len(s) == 13 && strings.HasSuffix(s, "Z"):
+		return s[0:4] + "-" + s[4:6] + "-" + s[6:8] + " " + s[8:10] + ":" + s[10:12]
+	case len(s) == 11 && strings.HasSuffix(s, "Z"):
+		return "19" + s[0:2] + "-" + s[2:4] + "-" + s[4:6] + " " + s[6:8] + ":" + s[8:10]
+	case len(s) == 10:
+		return s + " 00:00"
+	}
+	return s
+}
+
+// writeSmiXML writes mod as a document of the SMI XML schema, as
+// smidump -f xml does.
+func writeSmiXML(w io.Writer, _ *mib.Mib, mod *mib.Module) {
+	fmt.Fprintf(w, "<?xml version=\"1.0\"?>\n\n")
+	fmt.Fprintf(w, "<!-- This module has been generated by gomib %s. Do not edit. -->\n\n", buildVersion())
+	fmt.Fprintf(w, "<smi xmlns=\"%s\"\n     xmlns:xsi=\"http://www.w3.org/2001/XMLSchema-instance\"\n     xsi:schemaLocation=\"%s smi.xsd\">\n", smiXMLNamespace, smiXMLNamespace)
+	x := &xmlWriter{w: w, indent: 1}
+
+	x.open("<module%s>", attrs("name", mod.Name(), "language", mod.Language().String()))
+	x.block("organization", mod.Organization())
+	x.block("contact", mod.ContactInfo())
+	x.block("description", mod.Description())
+	for _, rev := range mod.Revisions() {
+		x.open("<revision%s>", attrs("date", smiXMLDate(rev.Date)))
+		x.block("description", rev.Description)
+		x.close("revision")
+	}
+	if oid := mod.OID(); len(oid) > 0 {
+		for _, nd := range mod.Nodes() {
+			if nd.OID().Equal(oid) {
+				x.line("<identity%s/>", attrs("node", nd.Name()))
+				break
+			}
+		}
+	}
+	x.close("module")
+
+	if imports := mod.Imports(); len(imports) > 0 {
+		fmt.Fprintln(w)
+		x.open("<imports>")
+		for _, imp := range imports {
+			for _, sym := range imp.Symbols {
+				x.line("<import%s/>", attrs("module", imp.Module, "name", sym))
+			}
+		}
+		x.close("imports")
+	}
+
+	if types := smiModuleTypes(mod); len(types) > 0 {
+		fmt.Fprintln(w)
+		x.open("<typedefs>")
+		for _, t := range types {
+			writeSmiXMLTypedef(x, t)
+		}
+		x.close("typedefs")
+	}
+
+	writeSmiXMLNodes(x, mod)
+
+	if notifs := mod.Notifications(); len(notifs) > 0 {
+		slices.SortFunc(notifs, func(a, b *mib.Notification) int { return a.OID().Compare(b.OID()) })
+		fmt.Fprintln(w)
+		x.open("<notifications>")
+		for _, n := range notifs {
+			x.open("<notification%s>", attrs("name", n.Name(), "oid", n.OID().String(), "status", n.Status().String()))
+			if objs := n.Objects(); len(objs) > 0 {
+				x.open("<objects>")
+				for _, obj := range objs {
+					x.line("<object%s/>", attrs("module", obj.Module().Name(), "name", obj.Name()))
+				}
+				x.close("objects")
+			}
+			x.block("description", n.Description())
+			x.block("reference", n.Reference())
+			x.close("notification")
+		}
+		x.close("notifications")
+	}
+
+	if groups := mod.Groups(); len(groups) > 0 {
+		fmt.Fprintln(w)
+		x.open("<groups>")
+		for _, g := range groups {
+			x.open("<group%s>", attrs("name", g.Name(), "oid", g.OID().String(), "status", g.Status().String()))
+			x.open("<members>")
+			for _, member := range g.Members() {
+				x.line("<member%s/>", attrs("module", nodeModuleName(member), "name", member.Name()))
+			}
+			x.close("members")
+			x.block("description", g.Description())
+			x.block("reference", g.Reference())
+			x.close("group")
+		}
+		x.close("groups")
+	}
+
+	if comps := mod.Compliances(); len(comps) > 0 {
+		fmt.Fprintln(w)
+		x.open("<compliances>")
+		for _, c := range comps {
+			writeSmiXMLCompliance(x, mod, c)
+		}
+		x.close("compliances")
+	}
+
+	fmt.Fprintln(w, "</smi>")
+}
+
+func nodeModuleName(nd *mib.Node) string {
+	if mod := nd.Module(); mod != nil {
+		return mod.Name()
+	}
+	return ""
+}
+
+func writeSmiXMLTypedef(x *xmlWriter, t *mib.Type) {
+	x.open("<typedef%s>", attrs("name", t.Name(), "basetype", smiBaseName(t, len(t.EffectiveEnums()) > 0), "status", t.Status().String()))
+	if p := t.Parent(); p != nil && (p.Parent() != nil || smiApplicationType(p)) && p.Module() != nil {
+		x.line("<parent%s/>", attrs("module", p.Module().Name(), "name", p.Name()))
+	}
+	writeSmiXMLRestrictions(x, t.Ranges(), t.Sizes(), t.Enums(), t.Bits())
+	x.text("format", t.DisplayHint())
+	x.block("description", t.Description())
+	x.block("reference", t.Reference())
+	x.close("typedef")
+}
+
+func writeSmiXMLRestrictions(x *xmlWriter, ranges, sizes []mib.Range, enums, bits []mib.NamedValue) {
+	for _, r := range append(slices.Clone(ranges), sizes...) {
+		x.line("<range%s/>", attrs("min", fmt.Sprint(r.Min), "max", fmt.Sprint(r.Max)))
+	}
+	for _, nv := range enums {
+		x.line("<namednumber%s/>", attrs("name", nv.Label, "number", fmt.Sprint(nv.Value)))
+	}
+	for _, nv := range bits {
+		x.line("<namednumber%s/>", attrs("name", nv.Label, "number", fmt.Sprint(nv.Value)))
+	}
+}
+
+// writeSmiXMLSyntax writes an object's syntax: a reference to its
+// named type, or an anonymous typedef when the object refines it.
+func writeSmiXMLSyntax(x *xmlWriter, tag string, obj *mib.Object) {
+	t := obj.Type()
+	if t == nil {
+		return
+	}
+	var ranges, sizes []mib.Range
+	var enums, bits []mib.NamedValue
+	if !slices.Equal(obj.EffectiveRanges(), t.EffectiveRanges()) {
+		ranges = obj.EffectiveRanges()
+	}
+	if !slices.Equal(obj.EffectiveSizes(), t.EffectiveSizes()) {
+		sizes = obj.EffectiveSizes()
+	}
+	if !slices.Equal(obj.EffectiveEnums(), t.EffectiveEnums()) {
+		enums = obj.EffectiveEnums()
+	}
+	if !slices.Equal(obj.EffectiveBits(), t.EffectiveBits()) {
+		bits = obj.EffectiveBits()
+	}
+	named := t.Parent() != nil || smiApplicationType(t)
+
+	x.open("<%s>", tag)
+	switch {
+	case ranges != nil || sizes != nil || enums != nil || bits != nil:
+	case named && t.Module() != nil:
+		x.line("<type%s/>", attrs("module", t.Module().Name(), "name", t.Name()))
+	default:
+		x.line("<type%s/>", attrs("module", "", "name", smiBaseName(t, false)))
+	}
+	if ranges != nil || sizes != nil || enums != nil || bits != nil {
+		x.open("<typedef%s>", attrs("basetype", smiBaseName(t, len(obj.EffectiveEnums()) > 0)))
+		if named && t.Module() != nil {
+			x.line("<parent%s/>", attrs("module", t.Module().Name(), "name", t.Name()))
+		}
+		writeSmiXMLRestrictions(x, ranges, sizes, enums, bits)
+		x.close("typedef")
+	}
+	x.close(tag)
+}
+
+func writeSmiXMLNodes(x *xmlWriter, mod *mib.Module) {
+	var nodes []*mib.Node
+	for _, nd := range smiModuleNodes(mod) {
+		switch nd.Kind() {
+		case mib.KindNode, mib.KindScalar, mib.KindTable:
+			nodes = append(nodes, nd)
+		}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	fmt.Fprintln(x.w)
+	x.open("<nodes>")
+	for _, nd := range nodes {
+		obj := nd.Object()
+		switch {
+		case obj == nil:
+			x.line("<node%s/>", attrs("name", nd.Name(), "oid", nd.OID().String()))
+		case obj.IsTable():
+			x.open("<table%s>", attrs("name", obj.Name(), "oid", obj.OID().String(), "status", obj.Status().String()))
+			x.block("description", obj.Description())
+			x.block("reference", obj.Reference())
+			if row := obj.Entry(); row != nil {
+				writeSmiXMLRow(x, row)
+			}
+			x.close("table")
+		default:
+			writeSmiXMLObject(x, "scalar", obj)
+		}
+	}
+	x.close("nodes")
+}
+
+func writeSmiXMLRow(x *xmlWriter, row *mib.Object) {
+	kv := []string{"name", row.Name(), "oid", row.OID().String(), "status", row.Status().String()}
+	cols := row.Columns()
+	if slices.ContainsFunc(cols, func(c *mib.Object) bool { return c.Access() == mib.AccessReadCreate }) {
+		kv = append(kv, "create", "true")
+	}
+	x.open("<row%s>", attrs(kv...))
+
+	indexes := row.EffectiveIndexes()
+	if base := row.Augments(); base != nil {
+		x.open("<linkage>")
+		x.line("<augments%s/>", attrs("module", base.Module().Name(), "name", base.Name()))
+		x.close("linkage")
+	} else if len(indexes) > 0 {
+		implied := slices.ContainsFunc(indexes, func(idx mib.IndexEntry) bool { return idx.Implied })
+		if implied {
+			x.open("<linkage%s>", attrs("implied", "true"))
+		} else {
+			x.open("<linkage>")
+		}
+		for _, idx := range indexes {
+			x.line("<index%s/>", attrs("module", idx.Object.Module().Name(), "name", idx.Object.Name()))
+		}
+		x.close("linkage")
+	}
+	x.block("description", row.Description())
+	x.block("reference", row.Reference())
+	for _, col := range cols {
+		writeSmiXMLObject(x, "column", col)
+	}
+	x.close("row")
+}
+
+func writeSmiXMLObject(x *xmlWriter, tag string, obj *mib.Object) {
+	x.open("<%s%s>", tag, attrs("name", obj.Name(), "oid", obj.OID().String(), "status", obj.Status().String()))
+	writeSmiXMLSyntax(x, "syntax", obj)
+	x.text("access", smiXMLAccess(obj.Access()))
+	if dv := obj.DefaultValue(); !dv.IsZero() {
+		x.text("default", dv.String())
+	}
+	x.text("format", obj.EffectiveDisplayHint())
+	x.text("units", obj.Units())
+	x.block("description", obj.Description())
+	x.block("reference", obj.Reference())
+	x.close(tag)
+}
+
+func writeSmiXMLCompliance(x *xmlWriter, mod *mib.Module, c *mib.Compliance) {
+	x.open("<compliance%s>", attrs("name", c.Name(), "oid", c.OID().String(), "status", c.Status().String()))
+	x.block("description", c.Description())
+	x.block("reference", c.Reference())
+
+	modName := func(cm mib.ComplianceModule) string {
+		if cm.ModuleName == "" {
+			return mod.Name()
+		}
+		return cm.ModuleName
+	}
+	var requires, refinements bool
+	for _, cm := range c.Modules() {
+		requires = requires || len(cm.MandatoryGroups) > 0 || len(cm.Groups) > 0
+		refinements = refinements || len(cm.Objects) > 0
+	}
+	if requires {
+		x.open("<requires>")
+		for _, cm := range c.Modules() {
+			for _, g := range cm.MandatoryGroups {
+				x.line("<mandatory%s/>", attrs("module", modName(cm), "name", g))
+			}
+			for _, g := range cm.Groups {
+				x.open("<option%s>", attrs("module", modName(cm), "name", g.Group))
+				x.block("description", g.Description)
+				x.close("option")
+			}
+		}
+		x.close("requires")
+	}
+	if refinements {
+		x.open("<refinements>")
+		for _, cm := range c.Modules() {
+			for _, ref := range cm.Objects {
+				x.open("<refinement%s>", attrs("module", modName(cm), "name", ref.Object))
+				writeSmiXMLConstraints(x, "syntax", ref.Syntax)
+				writeSmiXMLConstraints(x, "writesyntax", ref.WriteSyntax)
+				if ref.MinAccess != nil {
+					x.text("access", smiXMLAccess(*ref.MinAccess))
+				}
+				x.block("description", ref.Description)
+				x.close("refinement")
+			}
+		}
+		x.close("refinements")
+	}
+	x.close("compliance")
+}
+
+func writeSmiXMLConstraints(x *xmlWriter, tag string, sc *mib.SyntaxConstraints) {
+	if sc == nil {
+		return
+	}
+	x.open("<%s>", tag)
+	if t := sc.Type; t != nil {
+		if sc.Ranges == nil && sc.Sizes == nil && sc.Enums == nil && sc.Bits == nil && t.Module() != nil {
+			x.line("<type%s/>", attrs("module", t.Module().Name(), "name", t.Name()))
+		} else {
+			x.open("<typedef%s>", attrs("basetype", smiBaseName(t, len(sc.Enums) > 0)))
+			writeSmiXMLRestrictions(x, sc.Ranges, sc.Sizes, sc.Enums, sc.Bits)
+			x.close("typedef")
+		}
+	} else {
+		x.open("<typedef>")
+		writeSmiXMLRestrictions(x, sc.Ranges, sc.Sizes, sc.Enums, sc.Bits)
+		x.close("typedef")
+	}
+	x.close(tag)
+}
@@ -0,0 +1,699 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+const translateUsage = `gomib translate - Translate OIDs and names, like net-snmp's snmptranslate
+
+Usage:
+  gomib translate [options] OID...
+  gomib translate [options] < oids.txt
+
+Options:
+  -m MODULES    Modules to load, colon-separated (default: ALL)
+  -M DIRS       Additional MIB directories, colon-separated
+  -On           Numeric output
+  -Of           Full symbolic path: .iso.org.dod.internet...
+  -Os           Name and instance suffix only
+  -OS           MODULE::name (default)
+  -Ou           Path below mib-2, full path elsewhere
+  -Td           Print the definition of each node
+  -Tp           Print the subtree below each OID (the whole tree without one)
+  -IR           Random access lookup of unqualified names (always on)
+  -Ib           Best match: treat the input as a regular expression over names
+  -h, --help    Show help
+
+Input may be numeric (.1.3.6.1.2.1.1.1.0), qualified (SNMPv2-MIB::sysDescr.0),
+a plain name (sysDescr.0) or a full path (.iso.org.dod.internet.mgmt.mib-2...).
+With no OID arguments, or "-", OIDs are read from stdin one per line and each
+translation is written as soon as its line is read.
+
+Examples:
+  gomib translate -On IF-MIB::ifDescr.3
+  gomib translate .1.3.6.1.2.1.2.2.1.2.3
+  gomib translate -Of sysUpTime.0
+  gomib translate -Td ifOperStatus
+  gomib translate -Tp ifTable
+  gomib translate -Ib 'if.*octets'
+  awk '{print $1}' walk.txt | gomib translate -m IF-MIB
+`
+
+// translateOptions holds the snmptranslate-style flags.
+type translateOptions struct {
+	output    byte // 'n', 'f', 's', 'S', 'u', or 0 for the default
+	detail    bool
+	tree      bool
+	bestMatch bool
+}
+
+// translateArgs holds the parsed arguments of the translate command.
+type translateArgs struct {
+	opts    translateOptions
+	modules []string
+	paths   []string
+	inputs  []string
+	help    bool
+}
+
+// parseTranslateArgs parses snmptranslate-style arguments, where option
+// values may be attached (-mIF-MIB) or separate (-m IF-MIB).
+func parseTranslateArgs(args []string) (translateArgs, error) {
+	var ta translateArgs
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-" || !strings.HasPrefix(arg, "-") || len(arg) < 2 {
+			ta.inputs = append(ta.inputs, arg)
+			continue
+		}
+		if arg == "--help" {
+			ta.help = true
+			continue
+		}
+		flag, value := arg[1], arg[2:]
+		switch flag {
+		case 'm', 'M':
+			if value == "" {
+				if i+1 >= len(args) {
+					return ta, fmt.Errorf("option -%c requires an argument", flag)
+				}
+				i++
+				value = args[i]
+			}
+			for _, v := range strings.Split(value, ":") {
+				v = strings.TrimPrefix(v, "+")
+				switch {
+				case v == "":
+				case flag == 'M':
+					ta.paths = append(ta.paths, v)
+				default:
+					ta.modules = append(ta.modules, v)
+				}
+			}
+		case 'O':
+			for _, ch := range []byte(value) {
+				if !strings.ContainsRune("nfsSu", rune(ch)) {
+					return ta, fmt.Errorf("unsupported output option -O%c", ch)
+				}
+				ta.opts.output = ch
+			}
+		case 'T':
+			for _, ch := range []byte(value) {
+				switch ch {
+				case 'd':
+					ta.opts.detail = true
+				case 'p':
+					ta.opts.tree = true
+				default:
+					return ta, fmt.Errorf("unsupported translate option -T%c", ch)
+				}
+			}
+		case 'I':
+			for _, ch := range []byte(value) {
+				switch ch {
+				case 'R':
+				case 'b':
+					ta.opts.bestMatch = true
+				default:
+					return ta, fmt.Errorf("unsupported input option -I%c", ch)
+				}
+			}
+		default:
+			return ta, fmt.Errorf("unknown option: %s", arg)
+		}
+	}
+	return ta, nil
+}
+
+func (c *cli) cmdTranslate(args []string) int {
+	ta, err := parseTranslateArgs(args)
+	if err != nil {
+		printError("%v", err)
+		fmt.Fprint(os.Stderr, translateUsage)
+		return 1
+	}
+	if ta.help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, translateUsage)
+		return 0
+	}
+	c.paths = append(c.paths, ta.paths...)
+	opts, modules, inputs := ta.opts, ta.modules, ta.inputs
+
+	if slices.Contains(modules, "ALL") {
+		modules = nil
+	}
+	m, err := c.loadMib(modules)
+	if err != nil {
+		printError("failed to load: %v", err)
+		return exitError
+	}
+	t := &translator{m: m, opts: opts}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	if opts.tree && len(inputs) == 0 {
+		t.writeTree(out, m.Root())
+		return exitOK
+	}
+
+	code := exitOK
+	translate := func(input string) {
+		if err := t.translate(out, input); err != nil {
+			printError("%s", err)
+			code = exitError
+		}
+	}
+	if len(inputs) == 0 || (len(inputs) == 1 && inputs[0] == "-") {
+		sc := bufio.NewScanner(os.Stdin)
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			translate(line)
+			_ = out.Flush()
+		}
+		if err := sc.Err(); err != nil {
+			printError("reading stdin: %v", err)
+			return exitError
+		}
+		return code
+	}
+	for _, input := range inputs {
+		translate(input)
+	}
+	return code
+}
+
+type translator struct {
+	m    *mib.Mib
+	opts translateOptions
+}
+
+func (t *translator) translate(w io.Writer, input string) error {
+	oid, err := t.resolve(input)
+	if err != nil {
+		return err
+	}
+	if t.opts.tree {
+		nd := t.m.LongestPrefixByOID(oid)
+		if nd == nil {
+			return fmt.Errorf("%s: unknown object identifier", input)
+		}
+		t.writeTree(w, nd)
+		return nil
+	}
+
+	// Like snmptranslate, the default is -OS whatever the input form.
+	format := t.opts.output
+	if format == 0 {
+		format = 'S'
+	}
+	fmt.Fprintln(w, t.format(oid, format))
+	if t.opts.detail {
+		if nd := t.m.LongestPrefixByOID(oid); nd != nil && nd.Name() != "" {
+			writeDefinition(w, nd)
+		}
+	}
+	return nil
+}
+
+// resolve parses an input OID in any of the accepted forms.
+func (t *translator) resolve(input string) (mib.OID, error) {
+	if t.opts.bestMatch {
+		nd, err := t.bestMatch(input)
+		if err != nil {
+			return nil, err
+		}
+		return nd.OID(), nil
+	}
+
+	s := strings.TrimPrefix(input, ".")
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		oid, err := mib.ParseOID(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", input, err)
+		}
+		return oid, nil
+	}
+
+	var nd *mib.Node
+	var rest []string
+	switch {
+	case strings.Contains(s, "::"):
+		modName, path, _ := strings.Cut(s, "::")
+		mod := t.m.Module(modName)
+		if mod == nil {
+			return nil, fmt.Errorf("%s: unknown module %s", input, modName)
+		}
+		parts := strings.Split(path, ".")
+		nd, rest = moduleNode(mod, parts[0]), parts[1:]
+	case strings.HasPrefix(input, "."):
+		// A full path from the root: each label names a child.
+		nd, rest = t.m.Root(), strings.Split(s, ".")
+	default:
+		parts := strings.Split(s, ".")
+		nd, rest = t.m.Node(parts[0]), parts[1:]
+	}
+	if nd == nil {
+		return nil, fmt.Errorf("%s: unknown object identifier", input)
+	}
+
+	oid := nd.OID()
+	for _, part := range rest {
+		if arc, err := strconv.ParseUint(part, 10, 32); err == nil {
+			oid = append(oid, uint32(arc))
+			if nd != nil {
+				nd = nd.Child(uint32(arc))
+			}
+			continue
+		}
+		var child *mib.Node
+		if nd != nil {
+			for _, c := range nd.Children() {
+				if c.Name() == part {
+					child = c
+					break
+				}
+			}
+		}
+		if child == nil {
+			return nil, fmt.Errorf("%s: unknown sub-identifier %q", input, part)
+		}
+		nd = child
+		oid = append(oid, child.Arc())
+	}
+	if len(oid) == 0 {
+		return nil, fmt.Errorf("%s: empty object identifier", input)
+	}
+	return oid, nil
+}
+
+// moduleNode finds a definition of any kind by name within mod.
+func moduleNode(mod *mib.Module, name string) *mib.Node {
+	if obj := mod.Object(name); obj != nil {
+		return obj.Node()
+	}
+	if n := mod.Notification(name); n != nil {
+		return n.Node()
+	}
+	if g := mod.Group(name); g != nil {
+		return g.Node()
+	}
+	if c := mod.Compliance(name); c != nil {
+		return c.Node()
+	}
+	if c := mod.Capability(name); c != nil {
+		return c.Node()
+	}
+	return mod.Node(name)
+}
+
+// bestMatch returns the node whose name best matches the regular
+// expression: a match of the whole name first, then the earliest
+// match, then the shortest name.
+func (t *translator) bestMatch(expr string) (*mib.Node, error) {
+	re, err := regexp.Compile("(?i)" + expr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", expr, err)
+	}
+	var best *mib.Node
+	bestScore := [3]int{}
+	for nd := range t.m.Nodes() {
+		name := nd.Name()
+		loc := re.FindStringIndex(name)
+		if name == "" || loc == nil {
+			continue
+		}
+		whole := 1
+		if loc[0] == 0 && loc[1] == len(name) {
+			whole = 0
+		}
+		score := [3]int{whole, loc[0], len(name)}
+		if best == nil || lessScore(score, bestScore) ||
+			score == bestScore && nd.OID().Compare(best.OID()) < 0 {
+			best, bestScore = nd, score
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%s: no match", expr)
+	}
+	return best, nil
+}
+
+func lessScore(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// format renders oid in one of the -O output styles.
+func (t *translator) format(oid mib.OID, style byte) string {
+	switch style {
+	case 'n':
+		return "." + oid.String()
+	case 'S':
+		return t.m.FormatOID(oid)
+	case 's':
+		s := t.m.FormatOID(oid)
+		if _, after, ok := strings.Cut(s, "::"); ok {
+			return after
+		}
+		return s
+	}
+
+	labels := make([]string, 0, len(oid))
+	nd := t.m.Root()
+	for _, arc := range oid {
+		if nd != nil {
+			nd = nd.Child(arc)
+		}
+		if nd != nil && nd.Name() != "" {
+			labels = append(labels, nd.Name())
+		} else {
+			labels = append(labels, strconv.FormatUint(uint64(arc), 10))
+		}
+	}
+	if style == 'u' && len(oid) > 6 && oid[:6].Equal(mib.OID{1, 3, 6, 1, 2, 1}) {
+		return strings.Join(labels[6:], ".")
+	}
+	return "." + strings.Join(labels, ".")
+}
+
+// writeDefinition prints a node's definition in snmptranslate -Td style.
+func writeDefinition(w io.Writer, nd *mib.Node) {
+	macro := "OBJECT IDENTIFIER"
+	switch {
+	case nd.Object() != nil:
+		macro = "OBJECT-TYPE"
+	case nd.Notification() != nil:
+		macro = "NOTIFICATION-TYPE"
+		if nd.Notification().TrapInfo() != nil {
+			macro = "TRAP-TYPE"
+		}
+	case nd.Group() != nil:
+		macro = "OBJECT-GROUP"
+		if nd.Group().IsNotificationGroup() {
+			macro = "NOTIFICATION-GROUP"
+		}
+	case nd.Compliance() != nil:
+		macro = "MODULE-COMPLIANCE"
+	case nd.Capability() != nil:
+		macro = "AGENT-CAPABILITIES"
+	case nd.Module() != nil && nd.OID().Equal(nd.Module().OID()):
+		macro = "MODULE-IDENTITY"
+	}
+	fmt.Fprintf(w, "%s %s\n", nd.Name(), macro)
+	if mod := nd.Module(); mod != nil {
+		fmt.Fprintf(w, "  -- FROM\t%s\n", mod.Name())
+	}
+
+	var status mib.Status
+	var desc string
+	var trailer func()
+	hasStatus := true
+	switch {
+	case nd.Object() != nil:
+		obj := nd.Object()
+		writeObjectSyntax(w, obj)
+		if obj.Units() != "" {
+			fmt.Fprintf(w, "  UNITS\t\t%q\n", obj.Units())
+		}
+		fmt.Fprintf(w, "  MAX-ACCESS\t%s\n", obj.Access())
+		status, desc = obj.Status(), obj.Description()
+		// INDEX, AUGMENTS and DEFVAL follow the description.
+		trailer = func() {
+			if base := obj.Augments(); base != nil {
+				fmt.Fprintf(w, "  AUGMENTS\t{ %s }\n", base.Name())
+			} else if idx := obj.Index(); len(idx) > 0 {
+				names := make([]string, len(idx))
+				for i, e := range idx {
+					names[i] = e.Object.Name()
+					if e.Implied {
+						names[i] = "IMPLIED " + names[i]
+					}
+				}
+				fmt.Fprintf(w, "  INDEX\t\t{ %s }\n", strings.Join(names, ", "))
+			}
+			if dv := obj.DefaultValue(); !dv.IsZero() {
+				fmt.Fprintf(w, "  DEFVAL\t{ %s }\n", dv)
+			}
+		}
+	case nd.Notification() != nil:
+		n := nd.Notification()
+		writeObjectList(w, "OBJECTS", n.Objects())
+		status, desc = n.Status(), n.Description()
+	case nd.Group() != nil:
+		g := nd.Group()
+		names := make([]string, 0, len(g.Members()))
+		for _, member := range g.Members() {
+			names = append(names, member.Name())
+		}
+		label := "OBJECTS"
+		if g.IsNotificationGroup() {
+			label = "NOTIFICATIONS"
+		}
+		fmt.Fprintf(w, "  %s\t{ %s }\n", label, strings.Join(names, ", "))
+		status, desc = g.Status(), g.Description()
+	case nd.Compliance() != nil:
+		status, desc = nd.Compliance().Status(), nd.Compliance().Description()
+	case nd.Capability() != nil:
+		status, desc = nd.Capability().Status(), nd.Capability().Description()
+	default:
+		hasStatus = false
+	}
+	if hasStatus {
+		fmt.Fprintf(w, "  STATUS\t%s\n", status)
+	}
+	if desc != "" {
+		fmt.Fprintf(w, "  DESCRIPTION\t\"%s\"\n", desc)
+	}
+	if trailer != nil {
+		trailer()
+	}
+	writeAssignment(w, nd)
+}
+
+// writeAssignment prints "::= { iso(1) org(3) ... arc }".
+func writeAssignment(w io.Writer, nd *mib.Node) {
+	var parts []string
+	for p := nd.Parent(); p != nil && !p.IsRoot(); p = p.Parent() {
+		label := fmt.Sprint(p.Arc())
+		if p.Name() != "" {
+			label = fmt.Sprintf("%s(%d)", p.Name(), p.Arc())
+		}
+		parts = append([]string{label}, parts...)
+	}
+	parts = append(parts, fmt.Sprint(nd.Arc()))
+	fmt.Fprintf(w, "::= { %s }\n", strings.Join(parts, " "))
+}
+
+func writeObjectList(w io.Writer, label string, objs []*mib.Object) {
+	if len(objs) == 0 {
+		return
+	}
+	names := make([]string, len(objs))
+	for i, o := range objs {
+		names[i] = o.Name()
+	}
+	fmt.Fprintf(w, "  %s\t{ %s }\n", label, strings.Join(names, ", "))
+}
+
+// writeObjectSyntax prints the textual convention and base syntax of
+// an object, with its effective restrictions.
+func writeObjectSyntax(w io.Writer, obj *mib.Object) {
+	if obj.IsTable() {
+		if entry := obj.Entry(); entry != nil && entry.Type() != nil {
+			fmt.Fprintf(w, "  SYNTAX\tSEQUENCE OF %s\n", entry.Type().Name())
+		}
+		return
+	}
+	t := obj.Type()
+	if t == nil {
+		return
+	}
+	if t.IsTextualConvention() {
+		fmt.Fprintf(w, "  -- TEXTUAL CONVENTION %s\n", t.Name())
+	}
+	syntax := t.EffectiveBase().String()
+	ranges, sizes := objectRestrictions(obj)
+	switch {
+	case obj.IsRow():
+		syntax = t.Name()
+	case len(obj.EffectiveEnums()) > 0:
+		syntax = "INTEGER {" + joinNamedValues(obj.EffectiveEnums()) + "}"
+	case len(obj.EffectiveBits()) > 0:
+		syntax = "BITS {" + joinNamedValues(obj.EffectiveBits()) + "}"
+	case len(ranges) > 0:
+		syntax += " (" + joinSmiRanges(ranges) + ")"
+	case len(sizes) > 0:
+		syntax += " (" + joinSmiRanges(sizes) + ")"
+	}
+	fmt.Fprintf(w, "  SYNTAX\t%s\n", syntax)
+	if hint := obj.EffectiveDisplayHint(); hint != "" {
+		fmt.Fprintf(w, "  DISPLAY-HINT\t%q\n", hint)
+	}
+}
+
+// writeTree prints the subtree at nd in snmptranslate -Tp style.
+func (t *translator) writeTree(w io.Writer, nd *mib.Node) {
+	if nd.IsRoot() {
+		children := nd.Children()
+		for i, c := range children {
+			writeTreeNode(w, c, "", i < len(children)-1)
+		}
+		return
+	}
+	writeTreeNode(w, nd, "", false)
+}
+
+func writeTreeNode(w io.Writer, nd *mib.Node, indent string, more bool) {
+	name := nd.Name()
+	if name == "" {
+		name = fmt.Sprint(nd.Arc())
+	}
+	cont := " "
+	if more {
+		cont = "|"
+	}
+
+	if obj := nd.Object(); obj != nil && (obj.IsScalar() || obj.IsColumn()) {
+		fmt.Fprintf(w, "%s+-- %s %-9s %s(%d)\n", indent, treeAccess(obj.Access()), treeType(obj), name, nd.Arc())
+		for _, line := range leafDetails(obj) {
+			fmt.Fprintf(w, "%s%s        %s\n", indent, cont, line)
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "%s+--%s(%d)\n", indent, name, nd.Arc())
+	childIndent := indent + cont + "  "
+	children := nd.Children()
+	if obj := nd.Object(); obj != nil && obj.IsRow() {
+		line := "Index: "
+		if base := obj.Augments(); base != nil {
+			line = "Augments: " + base.Name()
+		} else {
+			var names []string
+			for _, idx := range obj.Index() {
+				names = append(names, idx.Object.Name())
+			}
+			line += strings.Join(names, ", ")
+		}
+		fmt.Fprintf(w, "%s|  %s\n", childIndent, line)
+	}
+	for i, c := range children {
+		fmt.Fprintf(w, "%s|\n", childIndent)
+		writeTreeNode(w, c, childIndent, i < len(children)-1)
+	}
+}
+
+// treeAccess returns net-snmp's four-character access column.
+func treeAccess(a mib.Access) string {
+	switch a {
+	case mib.AccessReadOnly:
+		return "-R--"
+	case mib.AccessReadWrite:
+		return "-RW-"
+	case mib.AccessWriteOnly:
+		return "--W-"
+	case mib.AccessAccessibleForNotify:
+		return "---N"
+	case mib.AccessReadCreate:
+		return "CR--"
+	}
+	return "----"
+}
+
+// treeType returns net-snmp's short type label for an object.
+func treeType(obj *mib.Object) string {
+	t := obj.Type()
+	if t == nil {
+		return ""
+	}
+	switch t.EffectiveBase() {
+	case mib.BaseInteger32:
+		if len(obj.EffectiveEnums()) > 0 {
+			return "EnumVal"
+		}
+		return "INTEGER"
+	case mib.BaseUnsigned32:
+		return "Unsigned"
+	case mib.BaseCounter32:
+		return "Counter"
+	case mib.BaseCounter64:
+		return "Counter64"
+	case mib.BaseGauge32:
+		return "Gauge"
+	case mib.BaseTimeTicks:
+		return "TimeTicks"
+	case mib.BaseIpAddress:
+		return "IpAddr"
+	case mib.BaseOctetString:
+		if len(obj.EffectiveBits()) > 0 {
+			return "BitString"
+		}
+		return "String"
+	case mib.BaseObjectIdentifier:
+		return "ObjID"
+	case mib.BaseBits:
+		return "BitString"
+	case mib.BaseOpaque:
+		return "Opaque"
+	}
+	return ""
+}
+
+func leafDetails(obj *mib.Object) []string {
+	var lines []string
+	if t := obj.Type(); t != nil && t.IsTextualConvention() {
+		lines = append(lines, "Textual Convention: "+t.Name())
+	}
+	if enums := obj.EffectiveEnums(); len(enums) > 0 {
+		lines = append(lines, "Values: "+joinNamedValues(enums))
+	} else if bits := obj.EffectiveBits(); len(bits) > 0 {
+		lines = append(lines, "Values: "+joinNamedValues(bits))
+	} else if ranges, sizes := objectRestrictions(obj); len(ranges) > 0 {
+		lines = append(lines, "Range: "+joinSmiRanges(ranges))
+	} else if len(sizes) > 0 {
+		lines = append(lines, "Size: "+joinSmiRanges(sizes))
+	}
+	return lines
+}
+
+// objectRestrictions returns the effective ranges and sizes of obj,
+// omitting those implied by an SMI base type such as Counter32.
+func objectRestrictions(obj *mib.Object) (ranges, sizes []mib.Range) {
+	ranges, sizes = obj.EffectiveRanges(), obj.EffectiveSizes()
+	for t := obj.Type(); t != nil; t = t.Parent() {
+		if t.Module() == nil || !smiBaseModules[t.Module().Name()] {
+			continue
+		}
+		if slices.Equal(ranges, t.EffectiveRanges()) {
+			ranges = nil
+		}
+		if slices.Equal(sizes, t.EffectiveSizes()) {
+			sizes = nil
+		}
+		break
+	}
+	return ranges, sizes
+}
+
+var smiBaseModules = map[string]bool{
+	"SNMPv2-SMI":  true,
+	"RFC1155-SMI": true,
+	"RFC1065-SMI": true,
+}
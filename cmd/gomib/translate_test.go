@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib"
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func loadTestMib(t *testing.T, modules ...string) *mib.Mib {
+	t.Helper()
+	src, err := gomib.DirTree("../../testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+	m, err := gomib.Load(context.Background(), gomib.WithSource(src), gomib.WithModules(modules...))
+	testutil.NoError(t, err, "Load")
+	return m
+}
+
+// TestTranslateMatchesSnmptranslate checks flag and output pairs against
+// net-snmp 5.9's snmptranslate -m IF-MIB:SNMPv2-MIB.
+func TestTranslateMatchesSnmptranslate(t *testing.T) {
+	m := loadTestMib(t, "IF-MIB", "SNMPv2-MIB")
+
+	tests := []struct {
+		args string
+		want string
+	}{
+		{"ifDescr", "IF-MIB::ifDescr"},
+		{"-IR ifDescr", "IF-MIB::ifDescr"},
+		{"ifDescr.3", "IF-MIB::ifDescr.3"},
+		{"IF-MIB::ifDescr.3", "IF-MIB::ifDescr.3"},
+		{".1.3.6.1.2.1.2.2.1.2.3", "IF-MIB::ifDescr.3"},
+		{".1.3.6.1.4.1", "SNMPv2-SMI::enterprises"},
+		{".iso.org.dod.internet.mgmt.mib-2.system.sysDescr.0", "SNMPv2-MIB::sysDescr.0"},
+		{"-Ib ifDes.*", "IF-MIB::ifDescr"},
+		{"-OS .1.3.6.1.2.1.2.2.1.2", "IF-MIB::ifDescr"},
+		{"-On IF-MIB::ifDescr.3", ".1.3.6.1.2.1.2.2.1.2.3"},
+		{"-On -IR sysUpTime.0", ".1.3.6.1.2.1.1.3.0"},
+		{"-Of sysUpTime.0", ".iso.org.dod.internet.mgmt.mib-2.system.sysUpTime.0"},
+		{"-Os IF-MIB::ifDescr.3", "ifDescr.3"},
+		{"-Os .1.3.6.1.2.1.1.1.0", "sysDescr.0"},
+		{"-Ou .1.3.6.1.2.1.1.1.0", "system.sysDescr.0"},
+		{"-Ou ifDescr.3", "interfaces.ifTable.ifEntry.ifDescr.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			ta, err := parseTranslateArgs(strings.Fields(tt.args))
+			testutil.NoError(t, err, "parseTranslateArgs")
+			testutil.Len(t, ta.inputs, 1, "inputs")
+
+			var out bytes.Buffer
+			tr := &translator{m: m, opts: ta.opts}
+			testutil.NoError(t, tr.translate(&out, ta.inputs[0]), "translate")
+			testutil.Equal(t, tt.want, strings.TrimSpace(out.String()), "output")
+		})
+	}
+}
+
+func TestParseTranslateArgs(t *testing.T) {
+	ta, err := parseTranslateArgs([]string{"-mIF-MIB:+SNMPv2-MIB", "-M", "/a:/b", "-Td", "-On", "ifIndex"})
+	testutil.NoError(t, err, "parseTranslateArgs")
+	testutil.SliceEqual(t, []string{"IF-MIB", "SNMPv2-MIB"}, ta.modules, "modules")
+	testutil.SliceEqual(t, []string{"/a", "/b"}, ta.paths, "paths")
+	testutil.True(t, ta.opts.detail, "-Td")
+	testutil.Equal(t, byte('n'), ta.opts.output, "-On")
+
+	for _, bad := range []string{"-Ox", "-Tz", "-Iq", "-q", "-m"} {
+		_, err := parseTranslateArgs([]string{bad})
+		testutil.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golangsnmp/gomib"
+)
+
+const vendorUsage = `gomib vendor - Copy the files needed to load modules
+
+Usage:
+  gomib vendor (-o DIR | --zip FILE) [options] MODULE...
+
+Finds the named modules and every module they import, transitively,
+using the same search as load, and writes exactly those files to a flat
+directory or zip archive as MODULE.mib. Base SMI modules (SNMPv2-SMI,
+SNMPv2-TC, ...) are built in and not copied. The output loads with
+gomib.Dir or gomib.FS and gives the same result as the original paths.
+
+Options:
+  -o, --output DIR   Write files to DIR
+  --zip FILE         Write a zip archive instead of a directory
+  --embed PKG        Also write DIR/mibs.go declaring package PKG with an
+                     embed.FS of the files and a Source function
+  --manifest         Also write manifest.json with each file's module,
+                     original path, SHA-256 and LAST-UPDATED revision
+  -h, --help         Show help
+
+Imported modules that cannot be found are reported on stderr and
+listed in the manifest.
+
+Examples:
+  gomib vendor -o mibs IF-MIB IP-MIB
+  gomib vendor -o internal/mibs --embed mibs --manifest -p ./vendor-mibs ACME-MIB
+  gomib vendor --zip mibs.zip --manifest IF-MIB
+`
+
+const (
+	vendorManifest = "manifest.json"
+	vendorEmbed    = "mibs.go"
+)
+
+// zipEpoch is the modification time recorded in zip archives so that
+// the same input always produces the same archive.
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (c *cli) cmdVendor(args []string) int {
+	fs := flag.NewFlagSet("vendor", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, vendorUsage) }
+
+	output := fs.String("o", "", "output directory")
+	fs.StringVar(output, "output", "", "output directory")
+	zipFile := fs.String("zip", "", "output zip archive")
+	embedPkg := fs.String("embed", "", "package name for an embed.FS source file")
+	manifest := fs.Bool("manifest", false, "write manifest.json")
+	help := fs.Bool("h", false, "show help")
+	fs.BoolVar(help, "help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *help || c.helpFlag {
+		_, _ = fmt.Fprint(os.Stdout, vendorUsage)
+		return 0
+	}
+
+	modules := fs.Args()
+	if len(modules) == 0 {
+		printError("no modules specified")
+		fmt.Fprint(os.Stderr, vendorUsage)
+		return 1
+	}
+	if (*output == "") == (*zipFile == "") {
+		printError("specify exactly one of -o DIR or --zip FILE")
+		return 1
+	}
+	if *embedPkg != "" {
+		if *zipFile != "" {
+			printError("--embed requires -o DIR")
+			return 1
+		}
+		if !token.IsIdentifier(*embedPkg) {
+			printError("invalid package name: %s", *embedPkg)
+			return 1
+		}
+	}
+
+	opts, err := c.loadOptions(modules)
+	if err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	closure, err := gomib.Closure(context.Background(), opts...)
+	if err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	for _, name := range closure.Missing {
+		fmt.Fprintf(os.Stderr, "warning: imported module not found: %s\n", name)
+	}
+
+	files := make(map[string][]byte)
+	var names []string
+	add := func(name string, data []byte) {
+		names = append(names, name)
+		files[name] = data
+	}
+	for _, f := range closure.Files {
+		add(vendorFileName(f.Module), f.Content)
+	}
+	if *manifest {
+		data, err := vendorManifestJSON(closure)
+		if err != nil {
+			printError("%v", err)
+			return exitError
+		}
+		add(vendorManifest, data)
+	}
+	if *embedPkg != "" {
+		add(vendorEmbed, vendorEmbedSource(*embedPkg))
+	}
+
+	dest := *output
+	if *zipFile != "" {
+		dest = *zipFile
+		err = writeVendorZip(*zipFile, names, files)
+	} else {
+		err = writeVendorDir(*output, names, files)
+	}
+	if err != nil {
+		printError("%v", err)
+		return exitError
+	}
+	fmt.Fprintf(os.Stderr, "vendored %d modules to %s\n", len(closure.Files), dest)
+	return exitOK
+}
+
+func vendorFileName(module string) string { return module + ".mib" }
+
+type vendorManifestFile struct {
+	Module   string `json:"module"`
+	File     string `json:"file"`
+	Source   string `json:"source"`
+	SHA256   string `json:"sha256"`
+	Revision string `json:"revision,omitempty"`
+}
+
+type vendorManifestDoc struct {
+	Modules []vendorManifestFile `json:"modules"`
+	Missing []string             `json:"missing,omitempty"`
+}
+
+func vendorManifestJSON(closure *gomib.ClosureResult) ([]byte, error) {
+	doc := vendorManifestDoc{
+		Modules: []vendorManifestFile{},
+		Missing: closure.Missing,
+	}
+	for _, f := range closure.Files {
+		sum := sha256.Sum256(f.Content)
+		doc.Modules = append(doc.Modules, vendorManifestFile{
+			Module:   f.Module,
+			File:     vendorFileName(f.Module),
+			Source:   f.Path,
+			SHA256:   hex.EncodeToString(sum[:]),
+			Revision: f.Revision,
+		})
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func vendorEmbedSource(pkg string) []byte {
+	var b strings.Builder
+	b.WriteString("// Code generated by gomib vendor. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n\t\"embed\"\n\n\t\"github.com/golangsnmp/gomib\"\n)\n\n")
+	b.WriteString("//go:embed *.mib\nvar files embed.FS\n\n")
+	b.WriteString("// Source returns the vendored MIB files as a gomib source.\n")
+	fmt.Fprintf(&b, "func Source() gomib.Source { return gomib.FS(%q, files) }\n", pkg)
+	return []byte(b.String())
+}
+
+func writeVendorDir(dir string, names []string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	want := make(map[string]bool, len(names))
+	for _, name := range names {
+		want[name] = true
+		if err := os.WriteFile(filepath.Join(dir, name), files[name], 0o644); err != nil {
+			return err
+		}
+	}
+	// Leftover files from an earlier run would be embedded and loaded too,
+	// so the set would no longer be minimal.
+	stale, err := filepath.Glob(filepath.Join(dir, "*.mib"))
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if !want[filepath.Base(path)] {
+			fmt.Fprintf(os.Stderr, "warning: %s is not part of the closure\n", path)
+		}
+	}
+	return nil
+}
+
+func writeVendorZip(path string, names []string, files map[string][]byte) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	zw := zip.NewWriter(f)
+	for _, name := range names {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: zipEpoch,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
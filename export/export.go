@@ -0,0 +1,154 @@
+// Package export generates configuration, schema and source files for
+// external tools from a resolved [mib.Mib].
+//
+// Configuration exporters operate on a selection of tables and scalars,
+// chosen with [Select] by name, module or OID subtree; source generators
+// operate on whole modules. All write their output to an io.Writer.
+package export
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// Select resolves specs to the tables, scalars and columns they denote,
+// sorted by OID with duplicates removed. Each spec is one of:
+//
+//	ifTable             an object name; a row selects its table
+//	IF-MIB::ifXTable    a module-qualified object name
+//	IF-MIB              a module: all of its tables and scalars
+//	1.3.6.1.2.1.2       an OID: all tables and scalars in the subtree
+//
+// Columns selected by name are returned as-is; exporters treat them as
+// a partial table.
+func Select(m *mib.Mib, specs ...string) ([]*mib.Object, error) {
+	seen := make(map[*mib.Object]struct{})
+	var out []*mib.Object
+	add := func(obj *mib.Object) {
+		if obj.IsRow() {
+			obj = obj.Table()
+		}
+		if obj == nil {
+			return
+		}
+		if _, dup := seen[obj]; !dup {
+			seen[obj] = struct{}{}
+			out = append(out, obj)
+		}
+	}
+	addSubtree := func(nd *mib.Node) {
+		for n := range nd.Subtree() {
+			if obj := n.Object(); obj != nil && (obj.IsTable() || obj.IsScalar()) {
+				add(obj)
+			}
+		}
+	}
+
+	for _, spec := range specs {
+		if mod := m.Module(spec); mod != nil {
+			for _, obj := range mod.Objects() {
+				if obj.IsTable() || obj.IsScalar() {
+					add(obj)
+				}
+			}
+			continue
+		}
+		nd := lookupNode(m, spec)
+		if nd == nil {
+			return nil, fmt.Errorf("select %q: not found", spec)
+		}
+		if obj := nd.Object(); obj != nil {
+			add(obj)
+			continue
+		}
+		if nd.Notification() != nil {
+			return nil, fmt.Errorf("select %q: a notification has no values to export", spec)
+		}
+		addSubtree(nd)
+	}
+	slices.SortFunc(out, func(a, b *mib.Object) int { return a.OID().Compare(b.OID()) })
+	return out, nil
+}
+
+// indexNames returns a distinct name for each INDEX component: the
+// object's name, numbered from its second occurrence when an INDEX
+// names an object more than once, as RMON2-MIB alHostEntry does with
+// protocolDirLocalIndex ("protocolDirLocalIndex2").
+func indexNames(indexes []mib.IndexEntry) []string {
+	out := make([]string, len(indexes))
+	seen := make(map[string]int)
+	for i, idx := range indexes {
+		name := idx.Object.Name()
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name += strconv.Itoa(n)
+		}
+		out[i] = name
+	}
+	return out
+}
+
+// lookupNode resolves a plain name, MODULE::name, or numeric OID.
+func lookupNode(m *mib.Mib, spec string) *mib.Node {
+	if modName, name, ok := strings.Cut(spec, "::"); ok {
+		mod := m.Module(modName)
+		if mod == nil {
+			return nil
+		}
+		if obj := mod.Object(name); obj != nil {
+			return obj.Node()
+		}
+		return mod.Node(name)
+	}
+	if q := strings.TrimPrefix(spec, "."); q != "" && q[0] >= '0' && q[0] <= '9' {
+		oid, err := mib.ParseOID(q)
+		if err != nil {
+			return nil
+		}
+		return m.NodeByOID(oid)
+	}
+	return m.Node(spec)
+}
+
+// tableColumns returns the readable columns of a selected table or the
+// column itself, and the row they belong to.
+func tableColumns(obj *mib.Object) (row *mib.Object, cols []*mib.Object) {
+	switch {
+	case obj.IsTable():
+		row = obj.Entry()
+		cols = row.Columns()
+	case obj.IsColumn():
+		row = obj.Row()
+		cols = []*mib.Object{obj}
+	}
+	return row, cols
+}
+
+// firstSentence returns the first sentence of a DESCRIPTION with
+// whitespace collapsed.
+func firstSentence(desc string) string {
+	s := strings.Join(strings.Fields(desc), " ")
+	if i := strings.Index(s, ". "); i >= 0 {
+		return s[:i+1]
+	}
+	return s
+}
+
+// hasTC reports whether module::name appears in t's type chain.
+func hasTC(t *mib.Type, module, name string) bool {
+	for ; t != nil; t = t.Parent() {
+		if t.Name() == name && t.Module() != nil && t.Module().Name() == module {
+			return true
+		}
+	}
+	return false
+}
+
+// isTextHint reports whether a DISPLAY-HINT renders octets as text.
+func isTextHint(hint string) bool {
+	return hint != "" && strings.ContainsAny(hint, "at") && !strings.ContainsAny(hint, "xdo")
+}
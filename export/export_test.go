@@ -0,0 +1,80 @@
+package export
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golangsnmp/gomib"
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+var (
+	loadOnce  sync.Once
+	loadedMib *mib.Mib
+	loadErr   error
+)
+
+func loadTestMIB(t testing.TB) *mib.Mib {
+	t.Helper()
+	loadOnce.Do(func() {
+		src, err := gomib.DirTree("../testdata/corpus/primary")
+		if err != nil {
+			loadErr = err
+			return
+		}
+		loadedMib, loadErr = gomib.Load(context.Background(),
+			gomib.WithSource(src), gomib.WithModules("IF-MIB", "SNMPv2-MIB", "IP-MIB", "ENTITY-MIB", "ENTITY-STATE-MIB", "RMON2-MIB"))
+	})
+	if loadErr != nil {
+		t.Fatalf("failed to load test MIBs: %v", loadErr)
+	}
+	return loadedMib
+}
+
+func names(objs []*mib.Object) []string {
+	out := make([]string, len(objs))
+	for i, o := range objs {
+		out[i] = o.Name()
+	}
+	return out
+}
+
+func TestSelect(t *testing.T) {
+	m := loadTestMIB(t)
+
+	tests := []struct {
+		specs []string
+		want  []string
+	}{
+		{[]string{"ifTable"}, []string{"ifTable"}},
+		{[]string{"ifEntry"}, []string{"ifTable"}},
+		{[]string{"IF-MIB::ifXTable", "ifTable"}, []string{"ifTable", "ifXTable"}},
+		{[]string{"1.3.6.1.2.1.2"}, []string{"ifNumber", "ifTable"}},
+		{[]string{"interfaces", "ifNumber"}, []string{"ifNumber", "ifTable"}},
+		{[]string{"ifInOctets"}, []string{"ifInOctets"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.specs, ","), func(t *testing.T) {
+			got, err := Select(m, tt.specs...)
+			testutil.NoError(t, err, "Select")
+			testutil.SliceEqual(t, tt.want, names(got), "selection")
+		})
+	}
+
+	got, err := Select(m, "IF-MIB")
+	testutil.NoError(t, err, "Select module")
+	testutil.Greater(t, len(got), 5, "IF-MIB tables and scalars")
+
+	_, err = Select(m, "noSuchObject")
+	testutil.Error(t, err, "unknown spec")
+	_, err = Select(m, "linkDown")
+	testutil.Error(t, err, "notification spec")
+}
+
+func TestFirstSentence(t *testing.T) {
+	testutil.Equal(t, "The first.", firstSentence("The  first.  The second."), "two sentences")
+	testutil.Equal(t, "No period", firstSentence("No\n   period"), "no period")
+}
@@ -0,0 +1,613 @@
+package export
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// GoOptions configures Go source generation.
+type GoOptions struct {
+	// Package is the package name of the generated file. It defaults
+	// to the first module's name, lowercased with punctuation removed
+	// ("ifmib").
+	Package string
+}
+
+// WriteGo writes a Go source file with typed access to the tables,
+// scalars and notifications of mods.
+//
+// Each conceptual row becomes a struct with one typed field per
+// readable non-index column, an Index struct with a Parse function for
+// instance suffixes, a Split function for full column instance OIDs,
+// and a Set method that stores a value as delivered by an SNMP library.
+// Enumerations become named integer types with constants and a String
+// method; BITS become named byte-slice types with bit constants and a
+// Has method. Scalars and notifications get OID string constants.
+//
+// The generated file depends only on the standard library.
+func WriteGo(w io.Writer, mods []*mib.Module, opts GoOptions) error {
+	if len(mods) == 0 {
+		return fmt.Errorf("go: no modules")
+	}
+	g := &goGen{
+		names:   make(map[string]bool),
+		types:   make(map[any]*goType),
+		imports: make(map[string]bool),
+		helpers: make(map[string]bool),
+	}
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = goPackageName(mods[0].Name())
+	}
+
+	for _, mod := range mods {
+		g.module(mod)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by gomib gen go. DO NOT EDIT.\n\n")
+	var modNames []string
+	for _, mod := range mods {
+		modNames = append(modNames, mod.Name())
+	}
+	fmt.Fprintf(&b, "// Package %s provides typed access to %s objects.\n", pkg, strings.Join(modNames, ", "))
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", pkg)
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	for _, imp := range imports {
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+	b.WriteString(")\n")
+	b.WriteString(g.decls.String())
+	b.WriteString(g.typeDecls.String())
+	for _, name := range goHelperOrder {
+		if g.helpers[name] {
+			b.WriteString(goHelpers[name])
+		}
+	}
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return fmt.Errorf("go: formatting generated source: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// goKind selects the Go representation of an SMI value.
+type goKind int
+
+const (
+	goInt32 goKind = iota
+	goUint32
+	goUint64
+	goEnum
+	goBits
+	goString
+	goBytes
+	goAddr
+	goOID
+)
+
+type goType struct {
+	kind goKind
+	name string // Go type name
+}
+
+type goGen struct {
+	decls     strings.Builder
+	typeDecls strings.Builder
+	names     map[string]bool
+	types     map[any]*goType // *mib.Type or *mib.Object owning an enum/BITS type
+	imports   map[string]bool
+	helpers   map[string]bool
+}
+
+// ident reserves a unique exported identifier derived from name.
+func (g *goGen) ident(name string) string {
+	id := name
+	for i := 2; g.names[id]; i++ {
+		id = fmt.Sprintf("%s%d", name, i)
+	}
+	g.names[id] = true
+	return id
+}
+
+func (g *goGen) module(mod *mib.Module) {
+	scalars := sortedByOID(mod.Scalars())
+	if len(scalars) > 0 {
+		fmt.Fprintf(&g.decls, "\n// OIDs of %s scalars. Instances are OID.0.\nconst (\n", mod.Name())
+		for _, obj := range scalars {
+			fmt.Fprintf(&g.decls, "\t%s = %q // %s::%s\n", g.ident(goName(obj.Name())+"OID"), obj.OID().String(), mod.Name(), obj.Name())
+		}
+		g.decls.WriteString(")\n")
+	}
+
+	notifs := mod.Notifications()
+	slices.SortFunc(notifs, func(a, b *mib.Notification) int { return a.OID().Compare(b.OID()) })
+	if len(notifs) > 0 {
+		fmt.Fprintf(&g.decls, "\n// OIDs of %s notifications.\nconst (\n", mod.Name())
+		for _, n := range notifs {
+			fmt.Fprintf(&g.decls, "\t%s = %q // %s::%s\n", g.ident(goName(n.Name())+"OID"), n.OID().String(), mod.Name(), n.Name())
+		}
+		g.decls.WriteString(")\n")
+	}
+
+	for _, table := range sortedByOID(mod.Tables()) {
+		if row := table.Entry(); row != nil {
+			g.row(table, row)
+		}
+	}
+}
+
+func (g *goGen) row(table, row *mib.Object) {
+	type field struct {
+		obj    *mib.Object
+		name   string
+		typ    *goType
+		column string // column number constant
+	}
+	var index []field
+	indexes := row.EffectiveIndexes()
+	for i, name := range indexNames(indexes) {
+		typ := g.goType(indexes[i].Object)
+		if typ == nil {
+			return
+		}
+		index = append(index, field{obj: indexes[i].Object, name: goName(name), typ: typ})
+	}
+	if len(index) == 0 {
+		return
+	}
+	g.imports["fmt"] = true
+	var cols []field
+	for _, col := range row.Columns() {
+		if slices.ContainsFunc(index, func(f field) bool { return f.obj == col }) {
+			continue
+		}
+		if a := col.Access(); a == mib.AccessNotAccessible || a == mib.AccessAccessibleForNotify {
+			continue
+		}
+		if typ := g.goType(col); typ != nil {
+			cols = append(cols, field{obj: col, name: goName(col.Name()), typ: typ})
+		}
+	}
+
+	qual := row.Module().Name() + "::" + row.Name()
+	rowName := g.ident(goName(row.Name()))
+	indexName := g.ident(rowName + "Index")
+	oidName := g.ident(rowName + "OID")
+	arcsName := strings.ToLower(rowName[:1]) + rowName[1:] + "Arcs"
+	d := &g.decls
+
+	fmt.Fprintf(d, "\n// %s is the OID of %s. Column instances are %s.column.index.\n", oidName, qual, oidName)
+	fmt.Fprintf(d, "const %s = %q\n\n", oidName, row.OID().String())
+	fmt.Fprintf(d, "var %s = []uint32{%s}\n", arcsName, joinArcs(row.OID()))
+
+	if len(cols) > 0 {
+		fmt.Fprintf(d, "\n// Column numbers of %s.\nconst (\n", qual)
+		for i, c := range cols {
+			cols[i].column = g.ident(c.name + "Column")
+			fmt.Fprintf(d, "\t%s uint32 = %d\n", cols[i].column, c.obj.OID().LastArc())
+		}
+		d.WriteString(")\n")
+	}
+
+	fmt.Fprintf(d, "\n// %s is the INDEX of %s.\ntype %s struct {\n", indexName, qual, indexName)
+	for _, f := range index {
+		fmt.Fprintf(d, "\t%s %s\n", f.name, f.typ.name)
+	}
+	d.WriteString("}\n")
+
+	fmt.Fprintf(d, "\n// Parse%s decodes the instance suffix of %s columns.\n", indexName, qual)
+	fmt.Fprintf(d, "func Parse%s(suffix []uint32) (%s, error) {\n", indexName, indexName)
+	fmt.Fprintf(d, "\tvar idx %s\n\trest := suffix\n", indexName)
+	for i, f := range index {
+		g.indexDecode(d, f.obj, f.name, f.typ, indexes[i].Implied)
+	}
+	fmt.Fprintf(d, "\tif len(rest) != 0 {\n\t\treturn idx, fmt.Errorf(\"%s: %%d trailing index arcs\", len(rest))\n\t}\n", row.Name())
+	d.WriteString("\treturn idx, nil\n}\n")
+
+	fmt.Fprintf(d, "\n// Split%s splits a column instance OID of %s into column number and index.\n", rowName, qual)
+	fmt.Fprintf(d, "func Split%s(oid []uint32) (uint32, %s, error) {\n", rowName, indexName)
+	fmt.Fprintf(d, "\tif len(oid) <= len(%s) || !slicesEqual(oid[:len(%s)], %s) {\n", arcsName, arcsName, arcsName)
+	fmt.Fprintf(d, "\t\treturn 0, %s{}, fmt.Errorf(\"%s: OID not in table\")\n\t}\n", indexName, row.Name())
+	fmt.Fprintf(d, "\tidx, err := Parse%s(oid[len(%s)+1:])\n", indexName, arcsName)
+	fmt.Fprintf(d, "\treturn oid[len(%s)], idx, err\n}\n", arcsName)
+	g.helpers["slicesEqual"] = true
+
+	fmt.Fprintf(d, "\n// %s is a row of %s.\ntype %s struct {\n\tIndex %s\n", rowName, table.Module().Name()+"::"+table.Name(), rowName, indexName)
+	for _, c := range cols {
+		fmt.Fprintf(d, "\t%s %s\n", c.name, c.typ.name)
+	}
+	d.WriteString("}\n")
+
+	fmt.Fprintf(d, "\n// Set stores v, as delivered by an SNMP library, in column col.\n")
+	fmt.Fprintf(d, "func (r *%s) Set(col uint32, v any) error {\n", rowName)
+	if len(cols) > 0 {
+		d.WriteString("\tswitch col {\n")
+		for _, c := range cols {
+			fmt.Fprintf(d, "\tcase %s:\n", c.column)
+			g.valueDecode(d, c.obj, "r."+c.name, c.typ)
+			d.WriteString("\t\treturn nil\n")
+		}
+		d.WriteString("\t}\n")
+	}
+	fmt.Fprintf(d, "\treturn fmt.Errorf(\"%s: unknown column %%d\", col)\n}\n", row.Name())
+}
+
+// valueDecode writes a case body converting v into dst.
+func (g *goGen) valueDecode(d *strings.Builder, obj *mib.Object, dst string, typ *goType) {
+	wrap := fmt.Sprintf("\t\tif err != nil {\n\t\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t\t}\n", obj.Name())
+	switch typ.kind {
+	case goInt32, goEnum:
+		g.helpers["asInt64"] = true
+		fmt.Fprintf(d, "\t\tn, err := asInt64(v)\n%s\t\t%s = %s(n)\n", wrap, dst, typ.name)
+	case goUint32, goUint64:
+		g.helpers["asUint64"] = true
+		fmt.Fprintf(d, "\t\tn, err := asUint64(v)\n%s\t\t%s = %s(n)\n", wrap, dst, typ.name)
+	case goBytes:
+		g.helpers["asBytes"] = true
+		fmt.Fprintf(d, "\t\tb, err := asBytes(v)\n%s\t\t%s = b\n", wrap, dst)
+	case goString, goBits:
+		g.helpers["asBytes"] = true
+		fmt.Fprintf(d, "\t\tb, err := asBytes(v)\n%s\t\t%s = %s(b)\n", wrap, dst, typ.name)
+	case goAddr:
+		g.helpers["asBytes"] = true
+		fmt.Fprintf(d, "\t\tb, err := asBytes(v)\n%s", wrap)
+		fmt.Fprintf(d, "\t\taddr, ok := netip.AddrFromSlice(b)\n\t\tif !ok {\n\t\t\treturn fmt.Errorf(\"%s: %%d-octet address\", len(b))\n\t\t}\n", obj.Name())
+		fmt.Fprintf(d, "\t\t%s = addr\n", dst)
+	case goOID:
+		g.helpers["asOID"] = true
+		fmt.Fprintf(d, "\t\toid, err := asOID(v)\n%s\t\t%s = oid\n", wrap, dst)
+	}
+}
+
+// indexDecode writes the statements decoding one index component from
+// rest into idx.name.
+func (g *goGen) indexDecode(d *strings.Builder, obj *mib.Object, name string, typ *goType, implied bool) {
+	wrap := fmt.Sprintf("\tif err != nil {\n\t\treturn idx, fmt.Errorf(\"%s: %%w\", err)\n\t}\n", obj.Name())
+	var size int
+	if n, ok := fixedSize(obj); ok {
+		size = n
+	}
+	switch typ.kind {
+	case goInt32, goUint32, goUint64, goEnum:
+		g.helpers["indexInt"] = true
+		fmt.Fprintf(d, "\t{\n\tn, r, err := indexInt(rest)\n%s\tidx.%s, rest = %s(n), r\n\t}\n", wrap, name, typ.name)
+	case goAddr:
+		g.helpers["indexBytes"] = true
+		fmt.Fprintf(d, "\t{\n\tb, r, err := indexBytes(rest, 4, false)\n%s\tidx.%s, rest = netip.AddrFrom4([4]byte(b)), r\n\t}\n", wrap, name)
+	case goString, goBytes, goBits:
+		g.helpers["indexBytes"] = true
+		if size == 0 {
+			size = -1
+		}
+		conv := "b"
+		if typ.kind != goBytes {
+			conv = typ.name + "(b)"
+		}
+		fmt.Fprintf(d, "\t{\n\tb, r, err := indexBytes(rest, %d, %t)\n%s\tidx.%s, rest = %s, r\n\t}\n", size, implied, wrap, name, conv)
+	case goOID:
+		g.helpers["indexOID"] = true
+		fmt.Fprintf(d, "\t{\n\toid, r, err := indexOID(rest, %t)\n%s\tidx.%s, rest = oid, r\n\t}\n", implied, wrap, name)
+	}
+}
+
+// goType returns the Go representation of obj's value, declaring enum
+// and BITS types on first use, or nil for unsupported syntax.
+func (g *goGen) goType(obj *mib.Object) *goType {
+	t := obj.Type()
+	if t == nil {
+		return nil
+	}
+	if enums := obj.EffectiveEnums(); len(enums) > 0 {
+		return g.namedType(obj, t, goEnum, enums)
+	}
+	if bits := obj.EffectiveBits(); len(bits) > 0 {
+		return g.namedType(obj, t, goBits, bits)
+	}
+	switch t.EffectiveBase() {
+	case mib.BaseInteger32:
+		return &goType{goInt32, "int32"}
+	case mib.BaseUnsigned32, mib.BaseGauge32, mib.BaseCounter32, mib.BaseTimeTicks:
+		return &goType{goUint32, "uint32"}
+	case mib.BaseCounter64:
+		return &goType{goUint64, "uint64"}
+	case mib.BaseIpAddress:
+		g.imports["net/netip"] = true
+		return &goType{goAddr, "netip.Addr"}
+	case mib.BaseObjectIdentifier:
+		return &goType{goOID, "[]uint32"}
+	case mib.BaseOctetString:
+		if isTextHint(obj.EffectiveDisplayHint()) {
+			return &goType{goString, "string"}
+		}
+		return &goType{goBytes, "[]byte"}
+	case mib.BaseOpaque:
+		return &goType{goBytes, "[]byte"}
+	}
+	return nil
+}
+
+// namedType returns the enum or BITS type for obj. Values taken
+// unchanged from a textual convention share one type named after it;
+// inline or refined values get a type named after the object.
+func (g *goGen) namedType(obj *mib.Object, t *mib.Type, kind goKind, values []mib.NamedValue) *goType {
+	var owner any = obj
+	name := goName(obj.Name())
+	qual := obj.Module().Name() + "::" + obj.Name()
+	if tc := namedValuesOwner(t, kind, values); tc != nil {
+		owner, name = tc, goName(tc.Name())
+		if tc.Module() != nil {
+			qual = tc.Module().Name() + "::" + tc.Name()
+		}
+	}
+	if typ, ok := g.types[owner]; ok {
+		return typ
+	}
+	typ := &goType{kind: kind, name: g.ident(name)}
+	g.types[owner] = typ
+
+	d := &g.typeDecls
+	if kind == goEnum {
+		g.imports["strconv"] = true
+		fmt.Fprintf(d, "\n// %s is the enumeration of %s.\ntype %s int32\n\nconst (\n", typ.name, qual, typ.name)
+		consts := make([]string, len(values))
+		for i, nv := range values {
+			consts[i] = g.ident(typ.name + goName(nv.Label))
+			fmt.Fprintf(d, "\t%s %s = %d\n", consts[i], typ.name, nv.Value)
+		}
+		fmt.Fprintf(d, ")\n\nfunc (v %s) String() string {\n\tswitch v {\n", typ.name)
+		for i, nv := range values {
+			fmt.Fprintf(d, "\tcase %s:\n\t\treturn %q\n", consts[i], nv.Label)
+		}
+		fmt.Fprintf(d, "\t}\n\treturn \"%s(\" + strconv.FormatInt(int64(v), 10) + \")\"\n}\n", typ.name)
+		return typ
+	}
+
+	g.imports["strings"] = true
+	fmt.Fprintf(d, "\n// %s is a BITS value of %s.\ntype %s []byte\n\n", typ.name, qual, typ.name)
+	fmt.Fprintf(d, "// Bit positions of %s.\nconst (\n", typ.name)
+	consts := make([]string, len(values))
+	for i, nv := range values {
+		consts[i] = g.ident(typ.name + goName(nv.Label))
+		fmt.Fprintf(d, "\t%s = %d\n", consts[i], nv.Value)
+	}
+	d.WriteString(")\n\n")
+	fmt.Fprintf(d, "// Has reports whether bit is set.\nfunc (b %s) Has(bit int) bool {\n", typ.name)
+	d.WriteString("\ti := bit / 8\n\treturn bit >= 0 && i < len(b) && b[i]&(0x80>>(bit%8)) != 0\n}\n\n")
+	fmt.Fprintf(d, "func (b %s) String() string {\n\tvar names []string\n", typ.name)
+	for i, nv := range values {
+		fmt.Fprintf(d, "\tif b.Has(%s) {\n\t\tnames = append(names, %q)\n\t}\n", consts[i], nv.Label)
+	}
+	d.WriteString("\treturn \"{\" + strings.Join(names, \" \") + \"}\"\n}\n")
+	return typ
+}
+
+// namedValuesOwner returns the textual convention in t's chain that
+// defines exactly values, or nil when the object refines them.
+func namedValuesOwner(t *mib.Type, kind goKind, values []mib.NamedValue) *mib.Type {
+	for ; t != nil; t = t.Parent() {
+		own := t.Enums()
+		if kind == goBits {
+			own = t.Bits()
+		}
+		if len(own) == 0 {
+			continue
+		}
+		if t.IsTextualConvention() && slices.Equal(own, values) {
+			return t
+		}
+		return nil
+	}
+	return nil
+}
+
+func sortedByOID(objs []*mib.Object) []*mib.Object {
+	slices.SortFunc(objs, func(a, b *mib.Object) int { return a.OID().Compare(b.OID()) })
+	return objs
+}
+
+func joinArcs(oid mib.OID) string {
+	parts := make([]string, len(oid))
+	for i, arc := range oid {
+		parts[i] = fmt.Sprint(arc)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// goName converts an SMI descriptor or enum label to an exported Go
+// identifier: "ifAdminStatus" becomes "IfAdminStatus", "ip-forward"
+// becomes "IpForward".
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" || !unicode.IsLetter(rune(out[0])) {
+		out = "X" + out
+	}
+	return out
+}
+
+// goPackageName derives a package name from a module name.
+func goPackageName(module string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(module) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' && b.Len() > 0 {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "mib"
+	}
+	return b.String()
+}
+
+// goHelpers are the support functions emitted into generated files
+// when used.
+var goHelpers = map[string]string{
+	"slicesEqual": `
+func slicesEqual(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+`,
+	"asInt64": `
+func asInt64(v any) (int64, error) {
+	switch x := v.(type) {
+	case int:
+		return int64(x), nil
+	case int8:
+		return int64(x), nil
+	case int16:
+		return int64(x), nil
+	case int32:
+		return int64(x), nil
+	case int64:
+		return x, nil
+	case uint:
+		return int64(x), nil
+	case uint8:
+		return int64(x), nil
+	case uint16:
+		return int64(x), nil
+	case uint32:
+		return int64(x), nil
+	case uint64:
+		return int64(x), nil
+	}
+	return 0, fmt.Errorf("unexpected %T for integer", v)
+}
+`,
+	"asUint64": `
+func asUint64(v any) (uint64, error) {
+	switch x := v.(type) {
+	case int:
+		return uint64(x), nil
+	case int8:
+		return uint64(x), nil
+	case int16:
+		return uint64(x), nil
+	case int32:
+		return uint64(x), nil
+	case int64:
+		return uint64(x), nil
+	case uint:
+		return uint64(x), nil
+	case uint8:
+		return uint64(x), nil
+	case uint16:
+		return uint64(x), nil
+	case uint32:
+		return uint64(x), nil
+	case uint64:
+		return x, nil
+	}
+	return 0, fmt.Errorf("unexpected %T for unsigned integer", v)
+}
+`,
+	"asBytes": `
+func asBytes(v any) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		return []byte(x), nil
+	}
+	return nil, fmt.Errorf("unexpected %T for octet string", v)
+}
+`,
+	"asOID": `
+func asOID(v any) ([]uint32, error) {
+	switch x := v.(type) {
+	case []uint32:
+		return x, nil
+	case []int:
+		oid := make([]uint32, len(x))
+		for i, arc := range x {
+			oid[i] = uint32(arc)
+		}
+		return oid, nil
+	}
+	return nil, fmt.Errorf("unexpected %T for object identifier", v)
+}
+`,
+	"indexInt": `
+func indexInt(s []uint32) (uint32, []uint32, error) {
+	if len(s) == 0 {
+		return 0, nil, fmt.Errorf("index truncated")
+	}
+	return s[0], s[1:], nil
+}
+`,
+	"indexBytes": `
+// indexBytes decodes a string index component: size octets when size
+// is positive, the remaining arcs when implied, else length-prefixed.
+func indexBytes(s []uint32, size int, implied bool) ([]byte, []uint32, error) {
+	n := size
+	switch {
+	case n > 0:
+	case implied:
+		n = len(s)
+	case len(s) == 0:
+		return nil, nil, fmt.Errorf("index truncated")
+	default:
+		n, s = int(s[0]), s[1:]
+	}
+	if n > len(s) {
+		return nil, nil, fmt.Errorf("index truncated")
+	}
+	b := make([]byte, n)
+	for i, arc := range s[:n] {
+		if arc > 255 {
+			return nil, nil, fmt.Errorf("index arc %d out of octet range", arc)
+		}
+		b[i] = byte(arc)
+	}
+	return b, s[n:], nil
+}
+`,
+	"indexOID": `
+func indexOID(s []uint32, implied bool) ([]uint32, []uint32, error) {
+	n := len(s)
+	if !implied {
+		if len(s) == 0 || int(s[0]) > len(s)-1 {
+			return nil, nil, fmt.Errorf("index truncated")
+		}
+		n, s = int(s[0]), s[1:]
+	}
+	return append([]uint32(nil), s[:n]...), s[n:], nil
+}
+`,
+}
+
+var goHelperOrder = []string{"slicesEqual", "asInt64", "asUint64", "asBytes", "asOID", "indexInt", "indexBytes", "indexOID"}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func generateGo(t *testing.T, modules ...string) string {
+	t.Helper()
+	m := loadTestMIB(t)
+	var mods []*mib.Module
+	for _, name := range modules {
+		mod := m.Module(name)
+		testutil.NotNil(t, mod, "module %s", name)
+		mods = append(mods, mod)
+	}
+	var b strings.Builder
+	err := WriteGo(&b, mods, GoOptions{})
+	testutil.NoError(t, err, "WriteGo")
+	return b.String()
+}
+
+var blanks = regexp.MustCompile(`[ \t]+`)
+
+// containsCode asserts that src contains want, ignoring gofmt alignment.
+func containsCode(t *testing.T, src, want, msg string) {
+	t.Helper()
+	testutil.Contains(t, blanks.ReplaceAllString(src, " "), blanks.ReplaceAllString(want, " "), msg)
+}
+
+func TestWriteGoTypeChecks(t *testing.T) {
+	tests := []struct {
+		modules []string
+		pkg     string
+	}{
+		{[]string{"IF-MIB", "IP-MIB", "ENTITY-MIB", "ENTITY-STATE-MIB"}, "ifmib"},
+		// Textual conventions only: no tables, so no fmt.
+		{[]string{"SNMPv2-TC"}, "snmpv2tc"},
+		// alHostEntry and others name protocolDirLocalIndex twice.
+		{[]string{"RMON2-MIB"}, "rmon2mib"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			src := generateGo(t, tt.modules...)
+
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "gen.go", src, parser.ParseComments)
+			testutil.NoError(t, err, "parse generated source")
+			testutil.Equal(t, tt.pkg, f.Name.Name, "package name")
+
+			conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+			_, err = conf.Check(tt.pkg, fset, []*ast.File{f}, nil)
+			testutil.NoError(t, err, "type-check generated source")
+		})
+	}
+}
+
+func TestWriteGoRepeatedIndex(t *testing.T) {
+	src := generateGo(t, "RMON2-MIB")
+	containsCode(t, src, "type AlHostEntryIndex struct {\n\tHlHostControlIndex int32\n\tAlHostTimeMark uint32\n"+
+		"\tProtocolDirLocalIndex int32\n\tNlHostAddress []byte\n\tProtocolDirLocalIndex2 int32\n}\n", "repeated index component")
+}
+
+func TestWriteGoIfMib(t *testing.T) {
+	src := generateGo(t, "IF-MIB")
+
+	testutil.True(t, strings.HasPrefix(src, "// Code generated by gomib gen go. DO NOT EDIT.\n"), "generated header")
+	containsCode(t, src, "\tIfNumberOID = \"1.3.6.1.2.1.2.1\" // IF-MIB::ifNumber\n", "scalar OID")
+	containsCode(t, src, "\tLinkDownOID = \"1.3.6.1.6.3.1.1.5.3\" // IF-MIB::linkDown\n", "notification OID")
+	containsCode(t, src, "type IfEntryIndex struct {\n\tIfIndex int32\n}\n", "index struct")
+	containsCode(t, src, "\tIfDescr ", "text column field")
+	containsCode(t, src, "\tIfHCInOctets ", "counter64 field")
+	containsCode(t, src, "type IfAdminStatus int32\n", "inline enum type named after object")
+	containsCode(t, src, "\tIfAdminStatusTesting IfAdminStatus = 3\n", "enum constant")
+	containsCode(t, src, "\tcase IfAdminStatusTesting:\n\t\treturn \"testing\"\n", "enum String")
+	containsCode(t, src, "type TruthValue int32\n", "TC enum type named after TC")
+	containsCode(t, src, "func SplitIfXEntry(oid []uint32) (uint32, IfXEntryIndex, error) {\n", "split function")
+	testutil.False(t, strings.Contains(src, "IfIndexColumn"), "index column is not a row field")
+	testutil.False(t, strings.Contains(src, "strings\""), "strings imported only for BITS")
+}
+
+func TestWriteGoBits(t *testing.T) {
+	src := generateGo(t, "ENTITY-STATE-MIB")
+
+	containsCode(t, src, "type EntityAlarmStatus []byte\n", "BITS type named after TC")
+	containsCode(t, src, "\tEntityAlarmStatusCritical = 2\n", "bit constant")
+	containsCode(t, src, "func (b EntityAlarmStatus) Has(bit int) bool {\n", "Has method")
+	containsCode(t, src, "\tEntStateAlarm EntityAlarmStatus\n", "BITS column field")
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"ifAdminStatus", "IfAdminStatus"},
+		{"ip-forward", "IpForward"},
+		{"ethernetCsmacd", "EthernetCsmacd"},
+		{"x25ple", "X25ple"},
+		{"1x", "X1x"},
+	}
+	for _, tt := range tests {
+		testutil.Equal(t, tt.want, goName(tt.in), "goName(%q)", tt.in)
+	}
+	testutil.Equal(t, "ifmib", goPackageName("IF-MIB"), "package name")
+	testutil.Equal(t, "snmpv2mib", goPackageName("SNMPv2-MIB"), "package name")
+}
@@ -0,0 +1,367 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// JSONSchemaOptions configures JSON Schema and OpenAPI output.
+type JSONSchemaOptions struct {
+	// OpenAPI wraps the schemas in an OpenAPI 3.1 document under
+	// components.schemas instead of a JSON Schema $defs document.
+	OpenAPI bool
+
+	// Title is the document title. It defaults to the first selected
+	// object's module name.
+	Title string
+
+	// Version is the OpenAPI info.version. It defaults to the module's
+	// LAST-UPDATED value.
+	Version string
+}
+
+// WriteJSONSchema writes a JSON Schema (draft 2020-12) document with
+// one object schema per selected table row and per group of selected
+// scalars sharing a parent node, named like the generated Go types
+// ("IfEntry", "Interfaces").
+//
+// Properties carry the object's description and OID (x-oid). Enums
+// become string enums of their labels, with the numeric values in
+// x-enum-values; BITS become arrays of unique bit labels. Integer
+// ranges become minimum/maximum, and OCTET STRING sizes become
+// minLength/maxLength. Strings are formatted by textual convention or
+// DISPLAY-HINT: DateAndTime is a date-time, IpAddress and
+// InetAddressIPv4/IPv6 are ipv4/ipv6, InetAddress is a textual address
+// naming its type object in x-address-type, MAC addresses and other
+// undecorated binary strings are colon-separated or plain hex. Index
+// objects are required; read-only objects are readOnly and write-only
+// objects writeOnly.
+//
+// The schemas use only keywords common to JSON Schema 2020-12 and
+// OpenAPI 3.x.
+func WriteJSONSchema(w io.Writer, m *mib.Mib, objs []*mib.Object, opts JSONSchemaOptions) error {
+	if len(objs) == 0 {
+		return fmt.Errorf("jsonschema: no objects selected")
+	}
+	title := opts.Title
+	version := opts.Version
+	if mod := objs[0].Module(); mod != nil {
+		if title == "" {
+			title = mod.Name()
+		}
+		if version == "" {
+			version = mod.LastUpdated()
+		}
+	}
+	if version == "" {
+		version = "0"
+	}
+
+	names := make(map[string]bool)
+	var schemas jsonObject
+	var parents []*mib.Node
+	scalars := make(map[*mib.Node][]*mib.Object)
+	for _, obj := range objs {
+		switch {
+		case obj.IsScalar():
+			parent := obj.Node().Parent()
+			if _, ok := scalars[parent]; !ok {
+				parents = append(parents, parent)
+			}
+			scalars[parent] = append(scalars[parent], obj)
+		case obj.IsTable(), obj.IsColumn():
+			row, cols := tableColumns(obj)
+			if row == nil {
+				continue
+			}
+			name := uniqueName(goName(row.Name()), names)
+			schemas = append(schemas, jsonField{name, rowSchema(row, cols)})
+		}
+	}
+	for _, parent := range parents {
+		name := uniqueName(goName(parent.Name()), names)
+		schemas = append(schemas, jsonField{name, scalarGroupSchema(parent, scalars[parent])})
+	}
+
+	var doc jsonObject
+	if opts.OpenAPI {
+		doc = jsonObject{
+			{"openapi", "3.1.0"},
+			{"info", jsonObject{{"title", title}, {"version", version}}},
+			{"paths", jsonObject{}},
+			{"components", jsonObject{{"schemas", schemas}}},
+		}
+	} else {
+		doc = jsonObject{
+			{"$schema", "https://json-schema.org/draft/2020-12/schema"},
+			{"title", title},
+			{"$defs", schemas},
+		}
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// jsonObject is a JSON object that keeps its key order.
+type jsonObject []jsonField
+
+type jsonField struct {
+	Key   string
+	Value any
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	id := name
+	for i := 2; used[id]; i++ {
+		id = fmt.Sprintf("%s%d", name, i)
+	}
+	used[id] = true
+	return id
+}
+
+func rowSchema(row *mib.Object, cols []*mib.Object) jsonObject {
+	var props jsonObject
+	var required []string
+	var ownIndex []*mib.Object
+	for _, idx := range row.EffectiveIndexes() {
+		if idx.Object == nil {
+			continue
+		}
+		required = append(required, idx.Object.Name())
+		if idx.Object.Row() != row {
+			// Borrowed index, such as ifIndex in ifXEntry.
+			props = append(props, jsonField{idx.Object.Name(), propertySchema(idx.Object)})
+		} else {
+			ownIndex = append(ownIndex, idx.Object)
+		}
+	}
+	for _, col := range row.Columns() {
+		if slices.Contains(cols, col) || slices.Contains(ownIndex, col) {
+			props = append(props, jsonField{col.Name(), propertySchema(col)})
+		}
+	}
+
+	s := jsonObject{
+		{"title", qualifiedName(row)},
+		{"type", "object"},
+	}
+	if desc := normalizeDescription(row.Table().Description()); desc != "" {
+		s = append(s, jsonField{"description", desc})
+	}
+	s = append(s, jsonField{"x-oid", row.OID().String()})
+	s = append(s, jsonField{"properties", props})
+	if len(required) > 0 {
+		s = append(s, jsonField{"required", required})
+	}
+	return s
+}
+
+func scalarGroupSchema(parent *mib.Node, scalars []*mib.Object) jsonObject {
+	var props jsonObject
+	for _, obj := range scalars {
+		props = append(props, jsonField{obj.Name(), propertySchema(obj)})
+	}
+	title := parent.Name()
+	if mod := scalars[0].Module(); mod != nil {
+		title = mod.Name() + "::" + parent.Name()
+	}
+	return jsonObject{
+		{"title", title},
+		{"type", "object"},
+		{"x-oid", parent.OID().String()},
+		{"properties", props},
+	}
+}
+
+func qualifiedName(obj *mib.Object) string {
+	if obj.Module() == nil {
+		return obj.Name()
+	}
+	return obj.Module().Name() + "::" + obj.Name()
+}
+
+// propertySchema returns the schema of one object's value.
+func propertySchema(obj *mib.Object) jsonObject {
+	s := valueSchema(obj)
+	if desc := normalizeDescription(obj.Description()); desc != "" {
+		s = append(s, jsonField{"description", desc})
+	}
+	switch obj.Access() {
+	case mib.AccessReadOnly, mib.AccessAccessibleForNotify:
+		s = append(s, jsonField{"readOnly", true})
+	case mib.AccessWriteOnly:
+		s = append(s, jsonField{"writeOnly", true})
+	}
+	if st := obj.Status(); st == mib.StatusDeprecated || st == mib.StatusObsolete {
+		s = append(s, jsonField{"deprecated", true})
+	}
+	if u := obj.Units(); u != "" {
+		s = append(s, jsonField{"x-units", u})
+	}
+	return append(s, jsonField{"x-oid", obj.OID().String()})
+}
+
+const hexPattern = "^([0-9a-fA-F]{2})*$"
+
+func valueSchema(obj *mib.Object) jsonObject {
+	t := obj.Type()
+	if t == nil {
+		return jsonObject{}
+	}
+	if enums := obj.EffectiveEnums(); len(enums) > 0 {
+		labels := make([]string, len(enums))
+		values := make(jsonObject, len(enums))
+		for i, nv := range enums {
+			labels[i] = nv.Label
+			values[i] = jsonField{nv.Label, nv.Value}
+		}
+		return jsonObject{{"type", "string"}, {"enum", labels}, {"x-enum-values", values}}
+	}
+	if bits := obj.EffectiveBits(); len(bits) > 0 {
+		labels := make([]string, len(bits))
+		for i, nv := range bits {
+			labels[i] = nv.Label
+		}
+		return jsonObject{
+			{"type", "array"},
+			{"items", jsonObject{{"type", "string"}, {"enum", labels}}},
+			{"uniqueItems", true},
+		}
+	}
+
+	switch t.EffectiveBase() {
+	case mib.BaseInteger32:
+		return integerSchema(obj, math.MinInt32, math.MaxInt32, "int32")
+	case mib.BaseUnsigned32, mib.BaseGauge32, mib.BaseCounter32, mib.BaseTimeTicks:
+		return integerSchema(obj, 0, math.MaxUint32, "int64")
+	case mib.BaseCounter64:
+		// 2^64-1 is not exactly representable as a JSON number.
+		return jsonObject{{"type", "integer"}, {"minimum", 0}}
+	case mib.BaseIpAddress:
+		return jsonObject{{"type", "string"}, {"format", "ipv4"}}
+	case mib.BaseObjectIdentifier:
+		return jsonObject{{"type", "string"}, {"pattern", `^[0-9]+(\.[0-9]+)*$`}}
+	case mib.BaseOctetString:
+		return stringSchema(obj)
+	case mib.BaseOpaque:
+		return jsonObject{{"type", "string"}, {"pattern", hexPattern}}
+	}
+	return jsonObject{}
+}
+
+func integerSchema(obj *mib.Object, lo, hi int64, format string) jsonObject {
+	s := jsonObject{{"type", "integer"}, {"format", format}}
+	ranges := obj.EffectiveRanges()
+	switch len(ranges) {
+	case 0:
+		return append(s, jsonField{"minimum", lo}, jsonField{"maximum", hi})
+	case 1:
+		return append(s, jsonField{"minimum", ranges[0].Min}, jsonField{"maximum", ranges[0].Max})
+	}
+	alts := make([]any, len(ranges))
+	for i, r := range ranges {
+		alts[i] = jsonObject{{"minimum", r.Min}, {"maximum", r.Max}}
+	}
+	return append(s, jsonField{"anyOf", alts})
+}
+
+// stringSchema describes an OCTET STRING by its textual convention or
+// DISPLAY-HINT.
+func stringSchema(obj *mib.Object) jsonObject {
+	t := obj.Type()
+	hint := obj.EffectiveDisplayHint()
+	s := jsonObject{{"type", "string"}}
+	switch {
+	case hasTC(t, "SNMPv2-TC", "DateAndTime"):
+		return append(s, jsonField{"format", "date-time"})
+	case hasTC(t, "INET-ADDRESS-MIB", "InetAddressIPv4"):
+		return append(s, jsonField{"format", "ipv4"})
+	case hasTC(t, "INET-ADDRESS-MIB", "InetAddressIPv6"):
+		return append(s, jsonField{"format", "ipv6"})
+	case hasTC(t, "INET-ADDRESS-MIB", "InetAddress"):
+		// Textual address whose family is given by another object.
+		if d := obj.Discriminator(); d != nil {
+			s = append(s, jsonField{"x-address-type", d.Name()})
+		}
+		return s
+	case hasTC(t, "SNMPv2-TC", "MacAddress"):
+		return append(s, jsonField{"pattern", "^[0-9a-fA-F]{2}(:[0-9a-fA-F]{2}){5}$"})
+	case hasTC(t, "SNMPv2-TC", "PhysAddress"), hint == "1x:":
+		return append(s, jsonField{"pattern", "^([0-9a-fA-F]{2}(:[0-9a-fA-F]{2})*)?$"})
+	case isTextHint(hint):
+		return appendSizes(s, obj.EffectiveSizes(), 1)
+	case hint != "":
+		return append(s, jsonField{"x-display-hint", hint})
+	}
+	s = append(s, jsonField{"pattern", hexPattern})
+	return appendSizes(s, obj.EffectiveSizes(), 2)
+}
+
+// appendSizes adds minLength/maxLength spanning the SIZE ranges, scaled
+// by the characters per octet.
+func appendSizes(s jsonObject, sizes []mib.Range, scale int64) jsonObject {
+	if len(sizes) == 0 {
+		return s
+	}
+	lo, hi := sizes[0].Min, sizes[0].Max
+	for _, r := range sizes[1:] {
+		lo, hi = min(lo, r.Min), max(hi, r.Max)
+	}
+	if lo > 0 {
+		s = append(s, jsonField{"minLength", lo * scale})
+	}
+	return append(s, jsonField{"maxLength", hi * scale})
+}
+
+// normalizeDescription joins the lines of each paragraph of a MIB
+// DESCRIPTION, keeping blank lines between paragraphs.
+func normalizeDescription(desc string) string {
+	var paras []string
+	var words []string
+	for _, line := range strings.Split(desc, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 && len(words) > 0 {
+			paras = append(paras, strings.Join(words, " "))
+			words = nil
+		}
+		words = append(words, fields...)
+	}
+	if len(words) > 0 {
+		paras = append(paras, strings.Join(words, " "))
+	}
+	return strings.Join(paras, "\n\n")
+}
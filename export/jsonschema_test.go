@@ -0,0 +1,88 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+func writeSchema(t *testing.T, opts JSONSchemaOptions, specs ...string) map[string]any {
+	t.Helper()
+	m := loadTestMIB(t)
+	objs, err := Select(m, specs...)
+	testutil.NoError(t, err, "Select")
+
+	var b strings.Builder
+	err = WriteJSONSchema(&b, m, objs, opts)
+	testutil.NoError(t, err, "WriteJSONSchema")
+
+	var doc map[string]any
+	err = json.Unmarshal([]byte(b.String()), &doc)
+	testutil.NoError(t, err, "output is valid JSON")
+	return doc
+}
+
+// lookup walks a decoded JSON document by object keys.
+func lookup(t *testing.T, v any, path ...string) any {
+	t.Helper()
+	for _, key := range path {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			t.Fatalf("%s: not an object", key)
+		}
+		v, ok = obj[key]
+		if !ok {
+			t.Fatalf("missing key %q in path %v", key, path)
+		}
+	}
+	return v
+}
+
+func TestWriteJSONSchema(t *testing.T) {
+	doc := writeSchema(t, JSONSchemaOptions{}, "ifNumber", "ifTable", "ifXTable", "ipAddressTable", "sysDescr", "ipReasmTimeout")
+
+	testutil.Equal(t, "https://json-schema.org/draft/2020-12/schema", lookup(t, doc, "$schema").(string), "$schema")
+	defs := lookup(t, doc, "$defs")
+
+	ifEntry := lookup(t, defs, "IfEntry")
+	testutil.Equal(t, "IF-MIB::ifEntry", lookup(t, ifEntry, "title").(string), "title")
+	testutil.Equal(t, "object", lookup(t, ifEntry, "type").(string), "type")
+	testutil.SliceEqual(t, []any{"ifIndex"}, lookup(t, ifEntry, "required").([]any), "required index")
+
+	props := lookup(t, ifEntry, "properties")
+	testutil.Equal(t, float64(1), lookup(t, props, "ifIndex", "minimum").(float64), "InterfaceIndex range minimum")
+	testutil.Equal(t, float64(255), lookup(t, props, "ifDescr", "maxLength").(float64), "DisplayString size")
+	testutil.True(t, lookup(t, props, "ifDescr", "readOnly").(bool), "read-only column")
+	testutil.SliceEqual(t, []any{"up", "down", "testing"}, lookup(t, props, "ifAdminStatus", "enum").([]any), "enum labels")
+	testutil.Equal(t, float64(3), lookup(t, props, "ifAdminStatus", "x-enum-values", "testing").(float64), "enum values")
+	testutil.Contains(t, lookup(t, props, "ifPhysAddress", "pattern").(string), "(:[0-9a-fA-F]{2})", "PhysAddress pattern")
+	testutil.True(t, lookup(t, props, "ifInNUcastPkts", "deprecated").(bool), "deprecated column")
+	testutil.Equal(t, "1.3.6.1.2.1.2.2.1.10", lookup(t, props, "ifInOctets", "x-oid").(string), "x-oid")
+
+	ifX := lookup(t, defs, "IfXEntry", "properties")
+	testutil.Equal(t, "1.3.6.1.2.1.2.2.1.1", lookup(t, ifX, "ifIndex", "x-oid").(string), "borrowed index property")
+
+	ipAddr := lookup(t, defs, "IpAddressEntry", "properties")
+	testutil.Equal(t, "ipAddressAddrType", lookup(t, ipAddr, "ipAddressAddr", "x-address-type").(string), "InetAddress discriminator")
+	testutil.Equal(t, "integer", lookup(t, ipAddr, "ipAddressCreated", "type").(string), "TimeStamp is an integer")
+	testutil.Equal(t, "seconds", lookup(t, defs, "Ip", "properties", "ipReasmTimeout", "x-units").(string), "units")
+
+	testutil.Equal(t, "SNMPv2-MIB::system", lookup(t, defs, "System", "title").(string), "scalar group")
+	testutil.NotNil(t, lookup(t, defs, "Interfaces", "properties", "ifNumber"), "ifNumber in its group")
+}
+
+func TestWriteJSONSchemaOpenAPI(t *testing.T) {
+	doc := writeSchema(t, JSONSchemaOptions{OpenAPI: true, Title: "Interfaces", Version: "1.0"}, "ifTable")
+
+	testutil.Equal(t, "3.1.0", lookup(t, doc, "openapi").(string), "openapi version")
+	testutil.Equal(t, "Interfaces", lookup(t, doc, "info", "title").(string), "title")
+	testutil.Equal(t, "1.0", lookup(t, doc, "info", "version").(string), "version")
+	testutil.NotNil(t, lookup(t, doc, "components", "schemas", "IfEntry"), "schema under components")
+}
+
+func TestNormalizeDescription(t *testing.T) {
+	got := normalizeDescription("  First line\n   continues.\n  \n   Second paragraph.\n")
+	testutil.Equal(t, "First line continues.\n\nSecond paragraph.", got, "normalizeDescription")
+}
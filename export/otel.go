@@ -0,0 +1,241 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// OTelOptions configures OpenTelemetry Collector snmpreceiver output.
+// Empty connection fields take the receiver's usual defaults.
+type OTelOptions struct {
+	Receiver           string // receiver ID, default "snmp"
+	Endpoint           string // default "udp://localhost:161"
+	Version            string // default "v2c"
+	Community          string // default "public"
+	CollectionInterval string // default "60s"
+
+	// ResourceIndexes maps table indexes, and the Name or Descr column
+	// labelling them, to resource attributes, so each row becomes its
+	// own resource (one per interface, say). By default they are
+	// metric attributes.
+	ResourceIndexes bool
+}
+
+// WriteOTelReceiver writes an OpenTelemetry Collector configuration
+// with one snmpreceiver for the selected objects.
+//
+// Scalars become scalar_oids metrics. Table columns become column_oids
+// metrics carrying one attribute per entry of the row's
+// EffectiveIndexes: the index column's value when it is readable, the
+// raw index suffix otherwise. A textual Name or Descr column labelling
+// a single-object index, such as ifDescr or entPhysicalName, is added
+// as a further attribute. Counters become monotonic cumulative sums,
+// and units come from the UNITS clause, converted to UCUM where the
+// unit is well known. When both a counter and its high-capacity twin
+// are selected, only the 64-bit counter is emitted.
+func WriteOTelReceiver(w io.Writer, m *mib.Mib, objs []*mib.Object, opts OTelOptions) error {
+	b := &otelBuilder{opts: opts, attrs: make(map[string]yamlMap), resAttrs: make(map[string]yamlMap)}
+	selected := make(map[*mib.Object]bool)
+	var metricObjs []*mib.Object
+	for _, obj := range objs {
+		if obj.IsScalar() {
+			metricObjs = append(metricObjs, obj)
+			continue
+		}
+		_, cols := tableColumns(obj)
+		metricObjs = append(metricObjs, cols...)
+	}
+	for _, obj := range metricObjs {
+		selected[obj] = true
+	}
+
+	var metrics yamlMap
+	for _, obj := range metricObjs {
+		mt := obj.Metric()
+		if mt.Kind == mib.MetricCounter && mt.Bits == 32 && mt.Twin != nil && selected[mt.Twin] {
+			continue
+		}
+		if metric, ok := b.metric(obj, mt); ok {
+			metrics = append(metrics, yamlPair{obj.Name(), metric})
+		}
+	}
+	if len(metrics) == 0 {
+		return fmt.Errorf("otel: no metrics in selection")
+	}
+
+	receiver := yamlMap{
+		{"collection_interval", withDefault(opts.CollectionInterval, "60s")},
+		{"endpoint", withDefault(opts.Endpoint, "udp://localhost:161")},
+		{"version", withDefault(opts.Version, "v2c")},
+		{"community", withDefault(opts.Community, "public")},
+	}
+	if len(b.resOrder) > 0 {
+		receiver = append(receiver, yamlPair{"resource_attributes", b.collect(b.resOrder, b.resAttrs)})
+	}
+	if len(b.attrOrder) > 0 {
+		receiver = append(receiver, yamlPair{"attributes", b.collect(b.attrOrder, b.attrs)})
+	}
+	receiver = append(receiver, yamlPair{"metrics", metrics})
+	return writeYAML(w, yamlMap{{"receivers", yamlMap{{withDefault(opts.Receiver, "snmp"), receiver}}}})
+}
+
+type otelBuilder struct {
+	opts      OTelOptions
+	attrs     map[string]yamlMap
+	attrOrder []string
+	resAttrs  map[string]yamlMap
+	resOrder  []string
+}
+
+func (b *otelBuilder) metric(obj *mib.Object, mt mib.Metric) (yamlMap, bool) {
+	if obj.Type() == nil {
+		return nil, false
+	}
+	var value yamlMap
+	switch mt.Kind {
+	case mib.MetricCounter:
+		value = yamlMap{{"sum", yamlMap{
+			{"aggregation", "cumulative"},
+			{"monotonic", true},
+			{"value_type", "int"},
+		}}}
+	case mib.MetricGauge, mib.MetricTimeTicks, mib.MetricEnum:
+		value = yamlMap{{"gauge", yamlMap{{"value_type", "int"}}}}
+	default:
+		return nil, false
+	}
+
+	metric := yamlMap{{"unit", otelUnit(obj, mt)}}
+	if desc := firstSentence(obj.Description()); desc != "" {
+		metric = append(metric, yamlPair{"description", desc})
+	}
+	metric = append(metric, value...)
+
+	if obj.IsScalar() {
+		return append(metric, yamlPair{"scalar_oids", []any{
+			yamlMap{{"oid", obj.OID().Child(0).String()}},
+		}}), true
+	}
+
+	names, ok := b.indexAttributes(obj.Row())
+	if !ok {
+		return nil, false
+	}
+	column := yamlMap{{"oid", obj.OID().String()}}
+	var refs []any
+	for _, name := range names {
+		refs = append(refs, yamlMap{{"name", name}})
+	}
+	if b.opts.ResourceIndexes {
+		column = append(column, yamlPair{"resource_attributes", names})
+	} else {
+		column = append(column, yamlPair{"attributes", refs})
+	}
+	return append(metric, yamlPair{"column_oids", []any{column}}), true
+}
+
+// indexAttributes registers the attributes identifying a row's
+// instances and returns their names.
+func (b *otelBuilder) indexAttributes(row *mib.Object) ([]string, bool) {
+	indexes := row.EffectiveIndexes()
+	if len(indexes) == 0 {
+		return nil, false
+	}
+	var names []string
+	for _, idx := range indexes {
+		if idx.Object == nil {
+			return nil, false
+		}
+		name := idx.Object.Name()
+		if idx.Object.Access() == mib.AccessNotAccessible || idx.Object.Access() == mib.AccessAccessibleForNotify {
+			b.addAttribute(name, yamlMap{{"indexed_value_prefix", name + "."}})
+		} else {
+			b.addAttribute(name, yamlMap{{"oid", idx.Object.OID().String()}})
+		}
+		names = append(names, name)
+	}
+	if len(indexes) == 1 {
+		if col := labelColumn(indexes[0].Object); col != nil {
+			b.addAttribute(col.Name(), yamlMap{{"oid", col.OID().String()}})
+			names = append(names, col.Name())
+		}
+	}
+	return names, true
+}
+
+func (b *otelBuilder) addAttribute(name string, cfg yamlMap) {
+	attrs, order := b.attrs, &b.attrOrder
+	if b.opts.ResourceIndexes {
+		attrs, order = b.resAttrs, &b.resOrder
+	}
+	if _, ok := attrs[name]; ok {
+		return
+	}
+	attrs[name] = cfg
+	*order = append(*order, name)
+}
+
+func (b *otelBuilder) collect(order []string, attrs map[string]yamlMap) yamlMap {
+	out := make(yamlMap, len(order))
+	for i, name := range order {
+		out[i] = yamlPair{name, attrs[name]}
+	}
+	return out
+}
+
+// ucumUnits maps common UNITS clause values to UCUM unit strings.
+var ucumUnits = map[string]string{
+	"seconds":                "s",
+	"second":                 "s",
+	"milliseconds":           "ms",
+	"microseconds":           "us",
+	"centiseconds":           "cs",
+	"hundredths of a second": "cs",
+	"octets":                 "By",
+	"bytes":                  "By",
+	"kilobytes":              "kBy",
+	"kbytes":                 "kBy",
+	"bits":                   "bit",
+	"bits per second":        "bit/s",
+	"bps":                    "bit/s",
+	"kbps":                   "kbit/s",
+	"percent":                "%",
+	"celsius":                "Cel",
+	"degrees celsius":        "Cel",
+	"watts":                  "W",
+	"milliwatts":             "mW",
+	"volts":                  "V",
+	"millivolts":             "mV",
+	"amperes":                "A",
+	"milliamperes":           "mA",
+	"rpm":                    "{rpm}",
+}
+
+// otelUnit returns the UCUM unit for a metric. Unknown UNITS become a
+// curly-brace annotation; TimeTicks default to centiseconds and
+// counters named *Octets to bytes.
+func otelUnit(obj *mib.Object, mt mib.Metric) string {
+	if u := strings.TrimSpace(mt.Units); u != "" {
+		if ucum, ok := ucumUnits[strings.ToLower(u)]; ok {
+			return ucum
+		}
+		return "{" + strings.Join(strings.Fields(u), "_") + "}"
+	}
+	switch {
+	case mt.Kind == mib.MetricTimeTicks:
+		return "cs"
+	case mt.Kind == mib.MetricCounter && strings.HasSuffix(obj.Name(), "Octets"):
+		return "By"
+	}
+	return "1"
+}
+
+func withDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
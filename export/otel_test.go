@@ -0,0 +1,110 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func TestWriteOTelReceiver(t *testing.T) {
+	m := loadTestMIB(t)
+	objs, err := Select(m, "sysUpTime", "ifTable", "ifXTable", "entPhysicalTable")
+	testutil.NoError(t, err, "Select")
+
+	var b strings.Builder
+	err = WriteOTelReceiver(&b, m, objs, OTelOptions{Endpoint: "udp://router:161"})
+	testutil.NoError(t, err, "WriteOTelReceiver")
+	out := b.String()
+
+	testutil.True(t, strings.HasPrefix(out, `receivers:
+  snmp:
+    collection_interval: 60s
+    endpoint: udp://router:161
+    version: v2c
+    community: public
+    attributes:
+      ifIndex:
+        oid: 1.3.6.1.2.1.2.2.1.1
+      ifDescr:
+        oid: 1.3.6.1.2.1.2.2.1.2
+      entPhysicalIndex:
+        indexed_value_prefix: entPhysicalIndex.
+      entPhysicalName:
+        oid: 1.3.6.1.2.1.47.1.1.1.1.7
+    metrics:
+`), "receiver header:\n%s", out)
+
+	testutil.Contains(t, out, `      sysUpTime:
+        unit: cs
+`, "TimeTicks unit")
+	testutil.Contains(t, out, `        scalar_oids:
+        - oid: 1.3.6.1.2.1.1.3.0
+`, "scalar OID")
+	testutil.Contains(t, out, `      ifHCInOctets:
+        unit: By
+        description: The total number of octets received on the interface, including framing characters.
+        sum:
+          aggregation: cumulative
+          monotonic: true
+          value_type: int
+        column_oids:
+        - oid: 1.3.6.1.2.1.31.1.1.1.6
+          attributes:
+          - name: ifIndex
+          - name: ifDescr
+`, "counter column")
+	testutil.False(t, strings.Contains(out, "ifInOctets:"), "32-bit twin of a selected HC counter is dropped")
+	testutil.False(t, strings.Contains(out, "ifIndex:\n        unit"), "index column is not a metric")
+	testutil.False(t, strings.Contains(out, "ifDescr:\n        unit"), "string column is not a metric")
+}
+
+func TestWriteOTelReceiverResources(t *testing.T) {
+	m := loadTestMIB(t)
+	objs, err := Select(m, "ifTable")
+	testutil.NoError(t, err, "Select")
+
+	var b strings.Builder
+	err = WriteOTelReceiver(&b, m, objs, OTelOptions{ResourceIndexes: true})
+	testutil.NoError(t, err, "WriteOTelReceiver")
+	out := b.String()
+
+	testutil.Contains(t, out, "    resource_attributes:\n      ifIndex:\n", "resource attributes")
+	testutil.Contains(t, out, `        - oid: 1.3.6.1.2.1.2.2.1.10
+          resource_attributes:
+          - ifIndex
+          - ifDescr
+`, "column resource attributes")
+	testutil.Contains(t, out, "      ifInOctets:\n", "32-bit counter without twin selected")
+	testutil.False(t, strings.Contains(out, "    attributes:"), "no metric attributes")
+}
+
+func TestWriteOTelReceiverEmpty(t *testing.T) {
+	m := loadTestMIB(t)
+	objs, err := Select(m, "sysDescr")
+	testutil.NoError(t, err, "Select")
+
+	var b strings.Builder
+	err = WriteOTelReceiver(&b, m, objs, OTelOptions{})
+	testutil.Error(t, err, "string scalar yields no metrics")
+}
+
+func TestOTelUnit(t *testing.T) {
+	tests := []struct {
+		units string
+		kind  mib.MetricKind
+		want  string
+	}{
+		{"seconds", mib.MetricGauge, "s"},
+		{"Octets", mib.MetricCounter, "By"},
+		{"bits per second", mib.MetricGauge, "bit/s"},
+		{"packets per second", mib.MetricGauge, "{packets_per_second}"},
+		{"", mib.MetricTimeTicks, "cs"},
+		{"", mib.MetricGauge, "1"},
+	}
+	for _, tt := range tests {
+		got := otelUnit(nil, mib.Metric{Kind: tt.kind, Units: tt.units})
+		testutil.Equal(t, tt.want, got, "otelUnit(%q)", tt.units)
+	}
+}
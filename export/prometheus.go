@@ -0,0 +1,462 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// snmp_exporter value types for enumerated objects.
+const (
+	EnumAsStateSet = "EnumAsStateSet"
+	EnumAsInfo     = "EnumAsInfo"
+	EnumAsGauge    = "gauge"
+)
+
+// MaxStateSetValues is the largest enumeration exported as a state set
+// when [PrometheusOptions].EnumType is unset.
+const MaxStateSetValues = 16
+
+// PrometheusOptions configures snmp_exporter configuration output.
+type PrometheusOptions struct {
+	// Module is the snmp_exporter module name. It defaults to the name
+	// of the first selected object's module, lowercased with hyphens
+	// replaced by underscores ("if_mib").
+	Module string
+
+	// Lookups replace or augment index labels with column values.
+	Lookups []PrometheusLookup
+
+	// AutoLookups adds a lookup for every single-object index whose own
+	// table has a textual Name or Descr column, such as ifDescr for
+	// ifIndex. Explicit Lookups for the same index take precedence.
+	AutoLookups bool
+
+	// EnumType is the value type used for enumerated objects:
+	// EnumAsStateSet (the default), EnumAsInfo or EnumAsGauge. With the
+	// default, enums of more than MaxStateSetValues labels, such as
+	// ifType, use EnumAsInfo to avoid one series per label.
+	EnumType string
+}
+
+// PrometheusLookup is a snmp_exporter index lookup: for metrics indexed
+// by SourceIndexes, add a label holding the value of the Lookup column.
+type PrometheusLookup struct {
+	SourceIndexes     []string
+	Lookup            string
+	DropSourceIndexes bool
+}
+
+// WritePrometheusGenerator writes a snmp_exporter generator.yml
+// containing one module for the selected objects, with walk list,
+// lookups and type overrides for enums and display-hinted strings.
+// A 32-bit counter whose high-capacity twin is also selected, such as
+// ifInOctets beside ifHCInOctets, is overridden with ignore: true.
+func WritePrometheusGenerator(w io.Writer, m *mib.Mib, objs []*mib.Object, opts PrometheusOptions) error {
+	p, err := newPromBuilder(m, objs, opts)
+	if err != nil {
+		return err
+	}
+
+	walk := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		walk = append(walk, obj.Name())
+	}
+	module := yamlMap{{"walk", walk}}
+
+	if len(p.lookups) > 0 {
+		var lookups []any
+		for _, l := range p.lookups {
+			entry := yamlMap{
+				{"source_indexes", l.SourceIndexes},
+				{"lookup", l.Lookup},
+			}
+			if l.DropSourceIndexes {
+				entry = append(entry, yamlPair{"drop_source_indexes", true})
+			}
+			lookups = append(lookups, entry)
+		}
+		module = append(module, yamlPair{"lookups", lookups})
+	}
+
+	var overrides yamlMap
+	for _, obj := range p.selectedMetrics() {
+		if p.shadowed[obj] {
+			overrides = append(overrides, yamlPair{obj.Name(), yamlMap{{"ignore", true}}})
+			continue
+		}
+		if typ, ok := p.valueType(obj); ok && needsTypeOverride(obj, typ) {
+			overrides = append(overrides, yamlPair{obj.Name(), yamlMap{{"type", typ}}})
+		}
+	}
+	if len(overrides) > 0 {
+		module = append(module, yamlPair{"overrides", overrides})
+	}
+
+	return writeYAML(w, yamlMap{{"modules", yamlMap{{p.module, module}}}})
+}
+
+// WritePrometheusSnmp writes a fully expanded snmp_exporter snmp.yml
+// module for the selected objects: numeric walk and get lists and one
+// metric per readable column or scalar, with indexes, lookups and enum
+// values resolved from the MIB. Objects whose syntax snmp_exporter
+// cannot represent, columns of tables with such an index, and 32-bit
+// counters whose high-capacity twin is also selected are left out.
+func WritePrometheusSnmp(w io.Writer, m *mib.Mib, objs []*mib.Object, opts PrometheusOptions) error {
+	p, err := newPromBuilder(m, objs, opts)
+	if err != nil {
+		return err
+	}
+
+	var walk, get []string
+	var walked []mib.OID
+	for _, obj := range objs {
+		if obj.IsScalar() {
+			get = append(get, obj.OID().Child(0).String())
+			continue
+		}
+		walk = append(walk, obj.OID().String())
+		walked = append(walked, obj.OID())
+	}
+	for _, l := range p.lookups {
+		col := p.mib.Object(l.Lookup)
+		if col == nil || slices.ContainsFunc(walked, col.OID().HasPrefix) {
+			continue
+		}
+		walk = append(walk, col.OID().String())
+		walked = append(walked, col.OID())
+	}
+
+	var metrics []any
+	for _, obj := range p.metricObjects() {
+		if metric, ok := p.snmpMetric(obj); ok {
+			metrics = append(metrics, metric)
+		}
+	}
+
+	module := yamlMap{}
+	if len(walk) > 0 {
+		module = append(module, yamlPair{"walk", walk})
+	}
+	if len(get) > 0 {
+		module = append(module, yamlPair{"get", get})
+	}
+	module = append(module, yamlPair{"metrics", metrics})
+	return writeYAML(w, yamlMap{{"modules", yamlMap{{p.module, module}}}})
+}
+
+type promBuilder struct {
+	mib      *mib.Mib
+	objs     []*mib.Object
+	opts     PrometheusOptions
+	module   string
+	lookups  []PrometheusLookup
+	shadowed map[*mib.Object]bool // 32-bit counters whose twin is selected
+}
+
+func newPromBuilder(m *mib.Mib, objs []*mib.Object, opts PrometheusOptions) (*promBuilder, error) {
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("prometheus: no objects selected")
+	}
+	switch opts.EnumType {
+	case "", EnumAsStateSet, EnumAsInfo, EnumAsGauge:
+	default:
+		return nil, fmt.Errorf("prometheus: unknown enum type %q", opts.EnumType)
+	}
+	p := &promBuilder{mib: m, objs: objs, opts: opts, module: opts.Module}
+	if p.module == "" && objs[0].Module() != nil {
+		p.module = strings.ReplaceAll(strings.ToLower(objs[0].Module().Name()), "-", "_")
+	}
+	if p.module == "" {
+		p.module = "default"
+	}
+
+	for _, l := range opts.Lookups {
+		if m.Object(l.Lookup) == nil {
+			return nil, fmt.Errorf("prometheus: lookup column %q not found", l.Lookup)
+		}
+		p.lookups = append(p.lookups, l)
+	}
+	if opts.AutoLookups {
+		p.addAutoLookups()
+	}
+
+	// A 32-bit counter and its high-capacity twin count the same events,
+	// so exporting both would double count.
+	selected := p.selectedMetrics()
+	p.shadowed = make(map[*mib.Object]bool)
+	for _, obj := range selected {
+		if metric := obj.Metric(); metric.Kind == mib.MetricCounter && metric.Bits == 32 &&
+			slices.Contains(selected, metric.Twin) {
+			p.shadowed[obj] = true
+		}
+	}
+	return p, nil
+}
+
+// metricObjects returns the selected scalars and columns to export, in
+// OID order.
+func (p *promBuilder) metricObjects() []*mib.Object {
+	return slices.DeleteFunc(p.selectedMetrics(), func(obj *mib.Object) bool { return p.shadowed[obj] })
+}
+
+// selectedMetrics returns the selected scalars and columns that have a
+// metric kind, in OID order.
+func (p *promBuilder) selectedMetrics() []*mib.Object {
+	var out []*mib.Object
+	for _, obj := range p.objs {
+		if obj.IsScalar() {
+			out = append(out, obj)
+			continue
+		}
+		_, cols := tableColumns(obj)
+		for _, col := range cols {
+			if col.Metric().Kind != mib.MetricNone {
+				out = append(out, col)
+			}
+		}
+	}
+	return out
+}
+
+func (p *promBuilder) addAutoLookups() {
+	covered := make(map[string]bool)
+	for _, l := range p.lookups {
+		if len(l.SourceIndexes) == 1 {
+			covered[l.SourceIndexes[0]] = true
+		}
+	}
+	for _, obj := range p.objs {
+		row, _ := tableColumns(obj)
+		for _, idx := range row.EffectiveIndexes() {
+			name := idx.Object.Name()
+			if covered[name] {
+				continue
+			}
+			covered[name] = true
+			if col := labelColumn(idx.Object); col != nil {
+				p.lookups = append(p.lookups, PrometheusLookup{
+					SourceIndexes: []string{name},
+					Lookup:        col.Name(),
+				})
+			}
+		}
+	}
+}
+
+// labelColumn returns a textual Name or Descr column of the table that
+// idx indexes on its own, e.g. ifDescr for ifIndex.
+func labelColumn(idx *mib.Object) *mib.Object {
+	row := idx.Row()
+	if row == nil {
+		return nil
+	}
+	indexes := row.EffectiveIndexes()
+	if len(indexes) != 1 || indexes[0].Object != idx {
+		return nil
+	}
+	for _, suffix := range []string{"Name", "Descr"} {
+		for _, col := range row.Columns() {
+			if strings.HasSuffix(col.Name(), suffix) && col.Access() != mib.AccessNotAccessible &&
+				stringType(col) == "DisplayString" {
+				return col
+			}
+		}
+	}
+	return nil
+}
+
+// valueType returns the snmp_exporter type for a metric's value.
+func (p *promBuilder) valueType(obj *mib.Object) (string, bool) {
+	if obj.Type() == nil {
+		return "", false
+	}
+	switch obj.Metric().Kind {
+	case mib.MetricCounter:
+		return "counter", true
+	case mib.MetricGauge, mib.MetricTimeTicks:
+		return "gauge", true
+	case mib.MetricEnum:
+		if p.opts.EnumType == "" {
+			if len(obj.EffectiveEnums()) > MaxStateSetValues {
+				return EnumAsInfo, true
+			}
+			return EnumAsStateSet, true
+		}
+		return p.opts.EnumType, true
+	case mib.MetricInfo:
+		if len(obj.EffectiveBits()) > 0 {
+			return "Bits", true
+		}
+		switch obj.Type().EffectiveBase() {
+		case mib.BaseIpAddress:
+			return "IpAddr", true
+		case mib.BaseOctetString:
+			return stringType(obj), true
+		}
+	}
+	return "", false
+}
+
+// stringType maps an OCTET STRING object to a snmp_exporter string type
+// using its textual convention or DISPLAY-HINT.
+func stringType(obj *mib.Object) string {
+	t := obj.Type()
+	switch {
+	case hasTC(t, "SNMPv2-TC", "DateAndTime"):
+		return "DateAndTime"
+	case hasTC(t, "INET-ADDRESS-MIB", "InetAddressIPv4"):
+		return "InetAddressIPv4"
+	case hasTC(t, "INET-ADDRESS-MIB", "InetAddressIPv6"):
+		return "InetAddressIPv6"
+	case hasTC(t, "INET-ADDRESS-MIB", "InetAddress"):
+		return "InetAddress"
+	case hasTC(t, "SNMPv2-TC", "PhysAddress"), hasTC(t, "SNMPv2-TC", "MacAddress"),
+		obj.EffectiveDisplayHint() == "1x:":
+		return "PhysAddress48"
+	case isTextHint(obj.EffectiveDisplayHint()):
+		return "DisplayString"
+	}
+	return "OctetString"
+}
+
+// needsTypeOverride reports whether generator.yml must name typ
+// explicitly: enums and display-hinted strings the generator would
+// otherwise treat as gauges or raw octets.
+func needsTypeOverride(obj *mib.Object, typ string) bool {
+	switch typ {
+	case EnumAsStateSet, EnumAsInfo:
+		return true
+	case "DisplayString", "PhysAddress48":
+		return obj.Type() != nil && !hasTC(obj.Type(), "SNMPv2-TC", "DisplayString") &&
+			!hasTC(obj.Type(), "SNMPv2-TC", "PhysAddress")
+	}
+	return false
+}
+
+func (p *promBuilder) snmpMetric(obj *mib.Object) (yamlMap, bool) {
+	typ, ok := p.valueType(obj)
+	if !ok {
+		return nil, false
+	}
+	metric := yamlMap{
+		{"name", metricName(obj.Name())},
+		{"oid", obj.OID().String()},
+		{"type", typ},
+		{"help", strings.TrimSpace(firstSentence(obj.Description()) + " - " + obj.OID().String())},
+	}
+
+	var labels []string
+	if row := obj.Row(); row != nil {
+		var indexes []any
+		for _, idx := range row.EffectiveIndexes() {
+			entry, ok := indexLabel(idx)
+			if !ok {
+				return nil, false
+			}
+			indexes = append(indexes, entry)
+			labels = append(labels, idx.Object.Name())
+		}
+		if len(indexes) > 0 {
+			metric = append(metric, yamlPair{"indexes", indexes})
+		}
+	}
+
+	var lookups []any
+	for _, l := range p.lookups {
+		if len(labels) == 0 || !containsAll(labels, l.SourceIndexes) {
+			continue
+		}
+		col := p.mib.Object(l.Lookup)
+		colType, ok := p.valueType(col)
+		if !ok {
+			continue
+		}
+		lookups = append(lookups, yamlMap{
+			{"labels", l.SourceIndexes},
+			{"labelname", metricName(col.Name())},
+			{"oid", col.OID().String()},
+			{"type", colType},
+		})
+		if l.DropSourceIndexes {
+			for _, src := range l.SourceIndexes {
+				lookups = append(lookups, yamlMap{
+					{"labels", []string{}},
+					{"labelname", metricName(src)},
+				})
+			}
+		}
+	}
+	if len(lookups) > 0 {
+		metric = append(metric, yamlPair{"lookups", lookups})
+	}
+
+	values := obj.EffectiveEnums()
+	if typ == "Bits" {
+		values = obj.EffectiveBits()
+	}
+	if typ == EnumAsStateSet || typ == EnumAsInfo || typ == "Bits" {
+		enums := make(yamlMap, len(values))
+		for i, nv := range values {
+			enums[i] = yamlPair{strconv.FormatInt(nv.Value, 10), nv.Label}
+		}
+		metric = append(metric, yamlPair{"enum_values", enums})
+	}
+	return metric, true
+}
+
+// indexLabel returns the snmp_exporter index entry for an INDEX object.
+func indexLabel(idx mib.IndexEntry) (yamlMap, bool) {
+	obj := idx.Object
+	if obj == nil || obj.Type() == nil {
+		return nil, false
+	}
+	entry := yamlMap{{"labelname", metricName(obj.Name())}}
+	switch obj.Type().EffectiveBase() {
+	case mib.BaseInteger32, mib.BaseUnsigned32, mib.BaseGauge32, mib.BaseCounter32, mib.BaseTimeTicks:
+		entry = append(entry, yamlPair{"type", "gauge"})
+	case mib.BaseIpAddress:
+		entry = append(entry, yamlPair{"type", "IpAddr"})
+	case mib.BaseOctetString:
+		typ := stringType(obj)
+		if typ == "DateAndTime" {
+			typ = "OctetString"
+		}
+		entry = append(entry, yamlPair{"type", typ})
+		if size, ok := fixedSize(obj); ok && typ == "OctetString" {
+			entry = append(entry, yamlPair{"fixed_size", size})
+		}
+	default:
+		return nil, false
+	}
+	if idx.Implied {
+		entry = append(entry, yamlPair{"implied", true})
+	}
+	return entry, true
+}
+
+func fixedSize(obj *mib.Object) (int, bool) {
+	sizes := obj.EffectiveSizes()
+	if len(sizes) == 1 && sizes[0].Min == sizes[0].Max {
+		return int(sizes[0].Min), true
+	}
+	return 0, false
+}
+
+// metricName makes an SMI descriptor a valid Prometheus metric name.
+func metricName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func containsAll(have, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
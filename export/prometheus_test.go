@@ -0,0 +1,100 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+func TestWritePrometheusGenerator(t *testing.T) {
+	m := loadTestMIB(t)
+	objs, err := Select(m, "ifTable", "ifXTable")
+	testutil.NoError(t, err, "Select")
+
+	var b strings.Builder
+	err = WritePrometheusGenerator(&b, m, objs, PrometheusOptions{
+		Module:  "if_mib",
+		Lookups: []PrometheusLookup{{SourceIndexes: []string{"ifIndex"}, Lookup: "ifAlias"}},
+	})
+	testutil.NoError(t, err, "WritePrometheusGenerator")
+	out := b.String()
+
+	testutil.True(t, strings.HasPrefix(out, "modules:\n  if_mib:\n    walk:\n    - ifTable\n    - ifXTable\n"), "walk list:\n%s", out)
+	testutil.Contains(t, out, "    - source_indexes:\n      - ifIndex\n      lookup: ifAlias\n", "lookup")
+	testutil.Contains(t, out, "      ifAdminStatus:\n        type: EnumAsStateSet\n", "small enum override")
+	testutil.Contains(t, out, "      ifType:\n        type: EnumAsInfo\n", "large enum override")
+}
+
+func TestWritePrometheusSnmp(t *testing.T) {
+	m := loadTestMIB(t)
+	objs, err := Select(m, "ifTable", "sysUpTime", "ipAddressTable")
+	testutil.NoError(t, err, "Select")
+
+	var b strings.Builder
+	err = WritePrometheusSnmp(&b, m, objs, PrometheusOptions{Module: "test", AutoLookups: true})
+	testutil.NoError(t, err, "WritePrometheusSnmp")
+	out := b.String()
+
+	testutil.Contains(t, out, "    walk:\n    - 1.3.6.1.2.1.2.2\n    - 1.3.6.1.2.1.4.34\n", "walk")
+	testutil.Contains(t, out, "    get:\n    - 1.3.6.1.2.1.1.3.0\n", "scalar get")
+	testutil.Contains(t, out, `    - name: ifInOctets
+      oid: 1.3.6.1.2.1.2.2.1.10
+      type: counter
+      help: The total number of octets received on the interface, including framing characters. - 1.3.6.1.2.1.2.2.1.10
+      indexes:
+      - labelname: ifIndex
+        type: gauge
+      lookups:
+      - labels:
+        - ifIndex
+        labelname: ifDescr
+        oid: 1.3.6.1.2.1.2.2.1.2
+        type: DisplayString
+`, "counter metric with auto lookup")
+	testutil.Contains(t, out, "      type: PhysAddress48\n", "display-hinted string")
+	testutil.Contains(t, out, "      enum_values:\n        1: up\n        2: down\n        3: testing\n", "enum values")
+	testutil.Contains(t, out, "      - labelname: ipAddressAddr\n        type: InetAddress\n", "InetAddress index")
+	testutil.False(t, strings.Contains(out, "name: ifSpecific"), "OBJECT IDENTIFIER values are skipped")
+}
+
+func TestPrometheusCounterTwins(t *testing.T) {
+	m := loadTestMIB(t)
+	objs, err := Select(m, "ifTable", "ifXTable")
+	testutil.NoError(t, err, "Select")
+
+	var b strings.Builder
+	testutil.NoError(t, WritePrometheusSnmp(&b, m, objs, PrometheusOptions{}), "WritePrometheusSnmp")
+	out := b.String()
+	testutil.Contains(t, out, "name: ifHCInOctets\n", "64-bit counter")
+	testutil.False(t, strings.Contains(out, "name: ifInOctets\n"), "32-bit twin is dropped")
+	testutil.Contains(t, out, "name: ifInErrors\n", "counter without a twin")
+
+	b.Reset()
+	testutil.NoError(t, WritePrometheusGenerator(&b, m, objs, PrometheusOptions{}), "WritePrometheusGenerator")
+	testutil.Contains(t, b.String(), "      ifInOctets:\n        ignore: true\n", "32-bit twin is ignored")
+
+	// Without the 64-bit table, the 32-bit counters are kept.
+	objs, err = Select(m, "ifTable")
+	testutil.NoError(t, err, "Select")
+	b.Reset()
+	testutil.NoError(t, WritePrometheusSnmp(&b, m, objs, PrometheusOptions{}), "WritePrometheusSnmp")
+	testutil.Contains(t, b.String(), "name: ifInOctets\n", "32-bit counter without its twin")
+}
+
+func TestPrometheusOptionsErrors(t *testing.T) {
+	m := loadTestMIB(t)
+	objs, _ := Select(m, "ifTable")
+	var b strings.Builder
+
+	err := WritePrometheusSnmp(&b, m, objs, PrometheusOptions{EnumType: "bogus"})
+	testutil.Error(t, err, "unknown enum type")
+
+	err = WritePrometheusSnmp(&b, m, objs, PrometheusOptions{
+		Lookups: []PrometheusLookup{{SourceIndexes: []string{"ifIndex"}, Lookup: "noSuchColumn"}},
+	})
+	testutil.Error(t, err, "unknown lookup column")
+
+	err = WritePrometheusGenerator(&b, m, nil, PrometheusOptions{})
+	testutil.Error(t, err, "empty selection")
+}
@@ -0,0 +1,309 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// ProtoOptions configures Protocol Buffers schema generation.
+type ProtoOptions struct {
+	// Package is the proto package. It defaults to the first module's
+	// name, lowercased with hyphens replaced by underscores ("if_mib").
+	Package string
+
+	// GoPackage, when set, is emitted as option go_package.
+	GoPackage string
+}
+
+// protoMaxField is the largest valid proto field number.
+const protoMaxField = 1<<29 - 1
+
+// protoExternalIndexBase numbers fields for INDEX objects that are
+// columns of another table (ifIndex in ifXEntry), above any column arc.
+const protoExternalIndexBase = 1000
+
+// WriteProto writes a proto3 schema for the tables and scalars of mods.
+//
+// Each conceptual row becomes a message whose fields are the row's
+// columns, numbered by column arc so numbers stay stable as a MIB
+// evolves. INDEX objects borrowed from another table are numbered from
+// 1000 in index order. Scalars become one message per parent node,
+// numbered by scalar arc. Enumerations become proto enums with values
+// prefixed by the enum name and an UNSPECIFIED zero value unless the
+// MIB itself defines 0. Enums of textual conventions from other modules
+// are emitted into the same file. DESCRIPTION clauses become comments.
+//
+// Value mapping: Integer32 to int32; Unsigned32, Gauge32, Counter32
+// and TimeTicks to uint32; Counter64 to uint64; text-hinted strings to
+// string; other OCTET STRINGs, IpAddress, Opaque and BITS to bytes;
+// OBJECT IDENTIFIER to its dotted string.
+func WriteProto(w io.Writer, mods []*mib.Module, opts ProtoOptions) error {
+	if len(mods) == 0 {
+		return fmt.Errorf("proto: no modules")
+	}
+	g := &protoGen{names: make(map[string]bool), enums: make(map[any]string), values: make(map[string]bool)}
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = strings.ReplaceAll(strings.ToLower(mods[0].Name()), "-", "_")
+	}
+
+	for _, mod := range mods {
+		g.module(mod)
+	}
+	if g.messages.Len() == 0 {
+		return fmt.Errorf("proto: no tables or scalars in %s", mods[0].Name())
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by gomib gen proto. DO NOT EDIT.\n")
+	for _, mod := range mods {
+		fmt.Fprintf(&b, "// Source: %s\n", mod.Name())
+	}
+	fmt.Fprintf(&b, "\nsyntax = \"proto3\";\n\npackage %s;\n", pkg)
+	if opts.GoPackage != "" {
+		fmt.Fprintf(&b, "\noption go_package = %q;\n", opts.GoPackage)
+	}
+	b.WriteString(g.messages.String())
+	b.WriteString(g.enumDecls.String())
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type protoGen struct {
+	messages  strings.Builder
+	enumDecls strings.Builder
+	names     map[string]bool // message and enum names
+	enums     map[any]string  // *mib.Type or *mib.Object -> enum name
+	values    map[string]bool // enum value names, which share the package scope
+}
+
+type protoField struct {
+	obj    *mib.Object
+	name   string // descriptor the field is named after
+	number uint32
+}
+
+func (g *protoGen) ident(name string, used map[string]bool) string {
+	id := name
+	for i := 2; used[id]; i++ {
+		id = fmt.Sprintf("%s%d", name, i)
+	}
+	used[id] = true
+	return id
+}
+
+func (g *protoGen) module(mod *mib.Module) {
+	// Scalars, grouped by parent node in order of first appearance.
+	var parents []*mib.Node
+	groups := make(map[*mib.Node][]protoField)
+	for _, obj := range sortedByOID(mod.Scalars()) {
+		parent := obj.Node().Parent()
+		if parent == nil {
+			continue
+		}
+		if _, ok := groups[parent]; !ok {
+			parents = append(parents, parent)
+		}
+		groups[parent] = append(groups[parent], protoField{obj, obj.Name(), obj.Node().Arc()})
+	}
+	for _, parent := range parents {
+		name := g.ident(goName(parent.Name()), g.names)
+		header := fmt.Sprintf("Scalars of %s::%s (%s).", mod.Name(), parent.Name(), parent.OID())
+		g.message(name, header, "", groups[parent])
+	}
+
+	for _, table := range sortedByOID(mod.Tables()) {
+		if row := table.Entry(); row != nil {
+			g.row(table, row)
+		}
+	}
+}
+
+func (g *protoGen) row(table, row *mib.Object) {
+	var fields []protoField
+	used := make(map[uint32]bool)
+	for _, col := range row.Columns() {
+		fields = append(fields, protoField{col, col.Name(), col.Node().Arc()})
+		used[col.Node().Arc()] = true
+	}
+	next := uint32(protoExternalIndexBase)
+	indexes := row.EffectiveIndexes()
+	for i, name := range indexNames(indexes) {
+		if indexes[i].Object.Row() == row {
+			continue
+		}
+		for used[next] {
+			next++
+		}
+		fields = append(fields, protoField{indexes[i].Object, name, next})
+		used[next] = true
+	}
+
+	name := g.ident(goName(row.Name()), g.names)
+	header := fmt.Sprintf("A row of %s::%s (%s).", table.Module().Name(), table.Name(), row.OID())
+	g.message(name, header, table.Description(), fields)
+}
+
+func (g *protoGen) message(name, header, desc string, fields []protoField) {
+	m := &g.messages
+	m.WriteByte('\n')
+	writeProtoComment(m, "", header)
+	if desc != "" {
+		m.WriteString("//\n")
+		writeProtoComment(m, "", desc)
+	}
+	fmt.Fprintf(m, "message %s {\n", name)
+	first := true
+	for _, f := range fields {
+		typ := g.fieldType(f.obj)
+		if typ == "" || f.number == 0 || f.number > protoMaxField || (f.number >= 19000 && f.number <= 19999) {
+			continue
+		}
+		if !first {
+			m.WriteByte('\n')
+		}
+		first = false
+		writeProtoComment(m, "  ", f.obj.Description())
+		var opts string
+		if s := f.obj.Status(); s == mib.StatusDeprecated || s == mib.StatusObsolete {
+			opts = " [deprecated = true]"
+		}
+		fmt.Fprintf(m, "  %s %s = %d%s;\n", typ, protoFieldName(f.name), f.number, opts)
+	}
+	m.WriteString("}\n")
+}
+
+// fieldType returns the proto type for obj, declaring enums on first
+// use, or "" for unsupported syntax.
+func (g *protoGen) fieldType(obj *mib.Object) string {
+	t := obj.Type()
+	if t == nil {
+		return ""
+	}
+	if enums := obj.EffectiveEnums(); len(enums) > 0 {
+		return g.enum(obj, t, enums)
+	}
+	if len(obj.EffectiveBits()) > 0 {
+		return "bytes"
+	}
+	switch t.EffectiveBase() {
+	case mib.BaseInteger32:
+		return "int32"
+	case mib.BaseUnsigned32, mib.BaseGauge32, mib.BaseCounter32, mib.BaseTimeTicks:
+		return "uint32"
+	case mib.BaseCounter64:
+		return "uint64"
+	case mib.BaseObjectIdentifier:
+		return "string"
+	case mib.BaseOctetString:
+		if isTextHint(obj.EffectiveDisplayHint()) {
+			return "string"
+		}
+		return "bytes"
+	case mib.BaseIpAddress, mib.BaseOpaque:
+		return "bytes"
+	}
+	return ""
+}
+
+func (g *protoGen) enum(obj *mib.Object, t *mib.Type, values []mib.NamedValue) string {
+	var owner any = obj
+	base := goName(obj.Name())
+	desc := obj.Description()
+	if tc := namedValuesOwner(t, goEnum, values); tc != nil {
+		owner, base, desc = tc, goName(tc.Name()), tc.Description()
+	}
+	if name, ok := g.enums[owner]; ok {
+		return name
+	}
+	name := g.ident(base, g.names)
+	g.enums[owner] = name
+
+	prefix := protoConstName(name) + "_"
+	d := &g.enumDecls
+	d.WriteByte('\n')
+	writeProtoComment(d, "", desc)
+	fmt.Fprintf(d, "enum %s {\n", name)
+	values = slices.Clone(values)
+	slices.SortStableFunc(values, func(a, b mib.NamedValue) int {
+		// The zero value must come first.
+		switch {
+		case a.Value == 0 && b.Value != 0:
+			return -1
+		case b.Value == 0 && a.Value != 0:
+			return 1
+		}
+		return 0
+	})
+	if values[0].Value != 0 {
+		fmt.Fprintf(d, "  %s = 0;\n", g.ident(prefix+"UNSPECIFIED", g.values))
+	}
+	for _, nv := range values {
+		fmt.Fprintf(d, "  %s = %d;\n", g.ident(prefix+protoConstName(nv.Label), g.values), nv.Value)
+	}
+	d.WriteString("}\n")
+	return name
+}
+
+// writeProtoComment writes text as // comment lines, keeping paragraph
+// breaks and dropping the MIB source indentation.
+func writeProtoComment(b *strings.Builder, indent, text string) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return
+	}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			fmt.Fprintf(b, "%s//\n", indent)
+			continue
+		}
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
+
+// protoFieldName converts a descriptor to a lower_snake_case field
+// name: "ifHCInOctets" becomes "if_hc_in_octets".
+func protoFieldName(s string) string {
+	return strings.ToLower(snakeWords(s))
+}
+
+// protoConstName converts a name to UPPER_SNAKE_CASE for enum values.
+func protoConstName(s string) string {
+	return strings.ToUpper(snakeWords(s))
+}
+
+// snakeWords splits a camelCase or hyphenated name into words joined
+// by underscores, keeping acronyms together ("ifHCIn" is "if_HC_In").
+func snakeWords(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				if !strings.HasSuffix(b.String(), "_") {
+					b.WriteByte('_')
+				}
+			}
+		}
+		b.WriteRune(r)
+	}
+	out := strings.TrimSuffix(b.String(), "_")
+	if out == "" || !unicode.IsLetter(rune(out[0])) {
+		out = "x_" + out
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func generateProto(t *testing.T, opts ProtoOptions, modules ...string) string {
+	t.Helper()
+	m := loadTestMIB(t)
+	var mods []*mib.Module
+	for _, name := range modules {
+		mod := m.Module(name)
+		testutil.NotNil(t, mod, "module %s", name)
+		mods = append(mods, mod)
+	}
+	var b strings.Builder
+	err := WriteProto(&b, mods, opts)
+	testutil.NoError(t, err, "WriteProto")
+	return b.String()
+}
+
+func TestWriteProtoIfMib(t *testing.T) {
+	src := generateProto(t, ProtoOptions{GoPackage: "example.com/ifmib"}, "IF-MIB")
+
+	testutil.Contains(t, src, "syntax = \"proto3\";\n\npackage if_mib;\n\noption go_package = \"example.com/ifmib\";\n", "header")
+	testutil.Contains(t, src, "message Interfaces {\n", "scalar message")
+	testutil.Contains(t, src, "  int32 if_number = 1;\n", "scalar field numbered by arc")
+	testutil.Contains(t, src, "message IfEntry {\n", "row message")
+	testutil.Contains(t, src, "  string if_descr = 2;\n", "text column")
+	testutil.Contains(t, src, "  IfAdminStatus if_admin_status = 7;\n", "enum column")
+	testutil.Contains(t, src, "  uint32 if_in_n_ucast_pkts = 12 [deprecated = true];\n", "deprecated column")
+	testutil.Contains(t, src, "  uint64 if_hc_in_octets = 6;\n", "Counter64 column")
+	testutil.Contains(t, src, "  int32 if_index = 1000;\n", "external index field")
+	testutil.Contains(t, src, "  // A textual string containing information about the\n  // interface.", "description comment")
+	testutil.Contains(t, src, `enum IfAdminStatus {
+  IF_ADMIN_STATUS_UNSPECIFIED = 0;
+  IF_ADMIN_STATUS_UP = 1;
+  IF_ADMIN_STATUS_DOWN = 2;
+  IF_ADMIN_STATUS_TESTING = 3;
+}
+`, "enum with UNSPECIFIED zero")
+	testutil.Equal(t, 1, strings.Count(src, "enum TruthValue {"), "shared TC enum declared once")
+}
+
+func TestWriteProtoFieldsUnique(t *testing.T) {
+	src := generateProto(t, ProtoOptions{}, "IF-MIB", "IP-MIB", "ENTITY-MIB", "ENTITY-STATE-MIB", "RMON2-MIB")
+	field := regexp.MustCompile(`^  \S+ (\w+) = (\d+)`)
+
+	var message string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(src, "\n") {
+		if name, ok := strings.CutPrefix(line, "message "); ok {
+			message = strings.TrimSuffix(name, " {")
+			continue
+		}
+		if strings.HasPrefix(line, "enum ") {
+			message = ""
+			continue
+		}
+		m := field.FindStringSubmatch(line)
+		if m == nil || message == "" {
+			continue
+		}
+		number, name := message+"/"+m[2], message+"/"+m[1]
+		testutil.False(t, seen[number], "duplicate field number %s", number)
+		testutil.False(t, seen[name], "duplicate field name %s", name)
+		seen[number], seen[name] = true, true
+	}
+	testutil.Greater(t, len(seen), 200, "fields checked")
+
+	// alHostEntry names protocolDirLocalIndex twice in its INDEX.
+	testutil.Contains(t, src, "  int32 protocol_dir_local_index = 1001;\n", "first index component")
+	testutil.Contains(t, src, "  int32 protocol_dir_local_index2 = 1003;\n", "repeated index component")
+}
+
+func TestWriteProtoZeroEnum(t *testing.T) {
+	src := generateProto(t, ProtoOptions{}, "IP-MIB")
+
+	// InetAddressType defines unknown(0), which becomes the zero value.
+	testutil.Contains(t, src, "enum InetAddressType {\n  INET_ADDRESS_TYPE_UNKNOWN = 0;\n", "MIB zero value kept")
+	testutil.False(t, strings.Contains(src, "INET_ADDRESS_TYPE_UNSPECIFIED"), "no UNSPECIFIED when MIB defines 0")
+}
+
+func TestSnakeWords(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"ifHCInOctets", "if_hc_in_octets"},
+		{"ifInNUcastPkts", "if_in_n_ucast_pkts"},
+		{"ipv6IfIndex", "ipv6_if_index"},
+		{"dot1dBaseBridgeAddress", "dot1d_base_bridge_address"},
+		{"under-repair", "under_repair"},
+	}
+	for _, tt := range tests {
+		testutil.Equal(t, tt.want, protoFieldName(tt.in), "protoFieldName(%q)", tt.in)
+	}
+	testutil.Equal(t, "ETHERNET_CSMACD", protoConstName("ethernetCsmacd"), "protoConstName")
+}
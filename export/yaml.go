@@ -0,0 +1,141 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// yamlMap is an ordered YAML mapping. Values may be yamlMap, []any,
+// []string, string, bool or an integer type.
+type yamlMap []yamlPair
+
+type yamlPair struct {
+	Key   string
+	Value any
+}
+
+// writeYAML writes v as block-style YAML, in the layout produced by
+// snmp_exporter's generator: sequences are not indented under their key.
+func writeYAML(w io.Writer, v yamlMap) error {
+	var b strings.Builder
+	encodeYAMLMap(&b, v, 0)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func encodeYAMLMap(b *strings.Builder, m yamlMap, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, p := range m {
+		b.WriteString(pad)
+		b.WriteString(yamlKey(p.Key))
+		b.WriteByte(':')
+		switch v := p.Value.(type) {
+		case yamlMap:
+			if len(v) == 0 {
+				b.WriteString(" {}\n")
+				continue
+			}
+			b.WriteByte('\n')
+			encodeYAMLMap(b, v, indent+2)
+		case []any:
+			if len(v) == 0 {
+				b.WriteString(" []\n")
+				continue
+			}
+			b.WriteByte('\n')
+			encodeYAMLList(b, v, indent)
+		case []string:
+			if len(v) == 0 {
+				b.WriteString(" []\n")
+				continue
+			}
+			b.WriteByte('\n')
+			items := make([]any, len(v))
+			for i, s := range v {
+				items[i] = s
+			}
+			encodeYAMLList(b, items, indent)
+		default:
+			b.WriteByte(' ')
+			b.WriteString(yamlScalar(v))
+			b.WriteByte('\n')
+		}
+	}
+}
+
+func encodeYAMLList(b *strings.Builder, items []any, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, item := range items {
+		b.WriteString(pad)
+		b.WriteString("- ")
+		if m, ok := item.(yamlMap); ok && len(m) > 0 {
+			var sub strings.Builder
+			encodeYAMLMap(&sub, m, indent+2)
+			b.WriteString(strings.TrimPrefix(sub.String(), pad+"  "))
+			continue
+		}
+		b.WriteString(yamlScalar(item))
+		b.WriteByte('\n')
+	}
+}
+
+func yamlScalar(v any) string {
+	switch x := v.(type) {
+	case string:
+		return yamlString(x)
+	case bool:
+		return strconv.FormatBool(x)
+	case int:
+		return strconv.Itoa(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case uint32:
+		return strconv.FormatUint(uint64(x), 10)
+	case yamlMap:
+		return "{}"
+	case []any, []string:
+		return "[]"
+	}
+	return yamlString(fmt.Sprint(v))
+}
+
+// yamlKey returns a mapping key, quoting only keys that would not parse
+// as a plain scalar. Numeric keys stay unquoted so they load as integers.
+func yamlKey(k string) string {
+	if k == "" || strings.ContainsAny(k, ":#{}[],&*!|>'\"%@`\n") || k != strings.TrimSpace(k) {
+		return strconv.Quote(k)
+	}
+	return k
+}
+
+// yamlString returns s as a plain scalar when that round-trips as the
+// same string, and double-quoted otherwise.
+func yamlString(s string) string {
+	if needsYAMLQuote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsYAMLQuote(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") ||
+		strings.ContainsAny(s, "\n\r\t\\") {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+func TestWriteYAML(t *testing.T) {
+	var b strings.Builder
+	err := writeYAML(&b, yamlMap{
+		{"modules", yamlMap{
+			{"if_mib", yamlMap{
+				{"walk", []string{"1.3.6.1.2.1.2.2", "ifXTable"}},
+				{"metrics", []any{
+					yamlMap{
+						{"name", "ifAdminStatus"},
+						{"help", "The desired state: up or down"},
+						{"enum_values", yamlMap{{"1", "up"}, {"2", "down"}}},
+					},
+				}},
+				{"lookups", []any{}},
+				{"empty", yamlMap{}},
+			}},
+		}},
+	})
+	testutil.NoError(t, err, "writeYAML")
+	want := `modules:
+  if_mib:
+    walk:
+    - 1.3.6.1.2.1.2.2
+    - ifXTable
+    metrics:
+    - name: ifAdminStatus
+      help: "The desired state: up or down"
+      enum_values:
+        1: up
+        2: down
+    lookups: []
+    empty: {}
+`
+	testutil.Equal(t, want, b.String(), "yaml output")
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"", `""`},
+		{"1.5", `"1.5"`},
+		{"42", `"42"`},
+		{"yes", `"yes"`},
+		{"a: b", `"a: b"`},
+		{"- item", `"- item"`},
+		{"trailing ", `"trailing "`},
+		{"1.3.6.1", "1.3.6.1"},
+		{"line\nbreak", `"line\nbreak"`},
+	}
+	for _, tt := range tests {
+		testutil.Equal(t, tt.want, yamlString(tt.in), "yamlString(%q)", tt.in)
+	}
+}
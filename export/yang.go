@@ -0,0 +1,668 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// yangTCs maps SMIv2 textual conventions and base types to the
+// equivalent ietf-yang-types and ietf-inet-types types, whose
+// definitions state that equivalence.
+var yangTCs = map[mib.TCKey]string{
+	{Module: "SNMPv2-TC", Name: "PhysAddress"}:                       "yang:phys-address",
+	{Module: "SNMPv2-TC", Name: "MacAddress"}:                        "yang:mac-address",
+	{Module: "SNMPv2-TC", Name: "TimeStamp"}:                         "yang:timestamp",
+	{Module: "HCNUM-TC", Name: "CounterBasedGauge64"}:                "yang:gauge64",
+	{Module: "HCNUM-TC", Name: "ZeroBasedCounter64"}:                 "yang:zero-based-counter64",
+	{Module: "RMON2-MIB", Name: "ZeroBasedCounter32"}:                "yang:zero-based-counter32",
+	{Module: "INET-ADDRESS-MIB", Name: "InetPortNumber"}:             "inet:port-number",
+	{Module: "INET-ADDRESS-MIB", Name: "InetVersion"}:                "inet:ip-version",
+	{Module: "INET-ADDRESS-MIB", Name: "InetAutonomousSystemNumber"}: "inet:as-number",
+	{Module: "IPV6-FLOW-LABEL-MIB", Name: "IPv6FlowLabel"}:           "inet:ipv6-flow-label",
+	{Module: "DIFFSERV-DSCP-TC", Name: "Dscp"}:                       "inet:dscp",
+}
+
+// yangSMIModules define the base types, which map to built-in and
+// ietf-yang-types types rather than typedefs.
+var yangSMIModules = map[string]bool{
+	"SNMPv2-SMI":  true,
+	"RFC1155-SMI": true,
+	"RFC1065-SMI": true,
+}
+
+// yangImports are the standard modules a translation may import.
+var yangImports = []struct{ module, prefix, revision string }{
+	{"ietf-inet-types", "inet", "2013-07-15"},
+	{"ietf-yang-smiv2", "smiv2", "2012-06-22"},
+	{"ietf-yang-types", "yang", "2013-07-15"},
+}
+
+// WriteYANG writes the RFC 6643 translation of mod as a YANG 1.1
+// module.
+//
+// The YANG module keeps the SMIv2 module name, with namespace
+// "urn:ietf:params:xml:ns:yang:smiv2:<name>" and the lowercased name as
+// prefix. Textual conventions and type assignments become typedefs.
+// All data is read-only under a config false container named after the
+// module: scalars are grouped in containers named after their parent
+// node, and each table is a container holding a list keyed by the
+// row's INDEX objects, with leafrefs for index objects of other
+// tables and for repeated index objects, which are numbered
+// (protocolDirLocalIndex2). Rows with AUGMENTS become augment statements on the base
+// list. Notifications carry one object-N container per varbind, holding
+// leafrefs to the object and its row's keys. Every translated
+// definition carries its OID in an smiv2:oid statement, and other OID
+// assignments become smiv2:alias statements. Conformance definitions
+// are not translated.
+func WriteYANG(w io.Writer, mod *mib.Module) error {
+	y := &yangGen{mod: mod, prefix: yangPrefix(mod.Name()), imports: make(map[string]string)}
+	y.imports["ietf-yang-smiv2"] = "smiv2"
+
+	var body yangWriter
+	body.indent = 1
+	y.body(&body)
+
+	var b yangWriter
+	b.line("module %s {", mod.Name())
+	b.indent++
+	b.line("yang-version 1.1;")
+	b.line("namespace %s;", yangQuote("urn:ietf:params:xml:ns:yang:smiv2:"+mod.Name(), 0))
+	b.line("prefix %s;", yangQuote(y.prefix, 0))
+	y.writeImports(&b)
+	b.blank()
+	if s := mod.Organization(); s != "" {
+		b.stmt("organization", s)
+	}
+	if s := mod.ContactInfo(); s != "" {
+		b.stmt("contact", s)
+	}
+	b.stmt("description", withDefault(mod.Description(), "Translated from the SMIv2 module "+mod.Name()+"."))
+	for _, rev := range yangRevisions(mod) {
+		b.line("revision %s {", rev.Date)
+		b.indent++
+		b.stmt("description", withDefault(rev.Description, "Revision "+rev.Date+"."))
+		b.indent--
+		b.line("}")
+	}
+	if oid := mod.OID(); len(oid) > 0 {
+		b.line("smiv2:oid %s;", yangQuote(oid.String(), 0))
+	}
+	b.buf.WriteString(body.buf.String())
+	b.indent--
+	b.line("}")
+	_, err := io.WriteString(w, b.buf.String())
+	return err
+}
+
+type yangGen struct {
+	mod     *mib.Module
+	prefix  string
+	imports map[string]string // module name -> prefix
+}
+
+// yangPrefix returns the prefix used for a translated module.
+func yangPrefix(module string) string { return strings.ToLower(module) }
+
+// ref returns the prefixed name of a definition in module, importing
+// the module when it is not the one being translated.
+func (y *yangGen) ref(module, name string) string {
+	if module == y.mod.Name() {
+		return y.prefix + ":" + name
+	}
+	p := yangPrefix(module)
+	y.imports[module] = p
+	return p + ":" + name
+}
+
+func (y *yangGen) writeImports(b *yangWriter) {
+	var mibImports []string
+	for module := range y.imports {
+		if !slices.ContainsFunc(yangImports, func(s struct{ module, prefix, revision string }) bool { return s.module == module }) {
+			mibImports = append(mibImports, module)
+		}
+	}
+	slices.Sort(mibImports)
+	for _, module := range mibImports {
+		b.blank()
+		b.line("import %s {", module)
+		b.line("  prefix %s;", yangQuote(y.imports[module], 0))
+		b.line("}")
+	}
+	for _, std := range yangImports {
+		if _, ok := y.imports[std.module]; !ok {
+			continue
+		}
+		b.blank()
+		b.line("import %s {", std.module)
+		b.line("  prefix %s;", yangQuote(std.prefix, 0))
+		b.line("  revision-date %s;", std.revision)
+		b.line("}")
+	}
+}
+
+func (y *yangGen) body(b *yangWriter) {
+	mod := y.mod
+	for _, t := range mod.Types() {
+		y.typedef(b, t)
+	}
+
+	var parents []*mib.Node
+	scalars := make(map[*mib.Node][]*mib.Object)
+	for _, obj := range sortedByOID(mod.Scalars()) {
+		parent := obj.Node().Parent()
+		if _, ok := scalars[parent]; !ok {
+			parents = append(parents, parent)
+		}
+		scalars[parent] = append(scalars[parent], obj)
+	}
+	var tables, augments []*mib.Object
+	for _, table := range sortedByOID(mod.Tables()) {
+		row := table.Entry()
+		switch {
+		case row == nil:
+		case row.Augments() != nil:
+			augments = append(augments, row)
+		default:
+			tables = append(tables, table)
+		}
+	}
+
+	if len(parents) > 0 || len(tables) > 0 {
+		b.blank()
+		b.line("container %s {", mod.Name())
+		b.indent++
+		b.line("config false;")
+		for _, parent := range parents {
+			b.blank()
+			b.line("container %s {", parent.Name())
+			b.indent++
+			b.line("smiv2:oid %s;", yangQuote(parent.OID().String(), 0))
+			for _, obj := range scalars[parent] {
+				b.blank()
+				y.leaf(b, obj)
+			}
+			b.indent--
+			b.line("}")
+		}
+		for _, table := range tables {
+			b.blank()
+			y.table(b, table)
+		}
+		b.indent--
+		b.line("}")
+	}
+
+	for _, row := range augments {
+		b.blank()
+		y.augment(b, row)
+	}
+
+	notifs := mod.Notifications()
+	slices.SortFunc(notifs, func(a, b *mib.Notification) int { return a.OID().Compare(b.OID()) })
+	for _, n := range notifs {
+		b.blank()
+		y.notification(b, n)
+	}
+
+	var aliases []*mib.Node
+	for _, nd := range mod.Nodes() {
+		if nd.Kind() == mib.KindNode && nd.Name() != "" && !nd.OID().Equal(mod.OID()) {
+			aliases = append(aliases, nd)
+		}
+	}
+	slices.SortFunc(aliases, func(a, b *mib.Node) int { return a.OID().Compare(b.OID()) })
+	for _, nd := range aliases {
+		b.blank()
+		b.line("smiv2:alias %s {", yangQuote(nd.Name(), 0))
+		b.line("  smiv2:oid %s;", yangQuote(nd.OID().String(), 0))
+		b.line("}")
+	}
+}
+
+func (y *yangGen) typedef(b *yangWriter, t *mib.Type) {
+	if t.Name() == "" || t.Parent() == nil {
+		return
+	}
+	b.blank()
+	b.line("typedef %s {", t.Name())
+	b.indent++
+	parent := t.Parent()
+	name, _ := y.typeName(parent)
+	if name == "binary" && t.DisplayHint() != "" {
+		// A display-hinted octet string is rendered as text.
+		name = "string"
+	}
+	y.typeStmt(b, name, restrictions{
+		ranges: t.Ranges(),
+		sizes:  yangSizes(name, t.Sizes()),
+		enums:  t.Enums(),
+		bits:   t.Bits(),
+	})
+	if hint := t.DisplayHint(); hint != "" {
+		b.line("smiv2:display-hint %s;", yangQuote(hint, b.indent*2+19))
+	}
+	y.status(b, t.Status())
+	if desc := t.Description(); desc != "" {
+		b.stmt("description", desc)
+	}
+	if ref := t.Reference(); ref != "" {
+		b.stmt("reference", ref)
+	}
+	b.indent--
+	b.line("}")
+}
+
+// typeName returns the YANG type for an SMIv2 type and whether it is a
+// standard YANG type rather than a translated typedef.
+func (y *yangGen) typeName(t *mib.Type) (string, bool) {
+	if t.Module() != nil {
+		if std, ok := yangTCs[mib.TCKey{Module: t.Module().Name(), Name: t.Name()}]; ok {
+			y.importStd(std)
+			return std, true
+		}
+	}
+	if t.Parent() != nil && t.Module() != nil && !yangSMIModules[t.Module().Name()] {
+		if t.Module() == y.mod {
+			return t.Name(), false
+		}
+		return y.ref(t.Module().Name(), t.Name()), false
+	}
+	var name string
+	switch t.EffectiveBase() {
+	case mib.BaseInteger32:
+		name = "int32"
+		if len(t.Enums()) > 0 {
+			name = "enumeration"
+		}
+	case mib.BaseUnsigned32:
+		name = "uint32"
+	case mib.BaseGauge32:
+		name = "yang:gauge32"
+	case mib.BaseCounter32:
+		name = "yang:counter32"
+	case mib.BaseCounter64:
+		name = "yang:counter64"
+	case mib.BaseTimeTicks:
+		name = "yang:timeticks"
+	case mib.BaseIpAddress:
+		name = "inet:ipv4-address"
+	case mib.BaseObjectIdentifier:
+		name = "yang:object-identifier-128"
+	case mib.BaseBits:
+		name = "bits"
+	default:
+		name = "binary"
+	}
+	y.importStd(name)
+	return name, true
+}
+
+func (y *yangGen) importStd(name string) {
+	switch {
+	case strings.HasPrefix(name, "yang:"):
+		y.imports["ietf-yang-types"] = "yang"
+	case strings.HasPrefix(name, "inet:"):
+		y.imports["ietf-inet-types"] = "inet"
+	}
+}
+
+type restrictions struct {
+	ranges, sizes []mib.Range
+	enums, bits   []mib.NamedValue
+}
+
+func (y *yangGen) typeStmt(b *yangWriter, name string, r restrictions) {
+	if len(r.enums) > 0 && name == "int32" {
+		name = "enumeration"
+	}
+	if len(r.bits) > 0 && name == "binary" {
+		name = "bits"
+	}
+	var sub yangWriter
+	sub.indent = b.indent + 1
+	if len(r.ranges) > 0 && name != "enumeration" {
+		sub.line("range %s;", yangQuote(joinRanges(r.ranges), 0))
+	}
+	if len(r.sizes) > 0 {
+		sub.line("length %s;", yangQuote(joinRanges(r.sizes), 0))
+	}
+	for _, nv := range r.enums {
+		sub.line("enum %s {", yangQuote(nv.Label, 0))
+		sub.line("  value %d;", nv.Value)
+		sub.line("}")
+	}
+	for _, nv := range r.bits {
+		sub.line("bit %s {", nv.Label)
+		sub.line("  position %d;", nv.Value)
+		sub.line("}")
+	}
+	if sub.buf.Len() == 0 {
+		b.line("type %s;", name)
+		return
+	}
+	b.line("type %s {", name)
+	b.buf.WriteString(sub.buf.String())
+	b.line("}")
+}
+
+// yangSizes drops SIZE restrictions that a type without a length
+// facet cannot carry.
+func yangSizes(name string, sizes []mib.Range) []mib.Range {
+	if name == "bits" || name == "enumeration" {
+		return nil
+	}
+	return sizes
+}
+
+// leafType writes the type of obj: its typedef or base type, restated
+// with the object's own refinements.
+func (y *yangGen) leafType(b *yangWriter, obj *mib.Object) {
+	t := obj.Type()
+	if t == nil {
+		b.line("type binary;")
+		return
+	}
+	name, std := y.typeName(t)
+	var r restrictions
+	if !std || t.Parent() != nil {
+		// Refinements of a typedef or mapped TC.
+		if !slices.Equal(obj.EffectiveRanges(), t.EffectiveRanges()) {
+			r.ranges = obj.EffectiveRanges()
+		}
+		if !slices.Equal(obj.EffectiveSizes(), t.EffectiveSizes()) {
+			r.sizes = obj.EffectiveSizes()
+		}
+		if !slices.Equal(obj.EffectiveEnums(), t.EffectiveEnums()) {
+			r.enums = obj.EffectiveEnums()
+		}
+		if !slices.Equal(obj.EffectiveBits(), t.EffectiveBits()) {
+			r.bits = obj.EffectiveBits()
+		}
+	} else {
+		r = restrictions{
+			ranges: obj.EffectiveRanges(),
+			sizes:  obj.EffectiveSizes(),
+			enums:  obj.EffectiveEnums(),
+			bits:   obj.EffectiveBits(),
+		}
+	}
+	r.sizes = yangSizes(name, r.sizes)
+	y.typeStmt(b, name, r)
+}
+
+func (y *yangGen) leaf(b *yangWriter, obj *mib.Object) {
+	b.line("leaf %s {", obj.Name())
+	b.indent++
+	y.leafType(b, obj)
+	if u := obj.Units(); u != "" {
+		b.line("units %s;", yangQuote(u, 0))
+	}
+	b.line("smiv2:max-access %s;", yangQuote(obj.Access().String(), 0))
+	if dv := obj.DefaultValue(); !dv.IsZero() {
+		b.line("smiv2:defval %s;", yangQuote(withDefault(dv.Raw(), dv.String()), 0))
+	}
+	y.status(b, obj.Status())
+	if desc := obj.Description(); desc != "" {
+		b.stmt("description", desc)
+	}
+	if ref := obj.Reference(); ref != "" {
+		b.stmt("reference", ref)
+	}
+	b.line("smiv2:oid %s;", yangQuote(obj.OID().String(), 0))
+	b.indent--
+	b.line("}")
+}
+
+func (y *yangGen) status(b *yangWriter, s mib.Status) {
+	switch s {
+	case mib.StatusDeprecated:
+		b.line("status deprecated;")
+	case mib.StatusObsolete:
+		b.line("status obsolete;")
+	}
+}
+
+func (y *yangGen) table(b *yangWriter, table *mib.Object) {
+	row := table.Entry()
+	b.line("container %s {", table.Name())
+	b.indent++
+	y.status(b, table.Status())
+	if desc := table.Description(); desc != "" {
+		b.stmt("description", desc)
+	}
+	b.line("smiv2:oid %s;", yangQuote(table.OID().String(), 0))
+	b.blank()
+
+	b.line("list %s {", row.Name())
+	b.indent++
+	indexes := row.EffectiveIndexes()
+	keys := indexNames(indexes)
+	var implied string
+	for i, idx := range indexes {
+		if idx.Implied {
+			implied = keys[i]
+		}
+	}
+	b.line("key %s;", yangQuote(strings.Join(keys, " "), 0))
+	if implied != "" {
+		b.line("smiv2:implied %s;", yangQuote(implied, 0))
+	}
+	y.status(b, row.Status())
+	if desc := row.Description(); desc != "" {
+		b.stmt("description", desc)
+	}
+	b.line("smiv2:oid %s;", yangQuote(row.OID().String(), 0))
+
+	// Index objects of other tables, and repeats of an index object,
+	// are leafrefs to its definition.
+	for i, idx := range indexes {
+		if idx.Object.Row() == row && keys[i] == idx.Object.Name() {
+			continue
+		}
+		b.blank()
+		b.line("leaf %s {", keys[i])
+		b.indent++
+		y.leafref(b, idx.Object)
+		b.indent--
+		b.line("}")
+	}
+	for _, col := range row.Columns() {
+		b.blank()
+		y.leaf(b, col)
+	}
+	b.indent--
+	b.line("}")
+	b.indent--
+	b.line("}")
+}
+
+func (y *yangGen) augment(b *yangWriter, row *mib.Object) {
+	base := row.Augments()
+	b.line("augment %s {", yangQuote(y.path(base), 0))
+	b.indent++
+	y.status(b, row.Status())
+	if desc := row.Description(); desc != "" {
+		b.stmt("description", desc)
+	}
+	b.line("smiv2:oid %s;", yangQuote(row.OID().String(), 0))
+	for _, col := range row.Columns() {
+		b.blank()
+		y.leaf(b, col)
+	}
+	b.indent--
+	b.line("}")
+}
+
+func (y *yangGen) notification(b *yangWriter, n *mib.Notification) {
+	b.line("notification %s {", n.Name())
+	b.indent++
+	y.status(b, n.Status())
+	if desc := n.Description(); desc != "" {
+		b.stmt("description", desc)
+	}
+	if ref := n.Reference(); ref != "" {
+		b.stmt("reference", ref)
+	}
+	b.line("smiv2:oid %s;", yangQuote(n.OID().String(), 0))
+	for i, obj := range n.Objects() {
+		b.blank()
+		b.line("container object-%d {", i+1)
+		b.indent++
+		if row := obj.Row(); row != nil {
+			indexes := row.EffectiveIndexes()
+			for i, name := range indexNames(indexes) {
+				idx := indexes[i]
+				if idx.Object == obj && name == obj.Name() {
+					continue
+				}
+				b.line("leaf %s {", name)
+				b.indent++
+				y.leafref(b, idx.Object)
+				b.indent--
+				b.line("}")
+			}
+		}
+		b.line("leaf %s {", obj.Name())
+		b.indent++
+		y.leafref(b, obj)
+		b.indent--
+		b.line("}")
+		b.indent--
+		b.line("}")
+	}
+	b.indent--
+	b.line("}")
+}
+
+func (y *yangGen) leafref(b *yangWriter, target *mib.Object) {
+	b.line("type leafref {")
+	b.line("  path %s;", yangQuote(y.path(target), 0))
+	b.line("}")
+}
+
+// path returns the absolute schema node path of a row, column or
+// scalar. Columns of augmenting rows hang off the augmented list, in
+// the augmenting module's namespace.
+func (y *yangGen) path(obj *mib.Object) string {
+	mod := obj.Module().Name()
+	switch {
+	case obj.IsScalar():
+		parent := obj.Node().Parent()
+		return "/" + y.ref(mod, mod) + "/" + y.ref(mod, parent.Name()) + "/" + y.ref(mod, obj.Name())
+	case obj.IsColumn():
+		return y.path(obj.Row()) + "/" + y.ref(mod, obj.Name())
+	case obj.IsRow():
+		if base := obj.Augments(); base != nil {
+			return y.path(base)
+		}
+		table := obj.Table()
+		return "/" + y.ref(mod, mod) + "/" + y.ref(mod, table.Name()) + "/" + y.ref(mod, obj.Name())
+	}
+	return "/" + y.ref(mod, mod)
+}
+
+// yangRevisions returns the module's revisions with YANG dates, newest
+// first, falling back to LAST-UPDATED.
+func yangRevisions(mod *mib.Module) []mib.Revision {
+	var out []mib.Revision
+	seen := make(map[string]bool)
+	for _, rev := range mod.Revisions() {
+		date := yangDate(rev.Date)
+		if date == "" || seen[date] {
+			continue
+		}
+		seen[date] = true
+		out = append(out, mib.Revision{Date: date, Description: rev.Description})
+	}
+	if len(out) == 0 {
+		if date := yangDate(mod.LastUpdated()); date != "" {
+			out = append(out, mib.Revision{Date: date})
+		}
+	}
+	slices.SortStableFunc(out, func(a, b mib.Revision) int { return strings.Compare(b.Date, a.Date) })
+	return out
+}
+
+// yangDate converts an SMI date ("200006140000Z", "9901010000Z") or
+// "YYYY-MM-DD" to a YANG revision date.
+func yangDate(s string) string {
+	switch {
+	case len(s) == 10 && s[4] == '-' && s[7] == '-':
+		return s
+	case len(s) == 13 && strings.HasSuffix(s, "Z"):
+		return s[0:4] + "-" + s[4:6] + "-" + s[6:8]
+	case len(s) == 11 && strings.HasSuffix(s, "Z"):
+		return "19" + s[0:2] + "-" + s[2:4] + "-" + s[4:6]
+	}
+	return ""
+}
+
+func joinRanges(rs []mib.Range) string {
+	parts := make([]string, len(rs))
+	for i, r := range rs {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, " | ")
+}
+
+// yangWriter accumulates indented YANG statements.
+type yangWriter struct {
+	buf    strings.Builder
+	indent int
+}
+
+func (b *yangWriter) line(format string, args ...any) {
+	b.buf.WriteString(strings.Repeat("  ", b.indent))
+	fmt.Fprintf(&b.buf, format, args...)
+	b.buf.WriteByte('\n')
+}
+
+func (b *yangWriter) blank() { b.buf.WriteByte('\n') }
+
+// stmt writes a statement whose argument is free text, such as a
+// description.
+func (b *yangWriter) stmt(keyword, text string) {
+	col := b.indent*2 + len(keyword) + 1
+	b.line("%s %s;", keyword, yangQuote(text, col+1))
+}
+
+// yangQuote returns s as a double-quoted YANG string. Continuation
+// lines are indented by col spaces, which YANG strips again since
+// they reach the column after the opening quote; any indentation
+// common to the source lines is removed first.
+func yangQuote(s string, col int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	common := -1
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if common < 0 || n < common {
+			common = n
+		}
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if i > 0 {
+			b.WriteByte('\n')
+			if line == "" {
+				continue
+			}
+			b.WriteString(strings.Repeat(" ", col))
+			line = line[min(common, len(line)):]
+		}
+		line = strings.ReplaceAll(line, `\`, `\\`)
+		line = strings.ReplaceAll(line, `"`, `\"`)
+		line = strings.ReplaceAll(line, "\t", `\t`)
+		b.WriteString(line)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,451 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// yangStmt is a parsed YANG statement.
+type yangStmt struct {
+	keyword, arg string
+	children     []*yangStmt
+}
+
+func (s *yangStmt) find(keyword, arg string) *yangStmt {
+	for _, c := range s.children {
+		if c.keyword == keyword && (arg == "" || c.arg == arg) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *yangStmt) value(keyword string) string {
+	if c := s.find(keyword, ""); c != nil {
+		return c.arg
+	}
+	return ""
+}
+
+// walk calls fn for s and every statement below it.
+func (s *yangStmt) walk(fn func(*yangStmt)) {
+	fn(s)
+	for _, c := range s.children {
+		c.walk(fn)
+	}
+}
+
+// parseYANG parses YANG statements, applying the RFC 7950 section 6.1.3
+// rules for quoted strings.
+func parseYANG(src string) (*yangStmt, error) {
+	p := &yangParser{src: src}
+	root := &yangStmt{}
+	if err := p.block(root, true); err != nil {
+		return nil, fmt.Errorf("line %d: %w", p.lineNo(), err)
+	}
+	if len(root.children) != 1 {
+		return nil, fmt.Errorf("want one top-level statement, got %d", len(root.children))
+	}
+	return root.children[0], nil
+}
+
+type yangParser struct {
+	src string
+	pos int
+}
+
+func (p *yangParser) lineNo() int { return strings.Count(p.src[:p.pos], "\n") + 1 }
+
+func (p *yangParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch {
+		case strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])):
+			p.pos++
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *yangParser) block(parent *yangStmt, top bool) error {
+	for {
+		p.skipSpace()
+		if p.pos == len(p.src) {
+			if top {
+				return nil
+			}
+			return fmt.Errorf("unexpected end of input")
+		}
+		if p.src[p.pos] == '}' {
+			if top {
+				return fmt.Errorf("unbalanced }")
+			}
+			p.pos++
+			return nil
+		}
+		keyword := p.word()
+		if keyword == "" {
+			return fmt.Errorf("expected keyword at %q", p.src[p.pos:min(p.pos+20, len(p.src))])
+		}
+		stmt := &yangStmt{keyword: keyword}
+		parent.children = append(parent.children, stmt)
+		p.skipSpace()
+		if p.pos < len(p.src) && p.src[p.pos] != ';' && p.src[p.pos] != '{' {
+			arg, err := p.argument()
+			if err != nil {
+				return err
+			}
+			stmt.arg = arg
+			p.skipSpace()
+		}
+		if p.pos == len(p.src) {
+			return fmt.Errorf("unterminated statement %s", keyword)
+		}
+		switch p.src[p.pos] {
+		case ';':
+			p.pos++
+		case '{':
+			p.pos++
+			if err := p.block(stmt, false); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("expected ; or { after %s", keyword)
+		}
+	}
+}
+
+func (p *yangParser) word() string {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\r\n;{}\"'", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *yangParser) argument() (string, error) {
+	if p.src[p.pos] != '"' {
+		return p.word(), nil
+	}
+	var out strings.Builder
+	for {
+		s, err := p.quoted()
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(s)
+		save := p.pos
+		p.skipSpace()
+		if p.pos < len(p.src) && p.src[p.pos] == '+' {
+			p.pos++
+			p.skipSpace()
+			continue
+		}
+		p.pos = save
+		return out.String(), nil
+	}
+}
+
+// quoted reads a double-quoted string. Whitespace on continuation
+// lines up to the column after the opening quote is stripped, as is
+// trailing whitespace before a line break.
+func (p *yangParser) quoted() (string, error) {
+	col := p.pos - strings.LastIndexByte(p.src[:p.pos], '\n')
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.pos == len(p.src) {
+				return "", fmt.Errorf("unterminated escape")
+			}
+			switch e := p.src[p.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\':
+				b.WriteByte(e)
+			default:
+				return "", fmt.Errorf("invalid escape \\%c", e)
+			}
+			p.pos++
+		case '\n':
+			s := strings.TrimRight(b.String(), " \t")
+			b.Reset()
+			b.WriteString(s)
+			b.WriteByte('\n')
+			for i := 0; i < col && p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t'); i++ {
+				p.pos++
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func translateYANG(t *testing.T, module string) (*mib.Module, *yangStmt) {
+	t.Helper()
+	m := loadTestMIB(t)
+	mod := m.Module(module)
+	testutil.NotNil(t, mod, "module %s", module)
+
+	var b strings.Builder
+	err := WriteYANG(&b, mod)
+	testutil.NoError(t, err, "WriteYANG")
+	root, err := parseYANG(b.String())
+	testutil.NoError(t, err, "parse %s translation", module)
+	return mod, root
+}
+
+// descriptionLines returns the non-empty lines of a description with
+// surrounding whitespace removed.
+func descriptionLines(s string) []string {
+	var out []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func TestWriteYANGRoundTrip(t *testing.T) {
+	for _, module := range []string{"IF-MIB", "IP-MIB", "ENTITY-MIB", "ENTITY-STATE-MIB", "SNMPv2-MIB", "RMON2-MIB"} {
+		t.Run(module, func(t *testing.T) {
+			mod, root := translateYANG(t, module)
+			prefix := strings.ToLower(module)
+
+			testutil.Equal(t, "module", root.keyword, "top statement")
+			testutil.Equal(t, module, root.arg, "module name")
+			testutil.Equal(t, "1.1", root.value("yang-version"), "yang-version")
+			testutil.Equal(t, "urn:ietf:params:xml:ns:yang:smiv2:"+module, root.value("namespace"), "namespace")
+			testutil.Equal(t, prefix, root.value("prefix"), "prefix")
+			testutil.NotNil(t, root.find("import", "ietf-yang-smiv2"), "imports ietf-yang-smiv2")
+			testutil.SliceEqual(t, descriptionLines(mod.Description()), descriptionLines(root.value("description")), "module description")
+
+			// Every prefixed type must use the module's own prefix or
+			// an imported one.
+			prefixes := map[string]bool{prefix: true}
+			for _, c := range root.children {
+				if c.keyword == "import" {
+					prefixes[c.value("prefix")] = true
+				}
+			}
+
+			// Index leaves by OID, and schema paths by their segments.
+			leaves := make(map[string]*yangStmt)
+			paths := make(map[string]bool)
+			var index func(s *yangStmt, path string)
+			index = func(s *yangStmt, path string) {
+				switch s.keyword {
+				case "container", "list":
+					path += "/" + prefix + ":" + s.arg
+					paths[path] = true
+				case "augment":
+					path = s.arg
+				case "leaf":
+					paths[path+"/"+prefix+":"+s.arg] = true
+					if oid := s.value("smiv2:oid"); oid != "" {
+						leaves[oid] = s
+					}
+				case "notification":
+					return
+				}
+				for _, c := range s.children {
+					index(c, path)
+				}
+			}
+			index(root, "")
+
+			var refs []string
+			root.walk(func(s *yangStmt) {
+				// YANG forbids sibling data nodes with the same name.
+				siblings := make(map[string]bool)
+				for _, c := range s.children {
+					switch c.keyword {
+					case "leaf", "list", "container":
+						testutil.False(t, siblings[c.arg], "%s %s: duplicate %s %s", s.keyword, s.arg, c.keyword, c.arg)
+						siblings[c.arg] = true
+					}
+				}
+				switch s.keyword {
+				case "type":
+					if p, _, ok := strings.Cut(s.arg, ":"); ok {
+						testutil.True(t, prefixes[p], "type %s uses an imported prefix", s.arg)
+					}
+				case "path":
+					refs = append(refs, s.arg)
+				}
+			})
+			for _, ref := range refs {
+				if strings.HasPrefix(ref, "/"+prefix+":") {
+					testutil.True(t, paths[ref], "leafref %s resolves", ref)
+				}
+			}
+
+			for _, obj := range mod.Objects() {
+				if !obj.IsScalar() && !obj.IsColumn() {
+					continue
+				}
+				leaf := leaves[obj.OID().String()]
+				if leaf == nil {
+					t.Errorf("no leaf for %s (%s)", obj.Name(), obj.OID())
+					continue
+				}
+				testutil.Equal(t, obj.Name(), leaf.arg, "leaf name for %s", obj.OID())
+				testutil.Equal(t, obj.Access().String(), leaf.value("smiv2:max-access"), "%s max-access", obj.Name())
+				testutil.NotNil(t, leaf.find("type", ""), "%s type", obj.Name())
+				testutil.SliceEqual(t, descriptionLines(obj.Description()), descriptionLines(leaf.value("description")), "%s description", obj.Name())
+			}
+
+			top := root.find("container", module)
+			for _, row := range mod.Rows() {
+				if base := row.Augments(); base != nil {
+					var target string
+					if base.Module() == mod {
+						target = fmt.Sprintf("/%s:%s/%s:%s/%s:%s", prefix, module, prefix, base.Table().Name(), prefix, base.Name())
+					} else {
+						p := strings.ToLower(base.Module().Name())
+						target = fmt.Sprintf("/%s:%s/%s:%s/%s:%s", p, base.Module().Name(), p, base.Table().Name(), p, base.Name())
+					}
+					found := false
+					for _, c := range root.children {
+						if c.keyword == "augment" && c.arg == target && c.value("smiv2:oid") == row.OID().String() {
+							found = true
+						}
+					}
+					testutil.True(t, found, "augment of %s for %s", target, row.Name())
+					continue
+				}
+				testutil.NotNil(t, top, "top-level container")
+				table := top.find("container", row.Table().Name())
+				if table == nil {
+					t.Errorf("no container for %s", row.Table().Name())
+					continue
+				}
+				list := table.find("list", row.Name())
+				if list == nil {
+					t.Errorf("no list for %s", row.Name())
+					continue
+				}
+				// A repeated index object is numbered from its second
+				// occurrence, as in RMON2-MIB alHostEntry.
+				var keys []string
+				seen := make(map[string]int)
+				for _, idx := range row.EffectiveIndexes() {
+					key := idx.Object.Name()
+					if seen[key]++; seen[key] > 1 {
+						key += strconv.Itoa(seen[key])
+					}
+					keys = append(keys, key)
+					testutil.NotNil(t, list.find("leaf", key), "%s key leaf %s", row.Name(), key)
+				}
+				testutil.Equal(t, strings.Join(keys, " "), list.value("key"), "%s key", row.Name())
+				testutil.Equal(t, row.OID().String(), list.value("smiv2:oid"), "%s oid", row.Name())
+			}
+
+			for _, n := range mod.Notifications() {
+				stmt := root.find("notification", n.Name())
+				if stmt == nil {
+					t.Errorf("no notification %s", n.Name())
+					continue
+				}
+				testutil.Equal(t, n.OID().String(), stmt.value("smiv2:oid"), "%s oid", n.Name())
+				for i, obj := range n.Objects() {
+					c := stmt.find("container", fmt.Sprintf("object-%d", i+1))
+					if c == nil {
+						t.Errorf("%s: no container for varbind %d", n.Name(), i+1)
+						continue
+					}
+					testutil.NotNil(t, c.find("leaf", obj.Name()), "%s varbind %s", n.Name(), obj.Name())
+				}
+			}
+
+			for _, typ := range mod.Types() {
+				if typ.Parent() == nil {
+					continue
+				}
+				td := root.find("typedef", typ.Name())
+				if td == nil {
+					t.Errorf("no typedef %s", typ.Name())
+					continue
+				}
+				testutil.Equal(t, typ.DisplayHint(), td.value("smiv2:display-hint"), "%s display-hint", typ.Name())
+			}
+		})
+	}
+}
+
+func TestWriteYANGTypes(t *testing.T) {
+	_, root := translateYANG(t, "IF-MIB")
+
+	td := root.find("typedef", "InterfaceIndex")
+	testutil.Equal(t, "int32", td.value("type"), "InterfaceIndex base")
+	testutil.Equal(t, "1..2147483647", td.find("type", "").value("range"), "InterfaceIndex range")
+	testutil.Equal(t, "string", root.find("typedef", "OwnerString").value("type"), "display-hinted string")
+	testutil.Equal(t, "deprecated", root.find("typedef", "OwnerString").value("status"), "typedef status")
+
+	ifEntry := root.find("container", "IF-MIB").find("container", "ifTable").find("list", "ifEntry")
+	testutil.Equal(t, "InterfaceIndex", ifEntry.find("leaf", "ifIndex").value("type"), "local typedef")
+	testutil.Equal(t, "snmpv2-tc:DisplayString", ifEntry.find("leaf", "ifDescr").value("type"), "imported typedef")
+	testutil.Equal(t, "yang:counter32", ifEntry.find("leaf", "ifInOctets").value("type"), "Counter32")
+	testutil.Equal(t, "yang:phys-address", ifEntry.find("leaf", "ifPhysAddress").value("type"), "PhysAddress")
+	testutil.Equal(t, "deprecated", ifEntry.find("leaf", "ifInNUcastPkts").value("status"), "deprecated leaf")
+
+	admin := ifEntry.find("leaf", "ifAdminStatus").find("type", "")
+	testutil.Equal(t, "enumeration", admin.arg, "inline enumeration")
+	testutil.Equal(t, "3", admin.find("enum", "testing").value("value"), "enum value")
+
+	rcv := root.find("container", "IF-MIB").find("container", "ifRcvAddressTable").find("list", "ifRcvAddressEntry")
+	testutil.Equal(t, "leafref", rcv.find("leaf", "ifIndex").value("type"), "borrowed index is a leafref")
+	testutil.Equal(t, "/if-mib:IF-MIB/if-mib:ifTable/if-mib:ifEntry/if-mib:ifIndex",
+		rcv.find("leaf", "ifIndex").find("type", "").value("path"), "leafref path")
+
+	ifX := root.find("augment", "/if-mib:IF-MIB/if-mib:ifTable/if-mib:ifEntry")
+	testutil.NotNil(t, ifX.find("leaf", "ifHCInOctets"), "augmenting column")
+
+	testutil.Equal(t, "2000-06-14", root.find("revision", "").arg, "newest revision first")
+	testutil.NotNil(t, root.find("smiv2:alias", "ifConformance"), "alias for OID assignment")
+	testutil.Nil(t, root.find("smiv2:alias", "ifMIB"), "module identity is not an alias")
+}
+
+func TestYANGQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", `"plain"`},
+		{`say "hi" \o/`, `"say \"hi\" \\o/"`},
+		{"first\n    second\n      indented\n\n    last", "\"first\n  second\n    indented\n\n  last\""},
+	}
+	for _, tt := range tests {
+		testutil.Equal(t, tt.want, yangQuote(tt.in, 2), "yangQuote(%q)", tt.in)
+	}
+}
+
+func TestYANGDate(t *testing.T) {
+	tests := map[string]string{
+		"200006140000Z": "2000-06-14",
+		"9311082155Z":   "1993-11-08",
+		"2006-02-01":    "2006-02-01",
+		"bogus":         "",
+	}
+	for in, want := range tests {
+		testutil.Equal(t, want, yangDate(in), "yangDate(%q)", in)
+	}
+}
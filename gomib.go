@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
+	"github.com/golangsnmp/gomib/internal/module"
 	"github.com/golangsnmp/gomib/internal/types"
 	"github.com/golangsnmp/gomib/mib"
 )
@@ -42,6 +44,8 @@ type loadConfig struct {
 	sources     []Source
 	modules     []string
 	hasModules  bool // true when WithModules was called (even with empty list)
+	patches     []*Patch
+	progress    func(Event)
 }
 
 // WithLogger sets the logger for debug/trace output.
@@ -104,13 +108,46 @@ func WithModules(names ...string) LoadOption {
 //
 //	m, err := gomib.Load(ctx, gomib.WithSystemPaths())
 func Load(ctx context.Context, opts ...LoadOption) (*mib.Mib, error) {
+	return runLoad(newLoadConfig(opts), func(cfg loadConfig) (*mib.Mib, error) {
+		return load(ctx, cfg)
+	})
+}
+
+// newLoadConfig returns the defaults with opts applied.
+func newLoadConfig(opts []LoadOption) loadConfig {
 	cfg := loadConfig{
 		diagConfig: mib.DefaultConfig(),
 	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	return cfg
+}
+
+// runLoad calls load and reports its completion as an [EventComplete].
+func runLoad(cfg loadConfig, load func(loadConfig) (*mib.Mib, error)) (*mib.Mib, error) {
+	start := time.Now()
+	m, err := load(cfg)
+	done := Event{Kind: EventComplete, Duration: time.Since(start), Err: err}
+	if m != nil {
+		done.Count = len(m.Modules())
+	}
+	cfg.emit(done)
+	return m, err
+}
+
+func load(ctx context.Context, cfg loadConfig) (*mib.Mib, error) {
+	modules, err := parseModules(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	m := resolveModules(collectModules(modules), cfg)
+	return m, checkLoadResult(m, cfg, cfg.modules)
+}
 
+// parseModules finds and decodes the modules cfg selects: the named
+// modules and their imports, or every module in the sources.
+func parseModules(ctx context.Context, cfg loadConfig) (map[string]*module.Module, error) {
 	sources := cfg.sources
 	if cfg.systemPaths {
 		sources = append(sources, discoverSystemSources(types.Logger{L: cfg.logger})...)
@@ -120,9 +157,9 @@ func Load(ctx context.Context, opts ...LoadOption) (*mib.Mib, error) {
 	}
 
 	if cfg.hasModules {
-		return loadModulesByName(ctx, sources, cfg.modules, cfg)
+		return findModuleClosure(ctx, sources, cfg.modules, cfg, nil)
 	}
-	return loadAllModules(ctx, sources, cfg)
+	return parseAllModules(ctx, sources, cfg)
 }
 
 // checkLoadResult checks the resolved Mib for diagnostic threshold violations
@@ -0,0 +1,147 @@
+package patch
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golangsnmp/gomib/internal/module"
+	"github.com/golangsnmp/gomib/internal/types"
+)
+
+// Apply applies the blocks for mod to it in order. Each directive is
+// recorded as a patch-applied info diagnostic, or as a patch-unmatched
+// minor diagnostic when its target does not exist, so that stale patches are
+// noticed when a vendor fixes the MIB.
+func (f *File) Apply(mod *module.Module, cfg types.DiagnosticConfig) {
+	for _, b := range f.Blocks {
+		if b.Module != mod.Name {
+			continue
+		}
+		for i := range b.Ops {
+			op := &b.Ops[i]
+			span, err := apply(mod, op)
+			code, sev := types.DiagPatchApplied, types.SeverityInfo
+			msg := fmt.Sprintf("patch %s:%d applied: %s", f.Name, op.Line, op.Text)
+			if err != nil {
+				code, sev = types.DiagPatchUnmatched, types.SeverityMinor
+				msg = fmt.Sprintf("patch %s:%d not applied: %v", f.Name, op.Line, err)
+			}
+			if !cfg.ShouldReport(code, sev) {
+				continue
+			}
+			d := types.Diagnostic{Severity: sev, Code: code, Message: msg, Module: mod.Name}
+			if span != types.Synthetic {
+				d.Line, d.Column = types.LineColFromTable(mod.LineTable, span.Start)
+			}
+			mod.Diagnostics = append(mod.Diagnostics, d)
+		}
+	}
+}
+
+// apply applies one directive, returning the span of the definition it
+// changed, if any.
+func apply(mod *module.Module, op *Op) (types.Span, error) {
+	switch op.Kind {
+	case KindImport:
+		for _, sym := range op.Symbols {
+			mod.Imports = slices.DeleteFunc(mod.Imports, func(imp module.Import) bool { return imp.Symbol == sym })
+			mod.Imports = append(mod.Imports, module.NewImport(op.Target, sym, types.Synthetic))
+		}
+		return types.Synthetic, nil
+
+	case KindRename:
+		if !rename(mod, op.Target, op.NewName) {
+			return types.Synthetic, fmt.Errorf("%s is not used in %s", op.Target, mod.Name)
+		}
+		// The parser's complaints about the old spelling no longer apply.
+		quoted := strconv.Quote(op.Target)
+		mod.Diagnostics = slices.DeleteFunc(mod.Diagnostics, func(d types.Diagnostic) bool {
+			return isIdentifierDiag(d.Code) && strings.Contains(d.Message, quoted)
+		})
+		if def := findDefinition(mod, op.NewName); def != nil {
+			return def.DefinitionSpan(), nil
+		}
+		return types.Synthetic, nil
+
+	case KindDrop:
+		if i := slices.IndexFunc(mod.Definitions, func(d module.Definition) bool { return d.DefinitionName() == op.Target }); i >= 0 {
+			span := mod.Definitions[i].DefinitionSpan()
+			mod.Definitions = slices.Delete(mod.Definitions, i, i+1)
+			dropDiagnostics(mod, span)
+			return span, nil
+		}
+		n := len(mod.Imports)
+		mod.Imports = slices.DeleteFunc(mod.Imports, func(imp module.Import) bool { return imp.Symbol == op.Target })
+		if len(mod.Imports) == n {
+			return types.Synthetic, fmt.Errorf("no definition or import %s in %s", op.Target, mod.Name)
+		}
+		return types.Synthetic, nil
+	}
+
+	def := findDefinition(mod, op.Target)
+	if def == nil {
+		return types.Synthetic, fmt.Errorf("no definition %s in %s", op.Target, mod.Name)
+	}
+	value, err := op.parseValue()
+	if err != nil {
+		return types.Synthetic, err
+	}
+	switch op.Kind {
+	case KindOID:
+		oid := def.DefinitionOid()
+		if oid == nil {
+			return types.Synthetic, fmt.Errorf("%s has no OID assignment", op.Target)
+		}
+		oid.Components = value.DefinitionOid().Components
+	case KindSyntax:
+		syntax := value.(*module.ObjectType).Syntax
+		switch d := def.(type) {
+		case *module.ObjectType:
+			d.Syntax = syntax
+		case *module.TypeDef:
+			d.Syntax = syntax
+		default:
+			return types.Synthetic, fmt.Errorf("%s has no SYNTAX", op.Target)
+		}
+	case KindDefVal:
+		obj, ok := def.(*module.ObjectType)
+		if !ok {
+			return types.Synthetic, fmt.Errorf("%s is not an OBJECT-TYPE", op.Target)
+		}
+		obj.DefVal = value.(*module.ObjectType).DefVal
+	}
+	return def.DefinitionSpan(), nil
+}
+
+func findDefinition(mod *module.Module, name string) module.Definition {
+	for _, def := range mod.Definitions {
+		if def.DefinitionName() == name {
+			return def
+		}
+	}
+	return nil
+}
+
+func isIdentifierDiag(code string) bool {
+	switch code {
+	case types.DiagIdentifierUnderscore, types.DiagIdentifierHyphenEnd,
+		types.DiagIdentifierLength64, types.DiagIdentifierLength32, types.DiagBadIdentifierCase:
+		return true
+	}
+	return false
+}
+
+// dropDiagnostics removes the diagnostics reported inside a dropped
+// definition.
+func dropDiagnostics(mod *module.Module, span types.Span) {
+	first, _ := types.LineColFromTable(mod.LineTable, span.Start)
+	last, _ := types.LineColFromTable(mod.LineTable, span.End)
+	if first == 0 {
+		return
+	}
+	mod.Diagnostics = slices.DeleteFunc(mod.Diagnostics, func(d types.Diagnostic) bool {
+		return d.Line >= first && d.Line <= last
+	})
+}
@@ -0,0 +1,240 @@
+// Package patch parses MIB patch files and applies them to lowered
+// modules before resolution.
+//
+// A patch file corrects broken vendor MIBs without editing them. It is a
+// list of module blocks, each holding one directive per line:
+//
+//	# Fixes for the 2019 ACME release.
+//	module ACME-MIB
+//	    import DisplayString, TruthValue FROM SNMPv2-TC
+//	    rename acme_fooTable acmeFooTable
+//	    oid acmeFoo { acmeProducts 5 }
+//	    syntax acmeFooSpeed Unsigned32 (0..100)
+//	    defval acmeFooMode { enabled }
+//	    drop acmeBrokenThing
+//
+// Values use MIB syntax and are parsed by the MIB parser. A directive
+// continues onto following lines while its braces or parentheses are
+// open, so enumerations can be written one label per line. Lines
+// starting with # are comments.
+package patch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangsnmp/gomib/internal/module"
+	"github.com/golangsnmp/gomib/internal/parser"
+	"github.com/golangsnmp/gomib/internal/types"
+)
+
+// Kind identifies a patch directive.
+type Kind int
+
+const (
+	KindImport Kind = iota // import SYMBOL[, SYMBOL...] FROM MODULE
+	KindRename             // rename OLD NEW
+	KindOID                // oid NAME { ... }
+	KindSyntax             // syntax NAME TYPE
+	KindDefVal             // defval NAME { ... }
+	KindDrop               // drop NAME
+)
+
+var kindNames = map[string]Kind{
+	"import": KindImport,
+	"rename": KindRename,
+	"oid":    KindOID,
+	"syntax": KindSyntax,
+	"defval": KindDefVal,
+	"drop":   KindDrop,
+}
+
+// Op is one patch directive.
+type Op struct {
+	Kind Kind
+	// Target is the definition the directive applies to; for import, the
+	// module symbols are imported from; for rename, the old name.
+	Target  string
+	Symbols []string // import: symbols to import
+	NewName string   // rename: new name
+	// Value is the MIB text of an oid, syntax or defval directive. It is
+	// parsed afresh for each module patched, since later directives and
+	// the resolver may modify what it produces.
+	Value string
+	Line  int    // line of the directive in the patch file
+	Text  string // directive as written, for diagnostics
+}
+
+// Block is the list of directives for one module.
+type Block struct {
+	Module string
+	Ops    []Op
+}
+
+// File is a parsed patch file.
+type File struct {
+	Name   string
+	Blocks []Block
+}
+
+// Parse parses a patch file. The name is used in error messages and
+// diagnostics.
+func Parse(name string, data []byte) (*File, error) {
+	f := &File{Name: name}
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		text := strings.TrimSpace(lines[i])
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		for depth(text) > 0 && i+1 < len(lines) {
+			i++
+			text += " " + strings.TrimSpace(lines[i])
+		}
+		if depth(text) != 0 {
+			return nil, fmt.Errorf("%s:%d: unbalanced braces or parentheses", name, lineNo)
+		}
+
+		word, rest, _ := strings.Cut(text, " ")
+		rest = strings.TrimSpace(rest)
+		if word == "module" {
+			if rest == "" || strings.ContainsAny(rest, " \t") {
+				return nil, fmt.Errorf("%s:%d: module needs a single module name", name, lineNo)
+			}
+			f.Blocks = append(f.Blocks, Block{Module: rest})
+			continue
+		}
+		kind, ok := kindNames[word]
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: unknown directive %q", name, lineNo, word)
+		}
+		if len(f.Blocks) == 0 {
+			return nil, fmt.Errorf("%s:%d: %s before any module line", name, lineNo, word)
+		}
+		op, err := parseOp(kind, rest)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s: %w", name, lineNo, word, err)
+		}
+		op.Line = lineNo
+		op.Text = text
+		b := &f.Blocks[len(f.Blocks)-1]
+		b.Ops = append(b.Ops, op)
+	}
+	return f, nil
+}
+
+// depth returns the bracket nesting left open at the end of s, ignoring
+// brackets inside quoted strings.
+func depth(s string) int {
+	d := 0
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '{' || r == '(':
+			d++
+		case r == '}' || r == ')':
+			d--
+		}
+	}
+	return d
+}
+
+func parseOp(kind Kind, rest string) (Op, error) {
+	op := Op{Kind: kind}
+	switch kind {
+	case KindImport:
+		idx := strings.LastIndex(strings.ToUpper(rest), " FROM ")
+		if idx < 0 {
+			return op, fmt.Errorf("expected SYMBOL[, SYMBOL...] FROM MODULE")
+		}
+		op.Target = strings.TrimSpace(rest[idx+len(" FROM "):])
+		for _, sym := range strings.Split(rest[:idx], ",") {
+			if sym = strings.TrimSpace(sym); sym != "" {
+				op.Symbols = append(op.Symbols, sym)
+			}
+		}
+		if op.Target == "" || len(op.Symbols) == 0 {
+			return op, fmt.Errorf("expected SYMBOL[, SYMBOL...] FROM MODULE")
+		}
+		return op, nil
+
+	case KindRename:
+		fields := strings.Fields(rest)
+		if len(fields) != 2 {
+			return op, fmt.Errorf("expected OLD NEW")
+		}
+		op.Target, op.NewName = fields[0], fields[1]
+		return op, nil
+
+	case KindDrop:
+		fields := strings.Fields(rest)
+		if len(fields) != 1 {
+			return op, fmt.Errorf("expected NAME")
+		}
+		op.Target = fields[0]
+		return op, nil
+	}
+
+	target, value, _ := strings.Cut(rest, " ")
+	value = strings.TrimSpace(value)
+	if target == "" || value == "" {
+		return op, fmt.Errorf("expected NAME VALUE")
+	}
+	op.Target = target
+	op.Value = value
+	if _, err := op.parseValue(); err != nil {
+		return op, err
+	}
+	return op, nil
+}
+
+// parseValue parses the value of an oid, syntax or defval directive into
+// a definition holding it.
+func (op *Op) parseValue() (module.Definition, error) {
+	switch op.Kind {
+	case KindOID:
+		value := strings.TrimSpace(strings.TrimPrefix(op.Value, "::="))
+		return parseFragment(fmt.Sprintf("patchValue OBJECT IDENTIFIER ::= %s", value))
+	case KindSyntax:
+		return parseFragment(objectTemplate(op.Value, ""))
+	case KindDefVal:
+		def, err := parseFragment(objectTemplate("Integer32", "DEFVAL "+op.Value))
+		if err == nil && def.(*module.ObjectType).DefVal == nil {
+			err = fmt.Errorf("expected { VALUE }")
+		}
+		return def, err
+	}
+	return nil, fmt.Errorf("directive has no value")
+}
+
+func objectTemplate(syntax, defval string) string {
+	return fmt.Sprintf(`patchValue OBJECT-TYPE
+    SYNTAX %s
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION ""
+    %s
+    ::= { 0 }`, syntax, defval)
+}
+
+// parseFragment parses a single definition with the MIB parser by
+// wrapping it in a module, and returns it lowered.
+func parseFragment(def string) (module.Definition, error) {
+	src := []byte("PATCH DEFINITIONS ::= BEGIN\n" + def + "\nEND\n")
+	p := parser.New(src, nil, types.DefaultConfig())
+	tree := p.ParseModule()
+	for _, d := range tree.Diagnostics {
+		if d.Code == types.DiagParseError {
+			return nil, fmt.Errorf("%s", d.Message)
+		}
+	}
+	mod := module.Lower(tree, src, nil, types.DefaultConfig())
+	if len(mod.Definitions) != 1 {
+		return nil, fmt.Errorf("cannot parse value")
+	}
+	return mod.Definitions[0], nil
+}
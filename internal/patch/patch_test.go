@@ -0,0 +1,97 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/module"
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/internal/types"
+)
+
+func TestParse(t *testing.T) {
+	f, err := Parse("fixes.patch", []byte(`# comment
+module ACME-MIB
+    import DisplayString, TruthValue FROM SNMPv2-TC
+    rename acme_foo acmeFoo
+
+    syntax acmeMode INTEGER {
+        on(1),
+        off(2)
+    }
+    defval acmeName { "a { b" }
+module OTHER-MIB
+    drop otherThing
+`))
+	testutil.NoError(t, err, "Parse")
+	testutil.Len(t, f.Blocks, 2, "blocks")
+
+	ops := f.Blocks[0].Ops
+	testutil.Len(t, ops, 4, "ACME-MIB directives")
+	testutil.Equal(t, "SNMPv2-TC", ops[0].Target, "import module")
+	testutil.SliceEqual(t, []string{"DisplayString", "TruthValue"}, ops[0].Symbols, "import symbols")
+	testutil.Equal(t, "acmeFoo", ops[1].NewName, "rename")
+	testutil.Equal(t, 6, ops[2].Line, "continued directive keeps its first line")
+	testutil.Equal(t, 10, ops[3].Line, "line after continuation")
+
+	def, err := ops[2].parseValue()
+	testutil.NoError(t, err, "syntax value")
+	enum, ok := def.(*module.ObjectType).Syntax.(*module.TypeSyntaxIntegerEnum)
+	testutil.True(t, ok, "enum syntax")
+	testutil.Len(t, enum.NamedNumbers, 2, "enum labels")
+
+	testutil.Equal(t, "otherThing", f.Blocks[1].Ops[0].Target, "drop")
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"no module", "drop x\n", "p:1: drop before any module line"},
+		{"unknown", "module A\nfrobnicate x\n", `p:2: unknown directive "frobnicate"`},
+		{"bad import", "module A\nimport X\n", "p:2: import: expected"},
+		{"bad rename", "module A\nrename x\n", "p:2: rename: expected OLD NEW"},
+		{"unbalanced", "module A\nsyntax x INTEGER { a(1)\n", "p:2: unbalanced"},
+		{"bad syntax", "module A\nsyntax x INTEGER { a(1) b(2) }\n", "p:2: syntax:"},
+		{"bad defval", "module A\ndefval x 5\n", "p:2: defval:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("p", []byte(tt.input))
+			testutil.Error(t, err, "Parse")
+			testutil.True(t, strings.HasPrefix(err.Error(), tt.want), "got %q, want prefix %q", err, tt.want)
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	mod := module.NewModule("A-MIB", types.Synthetic)
+	mod.Imports = []module.Import{module.NewImport("SNMPv2-TC", "DisplayString", types.Synthetic)}
+	mod.Definitions = []module.Definition{
+		&module.ObjectType{
+			Name:   "a_entry",
+			Syntax: &module.TypeSyntaxSequence{},
+			Index:  []module.IndexItem{{Object: "a_index"}},
+			Oid:    module.NewOidAssignment([]module.OidComponent{&module.OidComponentName{NameValue: "a_table"}, &module.OidComponentNumber{Value: 1}}, types.Synthetic),
+		},
+		&module.ObjectGroup{Name: "aGroup", Objects: []string{"a_index"}},
+		&module.ModuleCompliance{Modules: []module.ComplianceModule{
+			{ModuleName: "", MandatoryGroups: []string{"aGroup"}},
+			{ModuleName: "OTHER-MIB", MandatoryGroups: []string{"aGroup"}},
+		}},
+	}
+
+	testutil.True(t, rename(mod, "a_index", "aIndex"), "rename index")
+	testutil.Equal(t, "aIndex", mod.Definitions[0].(*module.ObjectType).Index[0].Object, "INDEX")
+	testutil.Equal(t, "aIndex", mod.Definitions[1].(*module.ObjectGroup).Objects[0], "group member")
+
+	testutil.True(t, rename(mod, "a_table", "aTable"), "rename OID parent")
+	testutil.Equal(t, "aTable", mod.Definitions[0].DefinitionOid().Components[0].(*module.OidComponentName).NameValue, "OID")
+
+	testutil.True(t, rename(mod, "aGroup", "aGroup2"), "rename group")
+	mc := mod.Definitions[2].(*module.ModuleCompliance)
+	testutil.Equal(t, "aGroup2", mc.Modules[0].MandatoryGroups[0], "this module's compliance clause")
+	testutil.Equal(t, "aGroup", mc.Modules[1].MandatoryGroups[0], "other module's clause untouched")
+
+	testutil.False(t, rename(mod, "nothing", "x"), "unused identifier")
+}
@@ -0,0 +1,163 @@
+package patch
+
+import "github.com/golangsnmp/gomib/internal/module"
+
+// rename replaces the identifier old with new throughout mod: imports,
+// definition names and every reference to them. References qualified
+// with another module, and clauses about other modules in
+// MODULE-COMPLIANCE and AGENT-CAPABILITIES, are left alone. It reports
+// whether anything was renamed.
+func rename(mod *module.Module, old, new string) bool {
+	r := renamer{old: old, new: new, module: mod.Name}
+	for i := range mod.Imports {
+		r.name(&mod.Imports[i].Symbol)
+	}
+	for _, def := range mod.Definitions {
+		r.definition(def)
+	}
+	return r.changed
+}
+
+type renamer struct {
+	old, new string
+	module   string
+	changed  bool
+}
+
+func (r *renamer) name(s *string) {
+	if *s == r.old {
+		*s = r.new
+		r.changed = true
+	}
+}
+
+func (r *renamer) names(list []string) {
+	for i := range list {
+		r.name(&list[i])
+	}
+}
+
+// local reports whether a clause naming moduleName refers to this module.
+func (r *renamer) local(moduleName string) bool {
+	return moduleName == "" || moduleName == r.module
+}
+
+func (r *renamer) definition(def module.Definition) {
+	if oid := def.DefinitionOid(); oid != nil {
+		r.oid(oid.Components)
+	}
+	switch d := def.(type) {
+	case *module.ObjectType:
+		r.name(&d.Name)
+		r.syntax(d.Syntax)
+		for i := range d.Index {
+			r.name(&d.Index[i].Object)
+		}
+		r.name(&d.Augments)
+		r.defVal(d.DefVal)
+	case *module.ModuleIdentity:
+		r.name(&d.Name)
+	case *module.ObjectIdentity:
+		r.name(&d.Name)
+	case *module.Notification:
+		r.name(&d.Name)
+		r.names(d.Objects)
+		if d.TrapInfo != nil {
+			r.name(&d.TrapInfo.Enterprise)
+		}
+	case *module.TypeDef:
+		r.name(&d.Name)
+		r.syntax(d.Syntax)
+	case *module.ValueAssignment:
+		r.name(&d.Name)
+	case *module.ObjectGroup:
+		r.name(&d.Name)
+		r.names(d.Objects)
+	case *module.NotificationGroup:
+		r.name(&d.Name)
+		r.names(d.Notifications)
+	case *module.ModuleCompliance:
+		r.name(&d.Name)
+		for i := range d.Modules {
+			cm := &d.Modules[i]
+			if !r.local(cm.ModuleName) {
+				continue
+			}
+			r.names(cm.MandatoryGroups)
+			for j := range cm.Groups {
+				r.name(&cm.Groups[j].Group)
+			}
+			for j := range cm.Objects {
+				r.name(&cm.Objects[j].Object)
+				r.syntax(cm.Objects[j].Syntax)
+				r.syntax(cm.Objects[j].WriteSyntax)
+			}
+		}
+	case *module.AgentCapabilities:
+		r.name(&d.Name)
+		for i := range d.Supports {
+			sm := &d.Supports[i]
+			if !r.local(sm.ModuleName) {
+				continue
+			}
+			r.names(sm.Includes)
+			for j := range sm.ObjectVariations {
+				ov := &sm.ObjectVariations[j]
+				r.name(&ov.Object)
+				r.syntax(ov.Syntax)
+				r.syntax(ov.WriteSyntax)
+				r.names(ov.CreationRequires)
+				r.defVal(ov.DefVal)
+			}
+			for j := range sm.NotificationVariations {
+				r.name(&sm.NotificationVariations[j].Notification)
+			}
+		}
+	}
+}
+
+func (r *renamer) oid(components []module.OidComponent) {
+	for _, c := range components {
+		switch c := c.(type) {
+		case *module.OidComponentName:
+			r.name(&c.NameValue)
+		case *module.OidComponentNamedNumber:
+			r.name(&c.NameValue)
+		case *module.OidComponentQualifiedName:
+			if r.local(c.ModuleValue) {
+				r.name(&c.NameValue)
+			}
+		case *module.OidComponentQualifiedNamedNumber:
+			if r.local(c.ModuleValue) {
+				r.name(&c.NameValue)
+			}
+		}
+	}
+}
+
+func (r *renamer) syntax(s module.TypeSyntax) {
+	switch s := s.(type) {
+	case *module.TypeSyntaxTypeRef:
+		r.name(&s.Name)
+	case *module.TypeSyntaxIntegerEnum:
+		r.name(&s.Base)
+	case *module.TypeSyntaxConstrained:
+		r.syntax(s.Base)
+	case *module.TypeSyntaxSequenceOf:
+		r.name(&s.EntryType)
+	case *module.TypeSyntaxSequence:
+		for i := range s.Fields {
+			r.name(&s.Fields[i].Name)
+			r.syntax(s.Fields[i].Syntax)
+		}
+	}
+}
+
+func (r *renamer) defVal(v module.DefVal) {
+	switch v := v.(type) {
+	case *module.DefValOidRef:
+		r.name(&v.Name)
+	case *module.DefValOidValue:
+		r.oid(v.Components)
+	}
+}
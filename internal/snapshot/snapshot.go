@@ -0,0 +1,402 @@
+// Package snapshot encodes lowered MIB modules in a compact binary form,
+// so that a fixed set of modules can be resolved without parsing.
+//
+// The encoding follows the Go types of package module field by field:
+// integers are varints, strings and slices are length-prefixed, pointers
+// and interfaces carry a presence or type tag. It is tied to this
+// version of the module types; [Decode] rejects data written by another
+// format version.
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/golangsnmp/gomib/internal/module"
+)
+
+// magic starts every snapshot. The last byte is the format version, to
+// be bumped whenever the module types change shape.
+const magic = "GOMIBSNAP\x01"
+
+// ErrFormat is returned by Decode for data that is not a snapshot of
+// this format version, or that is truncated or corrupt.
+var ErrFormat = errors.New("snapshot: invalid format")
+
+// concreteTypes lists the types that may appear behind the interfaces of
+// package module. A value is encoded as its index in this list, so
+// entries may only be appended, and any change needs a version bump.
+var concreteTypes = []reflect.Type{
+	// module.Definition
+	reflect.TypeFor[*module.ObjectType](),
+	reflect.TypeFor[*module.ModuleIdentity](),
+	reflect.TypeFor[*module.ObjectIdentity](),
+	reflect.TypeFor[*module.Notification](),
+	reflect.TypeFor[*module.TypeDef](),
+	reflect.TypeFor[*module.ValueAssignment](),
+	reflect.TypeFor[*module.ObjectGroup](),
+	reflect.TypeFor[*module.NotificationGroup](),
+	reflect.TypeFor[*module.ModuleCompliance](),
+	reflect.TypeFor[*module.AgentCapabilities](),
+	// module.OidComponent
+	reflect.TypeFor[*module.OidComponentName](),
+	reflect.TypeFor[*module.OidComponentNumber](),
+	reflect.TypeFor[*module.OidComponentNamedNumber](),
+	reflect.TypeFor[*module.OidComponentQualifiedName](),
+	reflect.TypeFor[*module.OidComponentQualifiedNamedNumber](),
+	// module.TypeSyntax
+	reflect.TypeFor[*module.TypeSyntaxTypeRef](),
+	reflect.TypeFor[*module.TypeSyntaxIntegerEnum](),
+	reflect.TypeFor[*module.TypeSyntaxBits](),
+	reflect.TypeFor[*module.TypeSyntaxConstrained](),
+	reflect.TypeFor[*module.TypeSyntaxSequenceOf](),
+	reflect.TypeFor[*module.TypeSyntaxSequence](),
+	reflect.TypeFor[*module.TypeSyntaxOctetString](),
+	reflect.TypeFor[*module.TypeSyntaxObjectIdentifier](),
+	// module.Constraint
+	reflect.TypeFor[*module.ConstraintSize](),
+	reflect.TypeFor[*module.ConstraintRange](),
+	// module.RangeValue
+	reflect.TypeFor[*module.RangeValueSigned](),
+	reflect.TypeFor[*module.RangeValueUnsigned](),
+	reflect.TypeFor[*module.RangeValueMin](),
+	reflect.TypeFor[*module.RangeValueMax](),
+	// module.DefVal
+	reflect.TypeFor[*module.DefValInteger](),
+	reflect.TypeFor[*module.DefValUnsigned](),
+	reflect.TypeFor[*module.DefValString](),
+	reflect.TypeFor[*module.DefValHexString](),
+	reflect.TypeFor[*module.DefValBinaryString](),
+	reflect.TypeFor[*module.DefValEnum](),
+	reflect.TypeFor[*module.DefValBits](),
+	reflect.TypeFor[*module.DefValOidRef](),
+	reflect.TypeFor[*module.DefValOidValue](),
+	reflect.TypeFor[*module.DefValUnparsed](),
+}
+
+var typeIndex = func() map[reflect.Type]uint64 {
+	m := make(map[reflect.Type]uint64, len(concreteTypes))
+	for i, t := range concreteTypes {
+		m[t] = uint64(i)
+	}
+	return m
+}()
+
+var moduleType = reflect.TypeFor[*module.Module]()
+
+// Encode writes mods to w.
+func Encode(w io.Writer, mods []*module.Module) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+	e.w.WriteString(magic)
+	e.uint(uint64(len(mods)))
+	for _, mod := range mods {
+		if err := e.value(reflect.ValueOf(mod)); err != nil {
+			return err
+		}
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (e *encoder) uint(n uint64) {
+	e.w.Write(binary.AppendUvarint(e.buf[:0], n))
+}
+
+func (e *encoder) value(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.uint(1)
+		} else {
+			e.uint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.w.Write(binary.AppendVarint(e.buf[:0], v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		e.uint(v.Uint())
+	case reflect.String:
+		e.uint(uint64(v.Len()))
+		e.w.WriteString(v.String())
+	case reflect.Slice:
+		// Zero is a nil slice; otherwise the length plus one.
+		if v.IsNil() {
+			e.uint(0)
+			return nil
+		}
+		e.uint(uint64(v.Len()) + 1)
+		for i := range v.Len() {
+			if err := e.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			e.uint(0)
+			return nil
+		}
+		e.uint(1)
+		return e.value(v.Elem())
+	case reflect.Interface:
+		// Zero is a nil interface; otherwise the type index plus one.
+		if v.IsNil() {
+			e.uint(0)
+			return nil
+		}
+		elem := v.Elem()
+		idx, ok := typeIndex[elem.Type()]
+		if !ok {
+			return fmt.Errorf("snapshot: unregistered type %s", elem.Type())
+		}
+		e.uint(idx + 1)
+		return e.value(elem)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if !v.Type().Field(i).IsExported() {
+				return fmt.Errorf("snapshot: unexported field %s.%s", v.Type(), v.Type().Field(i).Name)
+			}
+			if err := e.value(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("snapshot: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// Decode reads modules written by Encode.
+func Decode(data []byte) ([]*module.Module, error) {
+	if len(data) < len(magic) || string(data[:len(magic)]) != magic {
+		return nil, ErrFormat
+	}
+	d := &decoder{data: data[len(magic):]}
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+	decodeModule := decoderFor(moduleType)
+	mods := make([]*module.Module, n)
+	for i := range mods {
+		if err := decodeModule(d, reflect.ValueOf(&mods[i]).Elem()); err != nil {
+			return nil, err
+		}
+	}
+	if len(d.data) != 0 {
+		return nil, ErrFormat
+	}
+	return mods, nil
+}
+
+type decoder struct {
+	data []byte
+}
+
+func (d *decoder) uint() (uint64, error) {
+	n, size := binary.Uvarint(d.data)
+	if size <= 0 {
+		return 0, ErrFormat
+	}
+	d.data = d.data[size:]
+	return n, nil
+}
+
+func (d *decoder) int() (int64, error) {
+	n, size := binary.Varint(d.data)
+	if size <= 0 {
+		return 0, ErrFormat
+	}
+	d.data = d.data[size:]
+	return n, nil
+}
+
+// length reads a count of items that each take at least one byte, so a
+// count beyond the remaining data is corrupt rather than a reason to
+// allocate.
+func (d *decoder) length() (int, error) {
+	n, err := d.uint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)) {
+		return 0, ErrFormat
+	}
+	return int(n), nil
+}
+
+// sliceLength reads the length prefix of a slice, with ok false for a
+// nil slice.
+func (d *decoder) sliceLength() (n int, ok bool, err error) {
+	u, err := d.uint()
+	if err != nil || u == 0 {
+		return 0, false, err
+	}
+	if u-1 > uint64(len(d.data)) {
+		return 0, false, ErrFormat
+	}
+	return int(u - 1), true, nil
+}
+
+// decodeFunc decodes into v, which is settable and holds the zero value.
+type decodeFunc func(d *decoder, v reflect.Value) error
+
+var (
+	decodersMu sync.Mutex
+	decoders   = make(map[reflect.Type]*decodeFunc)
+)
+
+// decoderFor returns the decoder for t, building it on first use so that
+// decoding does not inspect types value by value.
+func decoderFor(t reflect.Type) decodeFunc {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	return *buildDecoder(t)
+}
+
+// buildDecoder returns a pointer to the decoder for t. The pointer is
+// registered before the decoder is built, so recursive types refer to
+// it. The caller holds decodersMu.
+func buildDecoder(t reflect.Type) *decodeFunc {
+	if f, ok := decoders[t]; ok {
+		return f
+	}
+	f := new(decodeFunc)
+	decoders[t] = f
+
+	switch t.Kind() {
+	case reflect.Bool:
+		*f = func(d *decoder, v reflect.Value) error {
+			n, err := d.uint()
+			v.SetBool(n != 0)
+			return err
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		*f = func(d *decoder, v reflect.Value) error {
+			n, err := d.int()
+			if err != nil || v.OverflowInt(n) {
+				return ErrFormat
+			}
+			v.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		*f = func(d *decoder, v reflect.Value) error {
+			n, err := d.uint()
+			if err != nil || v.OverflowUint(n) {
+				return ErrFormat
+			}
+			v.SetUint(n)
+			return nil
+		}
+	case reflect.String:
+		*f = func(d *decoder, v reflect.Value) error {
+			n, err := d.length()
+			if err != nil {
+				return err
+			}
+			v.SetString(string(d.data[:n]))
+			d.data = d.data[n:]
+			return nil
+		}
+	case reflect.Slice:
+		if t == reflect.TypeFor[[]int]() {
+			// Line tables are the longest slices; skip reflection.
+			*f = func(d *decoder, v reflect.Value) error {
+				n, ok, err := d.sliceLength()
+				if !ok {
+					return err
+				}
+				s := make([]int, n)
+				for i := range s {
+					x, err := d.int()
+					if err != nil {
+						return err
+					}
+					s[i] = int(x)
+				}
+				v.Set(reflect.ValueOf(s))
+				return nil
+			}
+			break
+		}
+		elem := buildDecoder(t.Elem())
+		*f = func(d *decoder, v reflect.Value) error {
+			n, ok, err := d.sliceLength()
+			if !ok {
+				return err
+			}
+			s := reflect.MakeSlice(t, n, n)
+			for i := range n {
+				if err := (*elem)(d, s.Index(i)); err != nil {
+					return err
+				}
+			}
+			v.Set(s)
+			return nil
+		}
+	case reflect.Pointer:
+		elem := buildDecoder(t.Elem())
+		*f = func(d *decoder, v reflect.Value) error {
+			present, err := d.uint()
+			if err != nil || present == 0 {
+				return err
+			}
+			p := reflect.New(t.Elem())
+			if err := (*elem)(d, p.Elem()); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		}
+	case reflect.Interface:
+		// One decoder per type tag, nil where the type does not
+		// implement this interface.
+		byTag := make([]*decodeFunc, len(concreteTypes))
+		for i, ct := range concreteTypes {
+			if ct.Implements(t) {
+				byTag[i] = buildDecoder(ct)
+			}
+		}
+		*f = func(d *decoder, v reflect.Value) error {
+			tag, err := d.uint()
+			if err != nil || tag == 0 {
+				return err
+			}
+			if tag > uint64(len(byTag)) || byTag[tag-1] == nil {
+				return ErrFormat
+			}
+			p := reflect.New(concreteTypes[tag-1]).Elem()
+			if err := (*byTag[tag-1])(d, p); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		}
+	case reflect.Struct:
+		fields := make([]*decodeFunc, t.NumField())
+		for i := range fields {
+			fields[i] = buildDecoder(t.Field(i).Type)
+		}
+		*f = func(d *decoder, v reflect.Value) error {
+			for i, field := range fields {
+				if err := (*field)(d, v.Field(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	default:
+		*f = func(d *decoder, v reflect.Value) error {
+			return fmt.Errorf("snapshot: unsupported type %s", t)
+		}
+	}
+	return f
+}
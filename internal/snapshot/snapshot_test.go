@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/module"
+	"github.com/golangsnmp/gomib/internal/types"
+)
+
+// testModules returns the base modules plus one module that uses every
+// concrete type behind the module interfaces, nil pointers and empty
+// slices.
+func testModules() []*module.Module {
+	access := types.AccessReadOnly
+	base := types.BaseInteger32
+	oid := module.OidAssignment{Components: []module.OidComponent{
+		&module.OidComponentName{NameValue: "enterprises"},
+		&module.OidComponentNumber{Value: 1},
+		&module.OidComponentNamedNumber{NameValue: "x", NumberValue: 2},
+		&module.OidComponentQualifiedName{ModuleValue: "M", NameValue: "y"},
+		&module.OidComponentQualifiedNamedNumber{ModuleValue: "M", NameValue: "z", NumberValue: 3},
+	}, Span: types.NewSpan(10, 20)}
+
+	mod := module.NewModule("TEST-MIB", types.NewSpan(0, 100))
+	mod.Language = types.LanguageSMIv2
+	mod.SourcePath = "ietf/TEST-MIB.mib"
+	mod.LineTable = []int{0, 17, 40}
+	mod.Imports = []module.Import{module.NewImport("SNMPv2-SMI", "enterprises", types.Synthetic)}
+	mod.Diagnostics = []types.Diagnostic{{Severity: types.SeverityWarning, Code: "c", Message: "m", Module: "TEST-MIB", Line: 2, Column: 3}}
+	mod.Definitions = []module.Definition{
+		&module.ObjectType{Name: "a", Syntax: &module.TypeSyntaxConstrained{
+			Base: &module.TypeSyntaxTypeRef{Name: "Integer32"},
+			Constraint: &module.ConstraintRange{Ranges: []module.Range{
+				module.NewRangeSigned(-5, 5),
+				module.NewRangeSingleUnsigned(1 << 63),
+				{Min: &module.RangeValueMin{}, Max: &module.RangeValueMax{}},
+			}},
+		}, Index: []module.IndexItem{{Implied: true, Object: "b"}}, DefVal: &module.DefValInteger{Value: -1}, Oid: oid},
+		&module.ObjectType{Name: "b", Syntax: &module.TypeSyntaxConstrained{
+			Base:       &module.TypeSyntaxOctetString{},
+			Constraint: &module.ConstraintSize{Ranges: []module.Range{}},
+		}, DefVal: &module.DefValHexString{Value: "ff"}},
+		&module.ObjectType{Name: "c", Syntax: &module.TypeSyntaxIntegerEnum{Base: "INTEGER", NamedNumbers: []module.NamedNumber{{Name: "up", Value: 1}}}, DefVal: &module.DefValEnum{Name: "up"}},
+		&module.ObjectType{Name: "d", Syntax: &module.TypeSyntaxBits{NamedBits: []module.NamedBit{{Name: "x", Position: 0}}}, DefVal: &module.DefValBits{Labels: []string{}}},
+		&module.ObjectType{Name: "e", Syntax: &module.TypeSyntaxObjectIdentifier{}, DefVal: &module.DefValOidValue{Components: oid.Components}},
+		&module.ObjectType{Name: "f", Syntax: &module.TypeSyntaxSequenceOf{EntryType: "G"}, DefVal: &module.DefValOidRef{Name: "zeroDotZero"}},
+		&module.ObjectType{Name: "g", Syntax: &module.TypeSyntaxSequence{Fields: []module.SequenceField{{Name: "a", Syntax: &module.TypeSyntaxTypeRef{Name: "Integer32"}}}}, DefVal: &module.DefValUnsigned{Value: 1 << 63}},
+		&module.ObjectType{Name: "h", DefVal: &module.DefValString{Value: "s"}},
+		&module.ObjectType{Name: "i", DefVal: &module.DefValBinaryString{Value: "0101"}},
+		&module.ObjectType{Name: "j", DefVal: &module.DefValUnparsed{}},
+		&module.ModuleIdentity{Name: "k", Revisions: []module.Revision{{Date: "202501010000Z", Description: "r"}}, Oid: oid},
+		&module.ObjectIdentity{Name: "l", Status: types.StatusDeprecated},
+		&module.Notification{Name: "m", Objects: []string{"a"}, TrapInfo: &module.TrapInfo{Enterprise: "e", TrapNumber: 7}},
+		&module.Notification{Name: "n", Oid: &oid},
+		&module.TypeDef{Name: "O", Syntax: &module.TypeSyntaxTypeRef{Name: "Integer32"}, BaseType: &base, IsTextualConvention: true},
+		&module.ValueAssignment{Name: "p"},
+		&module.ObjectGroup{Name: "q", Objects: []string{"a", "b"}},
+		&module.NotificationGroup{Name: "r", Notifications: []string{"m"}},
+		&module.ModuleCompliance{Name: "s", Modules: []module.ComplianceModule{{
+			MandatoryGroups: []string{"q"},
+			Groups:          []module.ComplianceGroup{{Group: "r", Description: "d"}},
+			Objects:         []module.ComplianceObject{{Object: "a", MinAccess: &access, Syntax: &module.TypeSyntaxTypeRef{Name: "Integer32"}}},
+		}}},
+		&module.AgentCapabilities{Name: "t", Supports: []module.SupportsModule{{
+			ModuleName:             "TEST-MIB",
+			Includes:               []string{"q"},
+			ObjectVariations:       []module.ObjectVariation{{Object: "a", Access: &access, CreationRequires: []string{"b"}, DefVal: &module.DefValInteger{Value: 2}}},
+			NotificationVariations: []module.NotificationVariation{{Notification: "m"}},
+		}}},
+	}
+	return append(module.CreateBaseModules(), mod)
+}
+
+func TestRoundTrip(t *testing.T) {
+	mods := testModules()
+	var buf bytes.Buffer
+	if err := Encode(&buf, mods); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !reflect.DeepEqual(mods, got) {
+		t.Error("decoded modules differ from the encoded ones")
+	}
+
+	var again bytes.Buffer
+	if err := Encode(&again, got); err != nil {
+		t.Fatalf("Encode again: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Error("encoding is not deterministic")
+	}
+}
+
+func TestDecodeCorrupt(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, testModules()); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	data := buf.Bytes()
+
+	for n := range len(data) {
+		if _, err := Decode(data[:n]); err == nil {
+			t.Fatalf("Decode of %d of %d bytes succeeded", n, len(data))
+		}
+	}
+
+	bad := bytes.Clone(data)
+	bad[len(magic)-1]++
+	if _, err := Decode(bad); err != ErrFormat {
+		t.Errorf("Decode with another version: %v, want ErrFormat", err)
+	}
+}
+
+type unregistered struct{}
+
+func (*unregistered) DefinitionName() string               { return "" }
+func (*unregistered) DefinitionSpan() types.Span           { return types.Synthetic }
+func (*unregistered) DefinitionOid() *module.OidAssignment { return nil }
+
+func TestEncodeUnregisteredType(t *testing.T) {
+	mod := module.NewModule("X", types.Synthetic)
+	mod.Definitions = []module.Definition{&unregistered{}}
+	if err := Encode(&bytes.Buffer{}, []*module.Module{mod}); err == nil {
+		t.Error("Encode of an unregistered definition type succeeded")
+	}
+}
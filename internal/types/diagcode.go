@@ -44,6 +44,12 @@ const (
 	DiagDefvalUnresolved     = "defval-unresolved"
 )
 
+// Patch diagnostic codes.
+const (
+	DiagPatchApplied   = "patch-applied"
+	DiagPatchUnmatched = "patch-unmatched"
+)
+
 // AllDiagnosticCodes returns all known diagnostic codes grouped by phase.
 func AllDiagnosticCodes() []DiagCodeInfo {
 	return []DiagCodeInfo{
@@ -79,6 +85,9 @@ func AllDiagnosticCodes() []DiagCodeInfo {
 		{Code: DiagNotifObjectNotObject, Phase: "resolver"},
 		{Code: DiagMalformedHexDefval, Phase: "resolver"},
 		{Code: DiagDefvalUnresolved, Phase: "resolver"},
+		// Patch
+		{Code: DiagPatchApplied, Phase: "patch"},
+		{Code: DiagPatchUnmatched, Phase: "patch"},
 	}
 }
 
@@ -10,10 +10,10 @@ import (
 	"runtime"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/golangsnmp/gomib/internal/module"
 	"github.com/golangsnmp/gomib/internal/parser"
-	"github.com/golangsnmp/gomib/mib"
 )
 
 func componentLogger(logger *slog.Logger, component string) *slog.Logger {
@@ -23,8 +23,8 @@ func componentLogger(logger *slog.Logger, component string) *slog.Logger {
 	return logger.With(slog.String("component", component))
 }
 
-// loadAllModules loads all MIB files from sources in parallel.
-func loadAllModules(ctx context.Context, sources []Source, cfg loadConfig) (*mib.Mib, error) {
+// parseAllModules decodes all MIB files from sources in parallel.
+func parseAllModules(ctx context.Context, sources []Source, cfg loadConfig) (map[string]*module.Module, error) {
 	if len(sources) == 0 {
 		return nil, ErrNoSources
 	}
@@ -53,8 +53,12 @@ func loadAllModules(ctx context.Context, sources []Source, cfg loadConfig) (*mib
 		}
 	}
 
+	modules := make(map[string]*module.Module)
 	if len(allModules) == 0 {
-		return mib.Resolve(nil, nil, nil), nil
+		return modules, nil
+	}
+	for _, sm := range allModules {
+		cfg.emit(Event{Kind: EventModuleDiscovered, Module: sm.name})
 	}
 
 	if logEnabled(logger, slog.LevelInfo) {
@@ -86,8 +90,10 @@ func loadAllModules(ctx context.Context, sources []Source, cfg loadConfig) (*mib
 				return
 			}
 
+			start := time.Now()
 			result, err := sm.source.Find(sm.name)
 			if err != nil {
+				cfg.emit(Event{Kind: EventModuleSkipped, Module: sm.name, Path: result.Path, Err: err})
 				if errors.Is(err, fs.ErrNotExist) {
 					if logEnabled(logger, slog.LevelDebug) {
 						logger.LogAttrs(ctx, slog.LevelDebug, "module not found",
@@ -102,6 +108,9 @@ func loadAllModules(ctx context.Context, sources []Source, cfg loadConfig) (*mib
 				return
 			}
 
+			cfg.emit(Event{Kind: EventModuleRead, Module: sm.name, Path: result.Path,
+				Bytes: len(result.Content), Duration: time.Since(start)})
+
 			mod := decodeModule(ctx, result.Content, result.Path, sm.name, logger, cfg)
 			if mod != nil {
 				results <- parseResult{mod: mod}
@@ -114,7 +123,6 @@ func loadAllModules(ctx context.Context, sources []Source, cfg loadConfig) (*mib
 		close(results)
 	}()
 
-	modules := make(map[string]*module.Module)
 	for r := range results {
 		if _, exists := modules[r.mod.Name]; !exists {
 			modules[r.mod.Name] = r.mod
@@ -125,18 +133,17 @@ func loadAllModules(ctx context.Context, sources []Source, cfg loadConfig) (*mib
 		return nil, ctx.Err()
 	}
 
-	mods := collectModules(modules)
-
 	if logEnabled(logger, slog.LevelInfo) {
 		logger.LogAttrs(ctx, slog.LevelInfo, "parallel loading complete",
-			slog.Int("modules", len(mods)))
+			slog.Int("modules", len(modules)))
 	}
-
-	m := mib.Resolve(mods, componentLogger(logger, "resolver"), &cfg.diagConfig)
-	return m, checkLoadResult(m, cfg, nil)
+	return modules, nil
 }
 
-func loadModulesByName(ctx context.Context, sources []Source, names []string, cfg loadConfig) (*mib.Mib, error) {
+// findModuleClosure finds and decodes the named modules and everything
+// they import, depth first. Missing modules are skipped. If found is not
+// nil it is called with each decoded module and the file it came from.
+func findModuleClosure(ctx context.Context, sources []Source, names []string, cfg loadConfig, found func(*module.Module, FindResult)) (map[string]*module.Module, error) {
 	logger := cfg.logger
 
 	modules := make(map[string]*module.Module)
@@ -163,11 +170,14 @@ func loadModulesByName(ctx context.Context, sources []Source, names []string, cf
 		loading[name] = struct{}{}
 		defer delete(loading, name)
 
+		cfg.emit(Event{Kind: EventModuleDiscovered, Module: name})
+		start := time.Now()
 		result, err := findModule(sources, name)
 		if err != nil {
 			if !errors.Is(err, fs.ErrNotExist) {
 				return err
 			}
+			cfg.emit(Event{Kind: EventModuleSkipped, Module: name, Err: err})
 			if logEnabled(logger, slog.LevelDebug) {
 				logger.LogAttrs(ctx, slog.LevelDebug, "module not found",
 					slog.String("module", name))
@@ -175,6 +185,9 @@ func loadModulesByName(ctx context.Context, sources []Source, names []string, cf
 			return nil // skip missing modules
 		}
 
+		cfg.emit(Event{Kind: EventModuleRead, Module: name, Path: result.Path,
+			Bytes: len(result.Content), Duration: time.Since(start)})
+
 		mod := decodeModule(ctx, result.Content, result.Path, name, logger, cfg)
 		if mod == nil {
 			return nil
@@ -184,6 +197,9 @@ func loadModulesByName(ctx context.Context, sources []Source, names []string, cf
 		if mod.Name != name {
 			modules[name] = mod // also cache under requested name
 		}
+		if found != nil {
+			found(mod, result)
+		}
 
 		for _, imp := range mod.Imports {
 			if err := loadOne(imp.Module); err != nil {
@@ -199,11 +215,7 @@ func loadModulesByName(ctx context.Context, sources []Source, names []string, cf
 			return nil, err
 		}
 	}
-
-	mods := collectModules(modules)
-
-	m := mib.Resolve(mods, componentLogger(logger, "resolver"), &cfg.diagConfig)
-	return m, checkLoadResult(m, cfg, names)
+	return modules, nil
 }
 
 func findModule(sources []Source, name string) (FindResult, error) {
@@ -252,16 +264,25 @@ func decodeModule(ctx context.Context, content []byte, sourcePath string, name s
 			logger.LogAttrs(ctx, slog.LevelDebug, "content rejected by heuristic",
 				slog.String("module", name))
 		}
+		cfg.emit(Event{Kind: EventModuleSkipped, Module: name, Path: sourcePath})
 		return nil
 	}
 
+	start := time.Now()
 	p := parser.New(content, componentLogger(logger, "parser"), cfg.diagConfig)
 	ast := p.ParseModule()
 
 	mod := module.Lower(ast, content, componentLogger(logger, "module"), cfg.diagConfig)
-	if mod != nil {
-		mod.SourcePath = sourcePath
+	if mod == nil {
+		cfg.emit(Event{Kind: EventModuleSkipped, Module: name, Path: sourcePath})
+		return nil
+	}
+	mod.SourcePath = sourcePath
+	for _, p := range cfg.patches {
+		p.file.Apply(mod, cfg.diagConfig)
 	}
+	cfg.emit(Event{Kind: EventModuleParsed, Module: name, Path: sourcePath,
+		Bytes: len(content), Duration: time.Since(start)})
 	return mod
 }
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"io/fs"
+	"os"
 	"testing"
+	"testing/fstest"
 
 	"github.com/golangsnmp/gomib/internal/testutil"
 	"github.com/golangsnmp/gomib/mib"
@@ -540,6 +542,7 @@ func TestDiagnosticThresholdNotTriggered(t *testing.T) {
 type fakeSource struct {
 	prefix  string // path prefix (default "fake")
 	modules map[string]fakeModule
+	listErr error // returned by ListModules when set
 }
 
 type fakeModule struct {
@@ -563,6 +566,9 @@ func (f *fakeSource) Find(name string) (FindResult, error) {
 }
 
 func (f *fakeSource) ListModules() ([]string, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
 	var names []string
 	for name := range f.modules {
 		names = append(names, name)
@@ -667,6 +673,52 @@ func TestFindModulePropagatesFindError(t *testing.T) {
 		"should propagate Find error, got %v", err)
 }
 
+func TestClosure(t *testing.T) {
+	src, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+
+	ctx := context.Background()
+	c, err := Closure(ctx, WithSource(src), WithModules("IF-MIB"))
+	testutil.NoError(t, err, "Closure")
+
+	var names []string
+	memFS := fstest.MapFS{}
+	for _, f := range c.Files {
+		names = append(names, f.Module)
+		memFS[f.Module+".mib"] = &fstest.MapFile{Data: f.Content}
+	}
+	testutil.SliceEqual(t, []string{"IANAifType-MIB", "IF-MIB", "SNMPv2-MIB"}, names,
+		"closure excludes built-in base modules")
+	testutil.Len(t, c.Missing, 0, "missing")
+	testutil.Equal(t, "200006140000Z", c.Files[1].Revision, "IF-MIB revision")
+
+	full, err := Load(ctx, WithSource(src), WithModules("IF-MIB"))
+	testutil.NoError(t, err, "Load from corpus")
+	vendored, err := Load(ctx, WithSource(FS("vendored", memFS)), WithModules("IF-MIB"))
+	testutil.NoError(t, err, "Load from closure")
+	testutil.Equal(t, len(full.Objects()), len(vendored.Objects()), "object count")
+}
+
+func TestClosureMissing(t *testing.T) {
+	src := &fakeSource{modules: map[string]fakeModule{
+		"TEST-CLOSURE-MIB": {content: []byte(`TEST-CLOSURE-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    enterprises FROM SNMPv2-SMI
+    AcmeThing FROM ACME-MISSING-MIB;
+testClosure OBJECT IDENTIFIER ::= { enterprises 99992 }
+END
+`)},
+	}}
+
+	ctx := context.Background()
+	c, err := Closure(ctx, WithSource(src), WithModules("TEST-CLOSURE-MIB", "NO-SUCH-MIB"))
+	testutil.True(t, errors.Is(err, ErrMissingModules), "should report missing requested module, got %v", err)
+	testutil.NotNil(t, c, "partial result")
+	testutil.Len(t, c.Files, 1, "files")
+	testutil.Equal(t, "", c.Files[0].Revision, "no MODULE-IDENTITY")
+	testutil.SliceEqual(t, []string{"ACME-MISSING-MIB", "NO-SUCH-MIB"}, c.Missing, "missing")
+}
+
 func loadInvalidMIB(t testing.TB, name string, level mib.StrictnessLevel) *mib.Mib {
 	t.Helper()
 	corpus, err := DirTree("testdata/corpus/primary")
@@ -767,3 +819,94 @@ func TestInvalidDuplicateOIDMIBBothObjectsLoad(t *testing.T) {
 			"duplicate objects should share the same OID")
 	}
 }
+
+func TestProgressLoadAll(t *testing.T) {
+	files := fstest.MapFS{"NOTES.txt": {Data: []byte("not a MIB at all")}}
+	for _, name := range []string{"IF-MIB", "SNMPv2-TC"} {
+		data, err := os.ReadFile("testdata/corpus/primary/ietf/" + name + ".mib")
+		testutil.NoError(t, err, "read %s", name)
+		files[name+".mib"] = &fstest.MapFile{Data: data}
+	}
+	src := FS("mem", files)
+
+	var events []Event
+	m, err := Load(context.Background(), WithSource(src), WithStrictness(mib.StrictnessPermissive),
+		WithProgress(func(e Event) { events = append(events, e) }))
+	testutil.NoError(t, err, "Load")
+
+	counts := make(map[EventKind]int)
+	var phases []string
+	for _, e := range events {
+		counts[e.Kind]++
+		switch e.Kind {
+		case EventModuleParsed:
+			testutil.Greater(t, e.Bytes, 0, "%s parsed bytes", e.Module)
+			testutil.True(t, e.Duration > 0, "%s parse duration", e.Module)
+		case EventModuleSkipped:
+			testutil.Equal(t, "NOTES", e.Module, "skipped module")
+			testutil.Nil(t, e.Err, "non-MIB skip has no error")
+		case EventPhaseEnd:
+			phases = append(phases, e.Phase)
+		}
+	}
+	testutil.Equal(t, 3, counts[EventModuleDiscovered], "discovered")
+	testutil.Equal(t, 3, counts[EventModuleRead], "read")
+	testutil.Equal(t, 2, counts[EventModuleParsed], "parsed")
+	testutil.Equal(t, 1, counts[EventModuleSkipped], "skipped")
+	testutil.SliceEqual(t, []string{"register", "imports", "types", "oids", "semantics"}, phases, "phases")
+	testutil.Equal(t, counts[EventPhaseEnd], counts[EventPhaseStart], "phase start/end pairs")
+
+	last := events[len(events)-1]
+	testutil.Equal(t, EventComplete, last.Kind, "last event")
+	testutil.Equal(t, len(m.Modules()), last.Count, "complete count")
+}
+
+func TestProgressByName(t *testing.T) {
+	src := MapSource(map[string][]byte{
+		"A-MIB": []byte(`A-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    enterprises FROM SNMPv2-SMI
+    thing FROM MISSING-MIB;
+a OBJECT IDENTIFIER ::= { enterprises 99998 }
+END
+`),
+	})
+
+	var events []Event
+	_, err := Load(context.Background(), WithSource(src), WithModules("A-MIB"),
+		WithStrictness(mib.StrictnessSilent),
+		WithProgress(func(e Event) { events = append(events, e) }))
+	testutil.NoError(t, err, "Load")
+
+	var discovered, skipped []string
+	for _, e := range events {
+		switch e.Kind {
+		case EventModuleDiscovered:
+			discovered = append(discovered, e.Module)
+		case EventModuleSkipped:
+			skipped = append(skipped, e.Module)
+			testutil.True(t, errors.Is(e.Err, fs.ErrNotExist), "missing module error: %v", e.Err)
+		}
+	}
+	testutil.SliceEqual(t, []string{"A-MIB", "MISSING-MIB"}, discovered, "base modules are not discovered")
+	testutil.SliceEqual(t, []string{"MISSING-MIB"}, skipped, "skipped")
+}
+
+func TestProgressCompleteOnError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	src, err := Dir("testdata/corpus/primary/ietf")
+	testutil.NoError(t, err, "Dir")
+	var last Event
+	_, err = Load(ctx, WithSource(src), WithProgress(func(e Event) { last = e }))
+	testutil.Error(t, err, "Load with cancelled context")
+	testutil.Equal(t, EventComplete, last.Kind, "last event after cancellation")
+	testutil.True(t, errors.Is(last.Err, context.Canceled), "complete carries the error: %v", last.Err)
+
+	failing := &fakeSource{listErr: errors.New("listing failed")}
+	_, err = Load(context.Background(), WithSource(failing), WithProgress(func(e Event) { last = e }))
+	testutil.Error(t, err, "Load with failing source")
+	testutil.Equal(t, EventComplete, last.Kind, "last event after source error")
+	testutil.Equal(t, err, last.Err, "complete carries the source error")
+}
@@ -0,0 +1,210 @@
+package mib
+
+import "slices"
+
+// AgentView is the effective set of objects and notifications an agent
+// implements according to an AGENT-CAPABILITIES statement: the members
+// of each SUPPORTS clause's INCLUDES groups, with every VARIATION applied.
+type AgentView struct {
+	Capability    *Capability
+	Groups        []*Group // INCLUDES groups that resolved
+	Objects       []*AgentObject
+	Notifications []*AgentNotification
+	// Unresolved lists SUPPORTS modules, INCLUDES groups and VARIATION
+	// targets that do not resolve in the Mib.
+	Unresolved []string
+
+	objects       map[*Object]*AgentObject
+	notifications map[*Notification]*AgentNotification
+}
+
+// AgentObject is an object as an agent implements it. The syntax fields
+// start from the object's effective constraints and are replaced by any
+// VARIATION SYNTAX; WriteSyntax is the VARIATION WRITE-SYNTAX, or Syntax
+// when none is given.
+type AgentObject struct {
+	Object           *Object
+	Access           Access // effective access; AccessNotImplemented if unsupported
+	Syntax           SyntaxConstraints
+	WriteSyntax      SyntaxConstraints
+	DefaultValue     DefVal    // VARIATION DEFVAL, or the object's DEFVAL
+	CreationRequires []*Object // CREATION-REQUIRES columns, for rows
+	Variation        *ObjectVariation
+}
+
+// Implemented reports whether the agent implements the object at all.
+func (o *AgentObject) Implemented() bool { return o.Access != AccessNotImplemented }
+
+// AgentNotification is a notification as an agent implements it.
+type AgentNotification struct {
+	Notification *Notification
+	Access       Access // AccessNotImplemented if unsupported, else AccessAccessibleForNotify
+	Variation    *NotificationVariation
+}
+
+// Implemented reports whether the agent sends the notification.
+func (n *AgentNotification) Implemented() bool { return n.Access != AccessNotImplemented }
+
+// Object returns the effective view of obj, or nil if the agent does
+// not claim to support it.
+func (v *AgentView) Object(obj *Object) *AgentObject { return v.objects[obj] }
+
+// Notification returns the effective view of n, or nil if the agent
+// does not claim to support it.
+func (v *AgentView) Notification(n *Notification) *AgentNotification {
+	return v.notifications[n]
+}
+
+// Implements reports whether the agent supports obj and has not
+// declared it not-implemented. Pollers can use it to skip objects.
+func (v *AgentView) Implements(obj *Object) bool {
+	o := v.objects[obj]
+	return o != nil && o.Implemented()
+}
+
+// Apply overlays the capability's VARIATION clauses onto the objects
+// and notifications of its INCLUDES groups, resolving module and group
+// references in m.
+func (c *Capability) Apply(m *Mib) *AgentView {
+	v := &AgentView{
+		Capability:    c,
+		objects:       make(map[*Object]*AgentObject),
+		notifications: make(map[*Notification]*AgentNotification),
+	}
+	for _, sm := range c.supports {
+		mod := m.Module(sm.ModuleName)
+		if mod == nil {
+			v.Unresolved = append(v.Unresolved, sm.ModuleName)
+			continue
+		}
+		for _, name := range sm.Includes {
+			g := mod.Group(name)
+			if g == nil {
+				v.Unresolved = append(v.Unresolved, mod.name+"::"+name)
+				continue
+			}
+			v.Groups = append(v.Groups, g)
+			for _, member := range g.members {
+				if member.obj != nil {
+					v.addObject(member.obj)
+				}
+				if member.notif != nil {
+					v.addNotification(member.notif)
+				}
+			}
+		}
+
+		for i := range sm.ObjectVariations {
+			ov := &sm.ObjectVariations[i]
+			obj := mod.Object(ov.Object)
+			if obj == nil {
+				v.Unresolved = append(v.Unresolved, mod.name+"::"+ov.Object)
+				continue
+			}
+			v.addObject(obj).apply(ov, mod)
+		}
+		for i := range sm.NotificationVariations {
+			nv := &sm.NotificationVariations[i]
+			n := mod.Notification(nv.Notification)
+			if n == nil {
+				v.Unresolved = append(v.Unresolved, mod.name+"::"+nv.Notification)
+				continue
+			}
+			an := v.addNotification(n)
+			an.Variation = nv
+			if nv.Access != nil {
+				an.Access = *nv.Access
+			}
+		}
+	}
+	slices.SortFunc(v.Objects, func(a, b *AgentObject) int { return a.Object.OID().Compare(b.Object.OID()) })
+	slices.SortFunc(v.Notifications, func(a, b *AgentNotification) int {
+		return a.Notification.OID().Compare(b.Notification.OID())
+	})
+	return v
+}
+
+func (v *AgentView) addObject(obj *Object) *AgentObject {
+	if o := v.objects[obj]; o != nil {
+		return o
+	}
+	syntax := SyntaxConstraints{
+		Type:   obj.typ,
+		Sizes:  obj.EffectiveSizes(),
+		Ranges: obj.EffectiveRanges(),
+		Enums:  obj.EffectiveEnums(),
+		Bits:   obj.EffectiveBits(),
+	}
+	o := &AgentObject{
+		Object:      obj,
+		Access:      obj.access,
+		Syntax:      syntax,
+		WriteSyntax: syntax,
+	}
+	if obj.defVal != nil {
+		o.DefaultValue = *obj.defVal
+	}
+	v.objects[obj] = o
+	v.Objects = append(v.Objects, o)
+	return o
+}
+
+func (v *AgentView) addNotification(n *Notification) *AgentNotification {
+	if an := v.notifications[n]; an != nil {
+		return an
+	}
+	an := &AgentNotification{Notification: n, Access: AccessAccessibleForNotify}
+	v.notifications[n] = an
+	v.Notifications = append(v.Notifications, an)
+	return an
+}
+
+// apply overlays one VARIATION. CREATION-REQUIRES names are resolved
+// in mod, the module the SUPPORTS clause covers.
+func (o *AgentObject) apply(ov *ObjectVariation, mod *Module) {
+	o.Variation = ov
+	if ov.Access != nil {
+		o.Access = *ov.Access
+	}
+	if ov.Syntax != nil {
+		o.Syntax = overlaySyntax(o.Syntax, ov.Syntax)
+		o.WriteSyntax = o.Syntax
+	}
+	if ov.WriteSyntax != nil {
+		o.WriteSyntax = overlaySyntax(o.WriteSyntax, ov.WriteSyntax)
+	}
+	if !ov.DefVal.IsZero() {
+		o.DefaultValue = ov.DefVal
+	}
+	for _, name := range ov.CreationRequires {
+		if col := mod.Object(name); col != nil {
+			o.CreationRequires = append(o.CreationRequires, col)
+		}
+	}
+}
+
+// overlaySyntax replaces the constraints in base with those a VARIATION
+// specifies. A refined type contributes its own effective constraints
+// where the clause gives none inline.
+func overlaySyntax(base SyntaxConstraints, sc *SyntaxConstraints) SyntaxConstraints {
+	out := base
+	if sc.Type != nil {
+		out.Type = sc.Type
+		out.Sizes = pick(sc.Type.EffectiveSizes(), out.Sizes)
+		out.Ranges = pick(sc.Type.EffectiveRanges(), out.Ranges)
+		out.Enums = pick(sc.Type.EffectiveEnums(), out.Enums)
+		out.Bits = pick(sc.Type.EffectiveBits(), out.Bits)
+	}
+	out.Sizes = pick(sc.Sizes, out.Sizes)
+	out.Ranges = pick(sc.Ranges, out.Ranges)
+	out.Enums = pick(sc.Enums, out.Enums)
+	out.Bits = pick(sc.Bits, out.Bits)
+	return out
+}
+
+func pick[T any](override, base []T) []T {
+	if len(override) > 0 {
+		return slices.Clone(override)
+	}
+	return base
+}
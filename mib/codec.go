@@ -0,0 +1,365 @@
+package mib
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCodecValue is returned by codecs when a value does not have the
+// shape required by the textual convention.
+var ErrCodecValue = errors.New("value does not match textual convention")
+
+// TCKey identifies a textual convention by its defining module and name.
+type TCKey struct {
+	Module string
+	Name   string
+}
+
+// String returns the key as "MODULE::Name".
+func (k TCKey) String() string { return k.Module + "::" + k.Name }
+
+// CodecContext supplies the object being decoded and, for conventions
+// interpreted through a companion column, access to the other values of
+// the same row instance.
+type CodecContext struct {
+	Object *Object
+	// Sibling returns the value of another column in the same row
+	// instance. It may be nil when no row context is available.
+	Sibling func(col *Object) (any, bool)
+}
+
+// Codec decodes a raw SNMP value into a semantic Go value.
+type Codec interface {
+	Decode(ctx CodecContext, v any) (any, error)
+}
+
+// CodecFunc adapts an ordinary function to the [Codec] interface.
+type CodecFunc func(ctx CodecContext, v any) (any, error)
+
+// Decode calls f(ctx, v).
+func (f CodecFunc) Decode(ctx CodecContext, v any) (any, error) { return f(ctx, v) }
+
+// CodecRegistry maps textual conventions to codecs. Lookups walk the
+// type chain, so a codec registered for a TC also applies to types
+// derived from it. A CodecRegistry is safe for concurrent use.
+type CodecRegistry struct {
+	mu     sync.RWMutex
+	codecs map[TCKey]Codec
+}
+
+// NewCodecRegistry returns an empty registry.
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{codecs: make(map[TCKey]Codec)}
+}
+
+// DefaultCodecs returns a new registry preloaded with codecs for
+// well-known textual conventions:
+//
+//	SNMPv2-TC::DateAndTime         time.Time
+//	SNMPv2-TC::MacAddress          net.HardwareAddr
+//	SNMPv2-TC::PhysAddress         net.HardwareAddr
+//	SNMPv2-TC::TruthValue          bool
+//	SNMPv2-TC::RowStatus           RowStatus
+//	SNMPv2-TC::StorageType         StorageType
+//	SNMPv2-TC::TimeStamp           time.Duration
+//	SNMPv2-TC::TimeInterval        time.Duration
+//	IPV6-TC::Ipv6Address           netip.Addr
+//	INET-ADDRESS-MIB::InetAddress  by InetAddressType, as below
+//	INET-ADDRESS-MIB::InetAddressIPv4, IPv6, IPv6z  netip.Addr
+//	INET-ADDRESS-MIB::InetAddressIPv4z  string, "a.b.c.d%zone"
+//	INET-ADDRESS-MIB::InetAddressDNS  string
+//
+// The returned registry is independent; registering vendor codecs on it
+// does not affect other callers.
+func DefaultCodecs() *CodecRegistry {
+	r := NewCodecRegistry()
+	r.Register("SNMPv2-TC", "DateAndTime", CodecFunc(decodeDateAndTime))
+	r.Register("SNMPv2-TC", "MacAddress", CodecFunc(decodeMacAddress))
+	r.Register("SNMPv2-TC", "PhysAddress", CodecFunc(decodePhysAddress))
+	r.Register("SNMPv2-TC", "TruthValue", CodecFunc(decodeTruthValue))
+	r.Register("SNMPv2-TC", "RowStatus", CodecFunc(decodeRowStatus))
+	r.Register("SNMPv2-TC", "StorageType", CodecFunc(decodeStorageType))
+	r.Register("SNMPv2-TC", "TimeStamp", CodecFunc(decodeCentiseconds))
+	r.Register("SNMPv2-TC", "TimeInterval", CodecFunc(decodeCentiseconds))
+	r.Register("IPV6-TC", "Ipv6Address", CodecFunc(decodeIpv6Address))
+	r.Register("INET-ADDRESS-MIB", "InetAddress", CodecFunc(decodeInetAddress))
+	r.Register("INET-ADDRESS-MIB", "InetAddressIPv4", inetAddressCodec(inetTypeIPv4))
+	r.Register("INET-ADDRESS-MIB", "InetAddressIPv6", inetAddressCodec(inetTypeIPv6))
+	r.Register("INET-ADDRESS-MIB", "InetAddressIPv4z", inetAddressCodec(inetTypeIPv4z))
+	r.Register("INET-ADDRESS-MIB", "InetAddressIPv6z", inetAddressCodec(inetTypeIPv6z))
+	r.Register("INET-ADDRESS-MIB", "InetAddressDNS", inetAddressCodec(inetTypeDNS))
+	return r
+}
+
+// Register associates a codec with the textual convention module::name,
+// replacing any previous registration.
+func (r *CodecRegistry) Register(module, name string, c Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codecs[TCKey{Module: module, Name: name}] = c
+}
+
+// Lookup returns the codec for t, walking [Type.Parent] until a type
+// with a registered codec is found.
+func (r *CodecRegistry) Lookup(t *Type) (Codec, TCKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for current := t; current != nil; current = current.parent {
+		if current.module == nil || current.name == "" {
+			continue
+		}
+		key := TCKey{Module: current.module.name, Name: current.name}
+		if c, ok := r.codecs[key]; ok {
+			return c, key, true
+		}
+	}
+	return nil, TCKey{}, false
+}
+
+// Decode decodes v for obj using the codec registered for the object's
+// type chain. If no codec applies, v is returned unchanged. sibling may
+// be nil; see [CodecContext].
+func (r *CodecRegistry) Decode(obj *Object, v any, sibling func(col *Object) (any, bool)) (any, error) {
+	if obj == nil {
+		return v, nil
+	}
+	c, _, ok := r.Lookup(obj.typ)
+	if !ok {
+		return v, nil
+	}
+	return c.Decode(CodecContext{Object: obj, Sibling: sibling}, v)
+}
+
+// RowStatus is the SNMPv2-TC RowStatus textual convention.
+type RowStatus int
+
+const (
+	RowStatusActive        RowStatus = 1
+	RowStatusNotInService  RowStatus = 2
+	RowStatusNotReady      RowStatus = 3
+	RowStatusCreateAndGo   RowStatus = 4
+	RowStatusCreateAndWait RowStatus = 5
+	RowStatusDestroy       RowStatus = 6
+)
+
+// String returns the RowStatus enumeration label.
+func (s RowStatus) String() string {
+	switch s {
+	case RowStatusActive:
+		return "active"
+	case RowStatusNotInService:
+		return "notInService"
+	case RowStatusNotReady:
+		return "notReady"
+	case RowStatusCreateAndGo:
+		return "createAndGo"
+	case RowStatusCreateAndWait:
+		return "createAndWait"
+	case RowStatusDestroy:
+		return "destroy"
+	default:
+		return "RowStatus(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// StorageType is the SNMPv2-TC StorageType textual convention.
+type StorageType int
+
+const (
+	StorageOther       StorageType = 1
+	StorageVolatile    StorageType = 2
+	StorageNonVolatile StorageType = 3
+	StoragePermanent   StorageType = 4
+	StorageReadOnly    StorageType = 5
+)
+
+// String returns the StorageType enumeration label.
+func (s StorageType) String() string {
+	switch s {
+	case StorageOther:
+		return "other"
+	case StorageVolatile:
+		return "volatile"
+	case StorageNonVolatile:
+		return "nonVolatile"
+	case StoragePermanent:
+		return "permanent"
+	case StorageReadOnly:
+		return "readOnly"
+	default:
+		return "StorageType(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+func codecError(tc string, v any) error {
+	return fmt.Errorf("%s: %w: %v", tc, ErrCodecValue, v)
+}
+
+func decodeDateAndTime(_ CodecContext, v any) (any, error) {
+	b, ok := valueToBytes(v)
+	if !ok || (len(b) != 8 && len(b) != 11) {
+		return nil, codecError("DateAndTime", v)
+	}
+	// RFC 2579 ranges; second 60 is a leap second.
+	if b[2] < 1 || b[2] > 12 || b[3] < 1 || b[3] > 31 ||
+		b[4] > 23 || b[5] > 59 || b[6] > 60 || b[7] > 9 {
+		return nil, codecError("DateAndTime", v)
+	}
+	loc := time.UTC
+	if len(b) == 11 {
+		if (b[8] != '+' && b[8] != '-') || b[9] > 13 || b[10] > 59 {
+			return nil, codecError("DateAndTime", v)
+		}
+		offset := int(b[9])*3600 + int(b[10])*60
+		if b[8] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	year := int(binary.BigEndian.Uint16(b[0:2]))
+	t := time.Date(year, time.Month(b[2]), int(b[3]),
+		int(b[4]), int(b[5]), int(b[6]), int(b[7])*int(100*time.Millisecond), loc)
+	if t.Day() != int(b[3]) && b[6] != 60 {
+		return nil, codecError("DateAndTime", v) // e.g. February 30
+	}
+	return t, nil
+}
+
+func decodeMacAddress(_ CodecContext, v any) (any, error) {
+	b, ok := valueToBytes(v)
+	if !ok || len(b) != 6 {
+		return nil, codecError("MacAddress", v)
+	}
+	return net.HardwareAddr(b), nil
+}
+
+func decodePhysAddress(_ CodecContext, v any) (any, error) {
+	b, ok := valueToBytes(v)
+	if !ok {
+		return nil, codecError("PhysAddress", v)
+	}
+	return net.HardwareAddr(b), nil
+}
+
+func decodeTruthValue(_ CodecContext, v any) (any, error) {
+	n, ok := Int64Value(v)
+	switch {
+	case ok && n == 1:
+		return true, nil
+	case ok && n == 2:
+		return false, nil
+	}
+	return nil, codecError("TruthValue", v)
+}
+
+func decodeRowStatus(_ CodecContext, v any) (any, error) {
+	n, ok := Int64Value(v)
+	if !ok || n < int64(RowStatusActive) || n > int64(RowStatusDestroy) {
+		return nil, codecError("RowStatus", v)
+	}
+	return RowStatus(n), nil
+}
+
+func decodeStorageType(_ CodecContext, v any) (any, error) {
+	n, ok := Int64Value(v)
+	if !ok || n < int64(StorageOther) || n > int64(StorageReadOnly) {
+		return nil, codecError("StorageType", v)
+	}
+	return StorageType(n), nil
+}
+
+// decodeCentiseconds handles TimeStamp and TimeInterval, both of which
+// count hundredths of a second.
+func decodeCentiseconds(_ CodecContext, v any) (any, error) {
+	n, ok := Int64Value(v)
+	if !ok || n < 0 {
+		return nil, codecError("TimeTicks", v)
+	}
+	return time.Duration(n) * 10 * time.Millisecond, nil
+}
+
+func decodeIpv6Address(_ CodecContext, v any) (any, error) {
+	b, ok := valueToBytes(v)
+	if !ok || len(b) != 16 {
+		return nil, codecError("Ipv6Address", v)
+	}
+	return netip.AddrFrom16([16]byte(b)), nil
+}
+
+// InetAddressType values from RFC 4001.
+const (
+	inetTypeUnknown = 0
+	inetTypeIPv4    = 1
+	inetTypeIPv6    = 2
+	inetTypeIPv4z   = 3
+	inetTypeIPv6z   = 4
+	inetTypeDNS     = 16
+)
+
+func inetAddressCodec(addrType int64) Codec {
+	return CodecFunc(func(_ CodecContext, v any) (any, error) {
+		return decodeInetAddressAs(addrType, v)
+	})
+}
+
+// decodeInetAddress interprets an InetAddress through its InetAddressType
+// discriminator.
+func decodeInetAddress(ctx CodecContext, v any) (any, error) {
+	if ctx.Sibling == nil {
+		return nil, fmt.Errorf("InetAddress: no row context for %s", ctx.Object.Name())
+	}
+	typeCol := ctx.Object.Discriminator()
+	if typeCol == nil {
+		return nil, fmt.Errorf("InetAddress: no InetAddressType column for %s", ctx.Object.Name())
+	}
+	tv, ok := ctx.Sibling(typeCol)
+	if !ok {
+		return nil, fmt.Errorf("InetAddress: %s value not available", typeCol.Name())
+	}
+	addrType, ok := Int64Value(tv)
+	if !ok {
+		return nil, codecError("InetAddressType", tv)
+	}
+	return decodeInetAddressAs(addrType, v)
+}
+
+func decodeInetAddressAs(addrType int64, v any) (any, error) {
+	b, ok := valueToBytes(v)
+	if !ok {
+		return nil, codecError("InetAddress", v)
+	}
+	switch addrType {
+	case inetTypeUnknown:
+		if len(b) == 0 {
+			return netip.Addr{}, nil
+		}
+	case inetTypeIPv4:
+		if len(b) == 4 {
+			return netip.AddrFrom4([4]byte(b)), nil
+		}
+	case inetTypeIPv6:
+		if len(b) == 16 {
+			return netip.AddrFrom16([16]byte(b)), nil
+		}
+	case inetTypeIPv4z:
+		if len(b) == 8 {
+			// netip.Addr cannot carry a zone on IPv4 addresses.
+			zone := binary.BigEndian.Uint32(b[4:])
+			return netip.AddrFrom4([4]byte(b[:4])).String() + "%" + strconv.FormatUint(uint64(zone), 10), nil
+		}
+	case inetTypeIPv6z:
+		if len(b) == 20 {
+			zone := strconv.FormatUint(uint64(binary.BigEndian.Uint32(b[16:])), 10)
+			return netip.AddrFrom16([16]byte(b[:16])).WithZone(zone), nil
+		}
+	case inetTypeDNS:
+		return string(b), nil
+	}
+	return nil, fmt.Errorf("InetAddress: %w: type %d with %d octets", ErrCodecValue, addrType, len(b))
+}
@@ -0,0 +1,266 @@
+package mib
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+// tcType returns a textual convention type defined in module, derived
+// from an OCTET STRING or INTEGER base.
+func tcType(module, name string, base BaseType) *Type {
+	return &Type{
+		name:   name,
+		module: &Module{name: module},
+		base:   base,
+		isTC:   true,
+	}
+}
+
+func TestDefaultCodecs(t *testing.T) {
+	r := DefaultCodecs()
+	obj := func(module, name string, base BaseType) *Object {
+		return &Object{name: "x", typ: tcType(module, name, base)}
+	}
+
+	tests := []struct {
+		name  string
+		obj   *Object
+		value any
+		want  any
+	}{
+		{
+			name:  "DateAndTime UTC",
+			obj:   obj("SNMPv2-TC", "DateAndTime", BaseOctetString),
+			value: []byte{0x07, 0xE8, 3, 5, 7, 8, 9, 4},
+			want:  time.Date(2024, 3, 5, 7, 8, 9, 400_000_000, time.UTC),
+		},
+		{
+			name:  "MacAddress",
+			obj:   obj("SNMPv2-TC", "MacAddress", BaseOctetString),
+			value: []byte{0x00, 0x1A, 0x2B, 0x3C, 0x4D, 0x5E},
+			want:  "00:1a:2b:3c:4d:5e",
+		},
+		{
+			name:  "PhysAddress",
+			obj:   obj("SNMPv2-TC", "PhysAddress", BaseOctetString),
+			value: []byte{0xAA, 0xBB},
+			want:  "aa:bb",
+		},
+		{
+			name:  "TruthValue true",
+			obj:   obj("SNMPv2-TC", "TruthValue", BaseInteger32),
+			value: 1,
+			want:  true,
+		},
+		{
+			name:  "TruthValue false",
+			obj:   obj("SNMPv2-TC", "TruthValue", BaseInteger32),
+			value: int64(2),
+			want:  false,
+		},
+		{
+			name:  "RowStatus",
+			obj:   obj("SNMPv2-TC", "RowStatus", BaseInteger32),
+			value: 5,
+			want:  RowStatusCreateAndWait,
+		},
+		{
+			name:  "StorageType",
+			obj:   obj("SNMPv2-TC", "StorageType", BaseInteger32),
+			value: 3,
+			want:  StorageNonVolatile,
+		},
+		{
+			name:  "TimeStamp",
+			obj:   obj("SNMPv2-TC", "TimeStamp", BaseTimeTicks),
+			value: uint32(12345),
+			want:  123450 * time.Millisecond,
+		},
+		{
+			name:  "Ipv6Address",
+			obj:   obj("IPV6-TC", "Ipv6Address", BaseOctetString),
+			value: netip.MustParseAddr("2001:db8::1").AsSlice(),
+			want:  netip.MustParseAddr("2001:db8::1"),
+		},
+		{
+			name:  "InetAddressIPv4",
+			obj:   obj("INET-ADDRESS-MIB", "InetAddressIPv4", BaseOctetString),
+			value: []byte{192, 0, 2, 1},
+			want:  netip.MustParseAddr("192.0.2.1"),
+		},
+		{
+			name:  "InetAddressIPv6z",
+			obj:   obj("INET-ADDRESS-MIB", "InetAddressIPv6z", BaseOctetString),
+			value: append(netip.MustParseAddr("fe80::1").AsSlice(), 0, 0, 0, 4),
+			want:  netip.MustParseAddr("fe80::1%4"),
+		},
+		{
+			name:  "InetAddressIPv4z",
+			obj:   obj("INET-ADDRESS-MIB", "InetAddressIPv4z", BaseOctetString),
+			value: []byte{192, 0, 2, 1, 0, 0, 0, 9},
+			want:  "192.0.2.1%9",
+		},
+		{
+			name:  "InetAddressDNS",
+			obj:   obj("INET-ADDRESS-MIB", "InetAddressDNS", BaseOctetString),
+			value: []byte("example.com"),
+			want:  "example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Decode(tt.obj, tt.value, nil)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			switch want := tt.want.(type) {
+			case time.Time:
+				if g, ok := got.(time.Time); !ok || !g.Equal(want) {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			case string:
+				s := ""
+				switch g := got.(type) {
+				case string:
+					s = g
+				case net.HardwareAddr:
+					s = g.String()
+				}
+				if s != want {
+					t.Errorf("got %v, want %s", got, want)
+				}
+			default:
+				if got != tt.want {
+					t.Errorf("got %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCodecPassThrough(t *testing.T) {
+	obj := &Object{typ: &Type{name: "OCTET STRING", base: BaseOctetString}}
+	got, err := DefaultCodecs().Decode(obj, "raw", nil)
+	if err != nil || got != "raw" {
+		t.Errorf("Decode = %v, %v; want value unchanged", got, err)
+	}
+}
+
+func TestDateAndTimeOffset(t *testing.T) {
+	obj := &Object{typ: tcType("SNMPv2-TC", "DateAndTime", BaseOctetString)}
+	got, err := DefaultCodecs().Decode(obj, []byte{0x07, 0xE8, 3, 5, 7, 8, 9, 0, '-', 5, 30}, nil)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	ts := got.(time.Time)
+	if _, off := ts.Zone(); off != -(5*3600 + 30*60) {
+		t.Errorf("offset = %d", off)
+	}
+	if want := time.Date(2024, 3, 5, 12, 38, 9, 0, time.UTC); !ts.Equal(want) {
+		t.Errorf("got %v, want %v", ts.UTC(), want)
+	}
+}
+
+func TestCodecInvalidValue(t *testing.T) {
+	r := DefaultCodecs()
+	tests := []struct {
+		name  string
+		typ   *Type
+		value any
+	}{
+		{"DateAndTime short", tcType("SNMPv2-TC", "DateAndTime", BaseOctetString), []byte{1, 2, 3}},
+		{"DateAndTime month 13", tcType("SNMPv2-TC", "DateAndTime", BaseOctetString), []byte{0x07, 0xe9, 13, 1, 0, 0, 0, 0}},
+		{"DateAndTime February 30", tcType("SNMPv2-TC", "DateAndTime", BaseOctetString), []byte{0x07, 0xe9, 2, 30, 0, 0, 0, 0}},
+		{"DateAndTime hour 24", tcType("SNMPv2-TC", "DateAndTime", BaseOctetString), []byte{0x07, 0xe9, 1, 1, 24, 0, 0, 0}},
+		{"DateAndTime deci-seconds 10", tcType("SNMPv2-TC", "DateAndTime", BaseOctetString), []byte{0x07, 0xe9, 1, 1, 0, 0, 0, 10}},
+		{"DateAndTime offset minutes 60", tcType("SNMPv2-TC", "DateAndTime", BaseOctetString), []byte{0x07, 0xe9, 1, 1, 0, 0, 0, 0, '+', 1, 60}},
+		{"MacAddress short", tcType("SNMPv2-TC", "MacAddress", BaseOctetString), []byte{1, 2}},
+		{"TruthValue 3", tcType("SNMPv2-TC", "TruthValue", BaseInteger32), 3},
+		{"RowStatus 0", tcType("SNMPv2-TC", "RowStatus", BaseInteger32), 0},
+		{"Ipv6Address short", tcType("IPV6-TC", "Ipv6Address", BaseOctetString), []byte{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Decode(&Object{typ: tt.typ}, tt.value, nil)
+			if !errors.Is(err, ErrCodecValue) {
+				t.Errorf("err = %v, want ErrCodecValue", err)
+			}
+		})
+	}
+}
+
+func TestCodecLookupWalksParent(t *testing.T) {
+	truth := tcType("SNMPv2-TC", "TruthValue", BaseInteger32)
+	derived := &Type{name: "VendorBool", module: &Module{name: "VENDOR-MIB"}, parent: truth}
+
+	r := DefaultCodecs()
+	_, key, ok := r.Lookup(derived)
+	if !ok || key != (TCKey{"SNMPv2-TC", "TruthValue"}) {
+		t.Fatalf("Lookup = %v, %v", key, ok)
+	}
+
+	r.Register("VENDOR-MIB", "VendorBool", CodecFunc(func(_ CodecContext, v any) (any, error) {
+		return "vendor", nil
+	}))
+	got, err := r.Decode(&Object{typ: derived}, 1, nil)
+	if err != nil || got != "vendor" {
+		t.Errorf("Decode = %v, %v; want vendor codec", got, err)
+	}
+	if _, _, ok := DefaultCodecs().Lookup(&Type{name: "VendorBool", module: &Module{name: "VENDOR-MIB"}}); ok {
+		t.Error("registration leaked into a fresh DefaultCodecs registry")
+	}
+}
+
+func TestInetAddressSibling(t *testing.T) {
+	_, _, col1, col2 := buildTableTree()
+	col1.name = "fooAddrType"
+	col1.typ = tcType("INET-ADDRESS-MIB", "InetAddressType", BaseInteger32)
+	col2.name = "fooAddr"
+	col2.typ = tcType("INET-ADDRESS-MIB", "InetAddress", BaseOctetString)
+	col2.discrim = col1
+
+	r := DefaultCodecs()
+	row := map[*Object]any{col1: 2}
+	sibling := func(col *Object) (any, bool) {
+		v, ok := row[col]
+		return v, ok
+	}
+
+	got, err := r.Decode(col2, netip.MustParseAddr("2001:db8::7").AsSlice(), sibling)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got != netip.MustParseAddr("2001:db8::7") {
+		t.Errorf("got %v", got)
+	}
+
+	row[col1] = 16
+	got, err = r.Decode(col2, []byte("host.example"), sibling)
+	if err != nil || got != "host.example" {
+		t.Errorf("dns: got %v, %v", got, err)
+	}
+
+	row[col1] = 1
+	if _, err := r.Decode(col2, []byte{1, 2}, sibling); !errors.Is(err, ErrCodecValue) {
+		t.Errorf("length mismatch: err = %v", err)
+	}
+
+	if _, err := r.Decode(col2, []byte{1, 2, 3, 4}, nil); err == nil {
+		t.Error("expected error without row context")
+	}
+}
+
+func TestRowStatusString(t *testing.T) {
+	if got := RowStatusNotInService.String(); got != "notInService" {
+		t.Errorf("got %q", got)
+	}
+	if got := RowStatus(9).String(); got != "RowStatus(9)" {
+		t.Errorf("got %q", got)
+	}
+	if got := StorageReadOnly.String(); got != "readOnly" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -11,6 +11,7 @@ type Compliance struct {
 	desc    string
 	ref     string
 	modules []ComplianceModule
+	scopes  []*Module // resolved module for each entry of modules, or nil
 }
 
 // newCompliance returns a Compliance initialized with the given name.
@@ -61,3 +62,165 @@ func (c *Compliance) setStatus(s Status)                    { c.status = s }
 func (c *Compliance) setDescription(d string)               { c.desc = d }
 func (c *Compliance) setReference(r string)                 { c.ref = r }
 func (c *Compliance) setModules(modules []ComplianceModule) { c.modules = modules }
+func (c *Compliance) setScopes(scopes []*Module)            { c.scopes = scopes }
+
+// Requirement is one object or notification required by a compliance
+// statement, with any OBJECT refinement applied.
+//
+// Syntax starts from the object's effective constraints and is replaced
+// by the refinement's SYNTAX; WriteSyntax is the WRITE-SYNTAX refinement,
+// or Syntax when none is given. MinAccess is the MIN-ACCESS refinement,
+// or the object's MAX-ACCESS when none is given. Both are zero for
+// notifications.
+type Requirement struct {
+	Node        *Node
+	Group       *Group // the group that requires the node, nil for a bare OBJECT clause
+	Conditional bool   // required by a GROUP clause rather than MANDATORY-GROUPS
+	Description string // the GROUP clause description, for conditional items
+	Syntax      SyntaxConstraints
+	WriteSyntax SyntaxConstraints
+	MinAccess   Access
+	Refinement  *ComplianceObject // the OBJECT clause, or nil
+}
+
+// Requirements flattens the compliance statement into the objects and
+// notifications it requires. Group and object names are resolved in the
+// scope of each MODULE clause. The members of the mandatory groups of
+// every clause come first, then those of the GROUP clauses, then nodes
+// named only by OBJECT clauses, each in clause order. A node required by
+// several groups is listed once, as mandatory if any mandatory group
+// includes it. References that do not resolve are skipped.
+func (c *Compliance) Requirements() []Requirement {
+	var reqs []Requirement
+	seen := make(map[*Node]int)
+	add := func(nd *Node, g *Group, conditional bool, desc string) {
+		if _, ok := seen[nd]; ok {
+			return
+		}
+		r := Requirement{Node: nd, Group: g, Conditional: conditional, Description: desc}
+		if obj := nd.obj; obj != nil {
+			r.Syntax = SyntaxConstraints{
+				Type:   obj.typ,
+				Sizes:  obj.EffectiveSizes(),
+				Ranges: obj.EffectiveRanges(),
+				Enums:  obj.EffectiveEnums(),
+				Bits:   obj.EffectiveBits(),
+			}
+			r.WriteSyntax = r.Syntax
+			r.MinAccess = obj.access
+		}
+		seen[nd] = len(reqs)
+		reqs = append(reqs, r)
+	}
+
+	res := c.resolve()
+	for _, mandatory := range []bool{true, false} {
+		for _, rm := range res.modules {
+			for _, rg := range rm.groups {
+				if rg.mandatory != mandatory {
+					continue
+				}
+				for _, member := range rg.group.members {
+					add(member, rg.group, !rg.mandatory, rg.description)
+				}
+			}
+		}
+	}
+	for _, rm := range res.modules {
+		for _, ro := range rm.objects {
+			co := ro.clause
+			add(ro.object.node, nil, true, "")
+			r := &reqs[seen[ro.object.node]]
+			r.Refinement = co
+			if co.Syntax != nil {
+				r.Syntax = overlaySyntax(r.Syntax, co.Syntax)
+				r.WriteSyntax = r.Syntax
+			}
+			if co.WriteSyntax != nil {
+				r.WriteSyntax = overlaySyntax(r.WriteSyntax, co.WriteSyntax)
+			}
+			if co.MinAccess != nil {
+				r.MinAccess = *co.MinAccess
+			}
+		}
+	}
+	return reqs
+}
+
+// resolvedCompliance is a compliance statement with its group and
+// object references resolved in the scope of each MODULE clause.
+type resolvedCompliance struct {
+	modules []resolvedComplianceModule
+	// unresolved lists the module, group and object references that do
+	// not resolve, as MODULE or MODULE::name.
+	unresolved []string
+}
+
+type resolvedComplianceModule struct {
+	groups  []resolvedComplianceGroup // mandatory groups, then GROUP clauses
+	objects []resolvedComplianceObject
+}
+
+type resolvedComplianceGroup struct {
+	group       *Group
+	mandatory   bool
+	description string // the GROUP clause description
+}
+
+type resolvedComplianceObject struct {
+	object *Object
+	clause *ComplianceObject
+}
+
+// scope returns the module the i'th MODULE clause refers to, or nil if
+// it is not loaded.
+func (c *Compliance) scope(i int) *Module {
+	if c.modules[i].ModuleName == "" {
+		return c.module
+	}
+	if i < len(c.scopes) {
+		return c.scopes[i]
+	}
+	return nil
+}
+
+// resolve resolves the references of every MODULE clause. It is the one
+// place compliance references are scoped; Requirements, CheckCompliance
+// and the cross-reference index build on it.
+func (c *Compliance) resolve() resolvedCompliance {
+	var res resolvedCompliance
+	for i := range c.modules {
+		cm := &c.modules[i]
+		scope := c.scope(i)
+		if scope == nil {
+			res.unresolved = append(res.unresolved, cm.ModuleName)
+			continue
+		}
+		var rm resolvedComplianceModule
+		addGroup := func(name string, mandatory bool, desc string) {
+			g := scope.Group(name)
+			if g == nil {
+				res.unresolved = append(res.unresolved, scope.Name()+"::"+name)
+				return
+			}
+			rm.groups = append(rm.groups, resolvedComplianceGroup{group: g, mandatory: mandatory, description: desc})
+		}
+		for _, name := range cm.MandatoryGroups {
+			addGroup(name, true, "")
+		}
+		for _, cg := range cm.Groups {
+			addGroup(cg.Group, false, cg.Description)
+		}
+		for j := range cm.Objects {
+			co := &cm.Objects[j]
+			obj := scope.Object(co.Object)
+			if obj == nil {
+				res.unresolved = append(res.unresolved, scope.Name()+"::"+co.Object)
+				continue
+			}
+			rm.objects = append(rm.objects, resolvedComplianceObject{object: obj, clause: co})
+		}
+		res.modules = append(res.modules, rm)
+	}
+	return res
+}
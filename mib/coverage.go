@@ -0,0 +1,244 @@
+package mib
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// GroupState summarizes how much of a group an agent implements.
+type GroupState int
+
+const (
+	// GroupMissing means none of the group's readable objects were seen.
+	GroupMissing GroupState = iota
+	// GroupPartial means some, but not all, readable objects were seen.
+	GroupPartial
+	// GroupImplemented means every readable object was seen.
+	GroupImplemented
+	// GroupUnverifiable means the group has no objects a walk can show,
+	// such as a NOTIFICATION-GROUP.
+	GroupUnverifiable
+)
+
+// String returns the state in lower case, e.g. "implemented".
+func (s GroupState) String() string {
+	switch s {
+	case GroupMissing:
+		return "missing"
+	case GroupPartial:
+		return "partial"
+	case GroupImplemented:
+		return "implemented"
+	case GroupUnverifiable:
+		return "unverifiable"
+	}
+	return fmt.Sprintf("GroupState(%d)", int(s))
+}
+
+// GroupCoverage reports which objects of one compliance group an agent
+// returned. Objects whose access, after any MIN-ACCESS refinement, does
+// not make them readable are not expected and appear in neither list.
+type GroupCoverage struct {
+	Group     *Group
+	Mandatory bool // listed in MANDATORY-GROUPS rather than a GROUP clause
+	Present   []*Object
+	Missing   []*Object
+}
+
+// State classifies the coverage of the group.
+func (g GroupCoverage) State() GroupState {
+	switch {
+	case len(g.Present) == 0 && len(g.Missing) == 0:
+		return GroupUnverifiable
+	case len(g.Missing) == 0:
+		return GroupImplemented
+	case len(g.Present) == 0:
+		return GroupMissing
+	}
+	return GroupPartial
+}
+
+// ComplianceViolation is a returned value that falls outside the SYNTAX
+// refinement of an OBJECT clause.
+type ComplianceViolation struct {
+	Object *Object
+	OID    OID // the instance OID
+	Value  any
+	Reason string
+}
+
+// ComplianceReport is the result of [Mib.CheckCompliance].
+type ComplianceReport struct {
+	Compliance *Compliance
+	Groups     []GroupCoverage
+	Violations []ComplianceViolation
+	// Unresolved lists group and object references in the compliance
+	// statement that do not resolve in the loaded modules.
+	Unresolved []string
+}
+
+// Compliant reports whether every mandatory group is implemented and
+// no returned value violates a refinement. Conditional groups do not
+// affect the result, since their conditions cannot be judged from a walk.
+func (r *ComplianceReport) Compliant() bool {
+	for _, g := range r.Groups {
+		if g.Mandatory && g.State() != GroupImplemented && g.State() != GroupUnverifiable {
+			return false
+		}
+	}
+	return len(r.Violations) == 0
+}
+
+// CheckCompliance evaluates a device walk against a MODULE-COMPLIANCE
+// statement. For each MANDATORY-GROUPS entry and GROUP clause it reports
+// which readable member objects have at least one instance in walk, and
+// it checks each returned value against the OBJECT clause SYNTAX
+// refinements (ranges, sizes, enumerations and named bits). A MIN-ACCESS
+// of not-accessible or accessible-for-notify exempts an object from
+// being expected in the walk. WRITE-SYNTAX cannot be judged from a walk
+// and is ignored, as are nil values.
+func (m *Mib) CheckCompliance(c *Compliance, walk []VarBind) *ComplianceReport {
+	res := c.resolve()
+	r := &ComplianceReport{Compliance: c, Unresolved: res.unresolved}
+
+	reqs := c.Requirements()
+	required := make(map[*Node]*Requirement, len(reqs))
+	for i := range reqs {
+		required[reqs[i].Node] = &reqs[i]
+	}
+
+	oids := make([]OID, len(walk))
+	for i, vb := range walk {
+		oids[i] = vb.OID
+	}
+	slices.SortFunc(oids, OID.Compare)
+
+	for _, rm := range res.modules {
+		for _, rg := range rm.groups {
+			cov := GroupCoverage{Group: rg.group, Mandatory: rg.mandatory}
+			for _, member := range rg.group.members {
+				obj := member.Object()
+				if obj == nil || !readable(required[member].MinAccess) {
+					continue
+				}
+				if hasInstance(oids, obj.OID()) {
+					cov.Present = append(cov.Present, obj)
+				} else {
+					cov.Missing = append(cov.Missing, obj)
+				}
+			}
+			r.Groups = append(r.Groups, cov)
+		}
+	}
+
+	for _, vb := range walk {
+		nd := m.LongestPrefixByOID(vb.OID)
+		for nd != nil && nd.obj == nil {
+			nd = nd.parent
+		}
+		if nd == nil {
+			continue
+		}
+		req := required[nd]
+		if req == nil || req.Refinement == nil || req.Refinement.Syntax == nil {
+			continue
+		}
+		if reason := checkSyntax(req.Refinement.Syntax, vb.Value); reason != "" {
+			r.Violations = append(r.Violations, ComplianceViolation{
+				Object: nd.obj,
+				OID:    vb.OID,
+				Value:  vb.Value,
+				Reason: reason,
+			})
+		}
+	}
+	return r
+}
+
+// readable reports whether an object with the given access appears in
+// a walk.
+func readable(a Access) bool {
+	switch a {
+	case AccessReadOnly, AccessReadWrite, AccessReadCreate:
+		return true
+	}
+	return false
+}
+
+// hasInstance reports whether sorted contains an OID strictly below prefix.
+func hasInstance(sorted []OID, prefix OID) bool {
+	i, _ := slices.BinarySearchFunc(sorted, prefix, OID.Compare)
+	for ; i < len(sorted); i++ {
+		if !sorted[i].HasPrefix(prefix) {
+			return false
+		}
+		if len(sorted[i]) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSyntax returns why v falls outside sc, or "" if it conforms.
+func checkSyntax(sc *SyntaxConstraints, v any) string {
+	if u, ok := valueToUint64(v); ok && u > math.MaxInt64 {
+		// Range bounds are clamped to MaxInt64, so a larger value only
+		// lies in a range that reaches that bound.
+		if len(sc.Enums) > 0 {
+			return fmt.Sprintf("value %d is not an enumeration allowed by the compliance", u)
+		}
+		if len(sc.Ranges) > 0 && !slices.ContainsFunc(sc.Ranges, func(r Range) bool { return r.Max == math.MaxInt64 }) {
+			return fmt.Sprintf("value %d is outside %s", u, rangesString(sc.Ranges))
+		}
+		return ""
+	}
+	if n, ok := Int64Value(v); ok {
+		if len(sc.Enums) > 0 && !slices.ContainsFunc(sc.Enums, func(nv NamedValue) bool { return nv.Value == n }) {
+			return fmt.Sprintf("value %d is not an enumeration allowed by the compliance", n)
+		}
+		if len(sc.Ranges) > 0 && !inRanges(sc.Ranges, n) {
+			return fmt.Sprintf("value %d is outside %s", n, rangesString(sc.Ranges))
+		}
+		return ""
+	}
+	b, ok := valueToBytes(v)
+	if !ok {
+		return ""
+	}
+	if len(sc.Sizes) > 0 && !inRanges(sc.Sizes, int64(len(b))) {
+		return fmt.Sprintf("length %d is outside SIZE %s", len(b), rangesString(sc.Sizes))
+	}
+	if len(sc.Bits) > 0 {
+		for i, octet := range b {
+			for bit := range 8 {
+				if octet&(0x80>>bit) == 0 {
+					continue
+				}
+				pos := int64(i*8 + bit)
+				if !slices.ContainsFunc(sc.Bits, func(nv NamedValue) bool { return nv.Value == pos }) {
+					return fmt.Sprintf("bit %d is not allowed by the compliance", pos)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func inRanges(rs []Range, n int64) bool {
+	for _, r := range rs {
+		if n >= r.Min && n <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+func rangesString(rs []Range) string {
+	parts := make([]string, len(rs))
+	for i, r := range rs {
+		parts[i] = r.String()
+	}
+	return "(" + strings.Join(parts, " | ") + ")"
+}
@@ -0,0 +1,43 @@
+package mib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckSyntax(t *testing.T) {
+	small := &SyntaxConstraints{Ranges: []Range{{Min: 0, Max: 100}}}
+	unbounded := &SyntaxConstraints{Ranges: []Range{{Min: 0, Max: math.MaxInt64}}}
+	enums := &SyntaxConstraints{Enums: []NamedValue{{Label: "up", Value: 1}}}
+	sizes := &SyntaxConstraints{Sizes: []Range{{Min: 0, Max: 4}}}
+	bits := &SyntaxConstraints{Bits: []NamedValue{{Label: "a", Value: 0}}}
+
+	tests := []struct {
+		name string
+		sc   *SyntaxConstraints
+		v    any
+		want string
+	}{
+		{"in range", small, int64(42), ""},
+		{"below range", small, int64(-1), "value -1 is outside (0..100)"},
+		{"unsigned in range", small, uint32(100), ""},
+		{"unsigned above range", small, uint64(101), "value 101 is outside (0..100)"},
+		{"unsigned above MaxInt64", small, uint64(math.MaxUint64), "value 18446744073709551615 is outside (0..100)"},
+		{"unsigned above MaxInt64 in clamped range", unbounded, uint64(math.MaxUint64), ""},
+		{"enum allowed", enums, int64(1), ""},
+		{"enum not allowed", enums, int64(2), "value 2 is not an enumeration allowed by the compliance"},
+		{"unsigned above MaxInt64 enum", enums, uint64(1 << 63), "value 9223372036854775808 is not an enumeration allowed by the compliance"},
+		{"size ok", sizes, []byte("abcd"), ""},
+		{"size too long", sizes, "abcde", "length 5 is outside SIZE (0..4)"},
+		{"bit allowed", bits, []byte{0x80}, ""},
+		{"bit not allowed", bits, []byte{0x40}, "bit 1 is not allowed by the compliance"},
+		{"nil value", small, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkSyntax(tt.sc, tt.v); got != tt.want {
+				t.Errorf("checkSyntax(%v) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package mib
+
+import (
+	"slices"
+
+	"github.com/golangsnmp/gomib/internal/graph"
+)
+
+// DependencyGraph is the module import graph of a [Mib]. Nodes are
+// module names; an edge from A to B means A imports from B. Modules
+// that are imported but were not loaded are kept as nodes with no
+// dependencies and reported by [DependencyGraph.Missing].
+type DependencyGraph struct {
+	modules    []string
+	deps       map[string][]string
+	dependents map[string][]string
+	missing    map[string]bool
+	unresolved map[string][]string
+	order      []string
+	cycles     [][]string
+}
+
+// DependencyGraph builds the import graph of the loaded modules.
+func (m *Mib) DependencyGraph() *DependencyGraph {
+	g := &DependencyGraph{
+		deps:       make(map[string][]string),
+		dependents: make(map[string][]string),
+		missing:    make(map[string]bool),
+		unresolved: make(map[string][]string),
+	}
+	ig := graph.New(len(m.modules))
+	for _, mod := range m.modules {
+		g.modules = append(g.modules, mod.name)
+		ig.AddNode(graph.Symbol{Module: mod.name})
+		for _, imp := range mod.imports {
+			if imp.Module == mod.name {
+				continue
+			}
+			g.deps[mod.name] = appendUnique(g.deps[mod.name], imp.Module)
+			g.dependents[imp.Module] = appendUnique(g.dependents[imp.Module], mod.name)
+			ig.AddEdge(graph.Symbol{Module: mod.name}, graph.Symbol{Module: imp.Module})
+			if m.Module(imp.Module) == nil {
+				g.missing[imp.Module] = true
+			}
+		}
+	}
+	for _, ref := range m.unresolved {
+		if ref.Kind == UnresolvedImport {
+			g.unresolved[ref.Module] = appendUnique(g.unresolved[ref.Module], ref.Symbol)
+		}
+	}
+	slices.Sort(g.modules)
+	for _, list := range g.deps {
+		slices.Sort(list)
+	}
+	for _, list := range g.dependents {
+		slices.Sort(list)
+	}
+
+	order, cycles := ig.ResolutionOrder()
+	for _, sym := range order {
+		g.order = append(g.order, sym.Module)
+	}
+	for _, cycle := range cycles {
+		names := make([]string, len(cycle))
+		for i, sym := range cycle {
+			names[i] = sym.Module
+		}
+		slices.Sort(names)
+		g.cycles = append(g.cycles, names)
+	}
+	return g
+}
+
+// Modules returns the names of the loaded modules, sorted.
+func (g *DependencyGraph) Modules() []string { return slices.Clone(g.modules) }
+
+// Dependencies returns the modules that name imports from directly.
+func (g *DependencyGraph) Dependencies(name string) []string { return slices.Clone(g.deps[name]) }
+
+// Dependents returns the modules that import from name directly.
+func (g *DependencyGraph) Dependents(name string) []string {
+	return slices.Clone(g.dependents[name])
+}
+
+// TransitiveDependencies returns every module name depends on, directly
+// or indirectly, sorted.
+func (g *DependencyGraph) TransitiveDependencies(name string) []string {
+	return g.closure(name, g.deps)
+}
+
+// TransitiveDependents returns every module that depends on name,
+// directly or indirectly, sorted.
+func (g *DependencyGraph) TransitiveDependents(name string) []string {
+	return g.closure(name, g.dependents)
+}
+
+func (g *DependencyGraph) closure(name string, edges map[string][]string) []string {
+	seen := map[string]bool{name: true}
+	queue := []string{name}
+	var result []string
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[cur] {
+			if !seen[next] {
+				seen[next] = true
+				result = append(result, next)
+				queue = append(queue, next)
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// Order returns the module names in topological order, dependencies
+// before dependents, including missing modules. Modules in an import
+// cycle are omitted; see [DependencyGraph.Cycles].
+func (g *DependencyGraph) Order() []string { return slices.Clone(g.order) }
+
+// Cycles returns the sets of modules that import each other in a cycle.
+func (g *DependencyGraph) Cycles() [][]string {
+	out := make([][]string, len(g.cycles))
+	for i, c := range g.cycles {
+		out[i] = slices.Clone(c)
+	}
+	return out
+}
+
+// Missing returns the modules that are imported but not loaded, sorted.
+func (g *DependencyGraph) Missing() []string {
+	var out []string
+	for name := range g.missing {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// IsMissing reports whether name is imported but not loaded.
+func (g *DependencyGraph) IsMissing(name string) bool { return g.missing[name] }
+
+// UnresolvedImports returns the symbols module name failed to import,
+// as recorded in [Mib.Unresolved].
+func (g *DependencyGraph) UnresolvedImports(name string) []string {
+	return slices.Clone(g.unresolved[name])
+}
+
+// Path returns the shortest import chain from one module to another,
+// starting with from and ending with to, or nil if to is not reachable.
+// It answers questions like "why does loading this module need that
+// missing file?".
+func (g *DependencyGraph) Path(from, to string) []string {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == to {
+			var path []string
+			for n := to; n != ""; n = prev[n] {
+				path = append(path, n)
+			}
+			slices.Reverse(path)
+			return path
+		}
+		for _, next := range g.deps[cur] {
+			if _, ok := prev[next]; !ok {
+				prev[next] = cur
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
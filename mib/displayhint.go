@@ -0,0 +1,207 @@
+package mib
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// octetHintSpec is one parsed octet-format specification from an
+// RFC 2579 DISPLAY-HINT (e.g. the "1x:" in "1x:1x:1x").
+type octetHintSpec struct {
+	repeat bool
+	length int
+	format byte
+	sep    byte // 0 if absent
+	term   byte // 0 if absent; only meaningful when repeat is set
+}
+
+// FormatOctetHint renders an OCTET STRING value using an RFC 2579
+// DISPLAY-HINT such as "255a", "1x:" or "2d-1d-1d,1d:1d:1d.1d".
+// The last specification is reused until the value is exhausted.
+func FormatOctetHint(hint string, value []byte) (string, error) {
+	specs, err := parseOctetHint(hint)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	pos := 0
+	for i := 0; pos < len(value); i++ {
+		spec := specs[min(i, len(specs)-1)]
+
+		count := 1
+		if spec.repeat {
+			count = int(value[pos])
+			pos++
+		}
+
+		for r := 0; r < count && pos < len(value); r++ {
+			n := min(spec.length, len(value)-pos)
+			writeOctetChunk(&b, spec.format, value[pos:pos+n])
+			pos += n
+			if pos >= len(value) {
+				break
+			}
+			if spec.repeat && r == count-1 && spec.term != 0 {
+				b.WriteByte(spec.term)
+			} else if spec.sep != 0 {
+				b.WriteByte(spec.sep)
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+func parseOctetHint(hint string) ([]octetHintSpec, error) {
+	var specs []octetHintSpec
+	isSpecial := func(c byte) bool { return c == '*' || (c >= '0' && c <= '9') }
+
+	for i := 0; i < len(hint); {
+		var spec octetHintSpec
+		if hint[i] == '*' {
+			spec.repeat = true
+			i++
+		}
+		start := i
+		for i < len(hint) && hint[i] >= '0' && hint[i] <= '9' {
+			i++
+		}
+		if start == i {
+			return nil, fmt.Errorf("display hint %q: missing octet length at offset %d", hint, i)
+		}
+		n, err := strconv.Atoi(hint[start:i])
+		if err != nil {
+			return nil, fmt.Errorf("display hint %q: %w", hint, err)
+		}
+		spec.length = n
+		if i >= len(hint) {
+			return nil, fmt.Errorf("display hint %q: missing display format", hint)
+		}
+		switch hint[i] {
+		case 'd', 'x', 'o', 'a', 't':
+			spec.format = hint[i]
+		default:
+			return nil, fmt.Errorf("display hint %q: unknown display format %q", hint, hint[i])
+		}
+		i++
+		if i < len(hint) && !isSpecial(hint[i]) {
+			spec.sep = hint[i]
+			i++
+			if spec.repeat && i < len(hint) && !isSpecial(hint[i]) {
+				spec.term = hint[i]
+				i++
+			}
+		}
+		if spec.length == 0 && !spec.repeat {
+			return nil, fmt.Errorf("display hint %q: zero octet length", hint)
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("empty display hint")
+	}
+	return specs, nil
+}
+
+func writeOctetChunk(b *strings.Builder, format byte, chunk []byte) {
+	switch format {
+	case 'a', 't':
+		if format == 't' && utf8.Valid(chunk) {
+			b.Write(chunk)
+			return
+		}
+		for _, c := range chunk {
+			if c < 0x20 || c > 0x7e {
+				if c == '\n' || c == '\r' || c == '\t' {
+					b.WriteByte(c)
+					continue
+				}
+				b.WriteByte('.')
+				continue
+			}
+			b.WriteByte(c)
+		}
+	case 'x':
+		b.WriteString(hex.EncodeToString(chunk))
+	default:
+		base := 10
+		if format == 'o' {
+			base = 8
+		}
+		if len(chunk) > 8 {
+			b.WriteString(new(big.Int).SetBytes(chunk).Text(base))
+			return
+		}
+		var n uint64
+		for _, c := range chunk {
+			n = n<<8 | uint64(c)
+		}
+		b.WriteString(strconv.FormatUint(n, base))
+	}
+}
+
+// FormatIntegerHint renders an integer value using an RFC 2579
+// DISPLAY-HINT for INTEGER-based types: "d", "d-N" (implied decimal
+// point N digits from the right), "x", "o" or "b".
+func FormatIntegerHint(hint string, value int64) (string, error) {
+	return formatIntegerHint(hint, value < 0, absInt64(value))
+}
+
+// FormatUnsignedHint is like [FormatIntegerHint] for unsigned values,
+// such as Counter64 values above the int64 range.
+func FormatUnsignedHint(hint string, value uint64) (string, error) {
+	return formatIntegerHint(hint, false, value)
+}
+
+// formatIntegerHint renders the integer with magnitude mag, negative if
+// neg is set.
+func formatIntegerHint(hint string, neg bool, mag uint64) (string, error) {
+	if hint == "" {
+		return "", fmt.Errorf("empty display hint")
+	}
+	sign := ""
+	if neg {
+		sign = "-"
+	}
+	switch hint[0] {
+	case 'x':
+		return sign + strconv.FormatUint(mag, 16), nil
+	case 'o':
+		return sign + strconv.FormatUint(mag, 8), nil
+	case 'b':
+		return sign + strconv.FormatUint(mag, 2), nil
+	case 'd':
+	default:
+		return "", fmt.Errorf("display hint %q: unknown integer format", hint)
+	}
+
+	if len(hint) == 1 {
+		return sign + strconv.FormatUint(mag, 10), nil
+	}
+	if hint[1] != '-' {
+		return "", fmt.Errorf("display hint %q: expected '-' after 'd'", hint)
+	}
+	places, err := strconv.Atoi(hint[2:])
+	if err != nil || places < 0 {
+		return "", fmt.Errorf("display hint %q: invalid decimal places", hint)
+	}
+	digits := strconv.FormatUint(mag, 10)
+	if places == 0 {
+		return sign + digits, nil
+	}
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-places] + "." + digits[len(digits)-places:], nil
+}
+
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
@@ -0,0 +1,103 @@
+package mib
+
+import "testing"
+
+func TestFormatOctetHint(t *testing.T) {
+	tests := []struct {
+		name  string
+		hint  string
+		value []byte
+		want  string
+	}{
+		{"DisplayString", "255a", []byte("eth0"), "eth0"},
+		{"MacAddress", "1x:", []byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}, "00:1a:2b:3c:4d:5e"},
+		{"dotted decimal", "1d.1d.1d.1d", []byte{192, 0, 2, 1}, "192.0.2.1"},
+		{"last spec reused", "1d.", []byte{10, 0, 0, 1}, "10.0.0.1"},
+		{"DateAndTime", "2d-1d-1d,1d:1d:1d.1d,1a1d:1d",
+			[]byte{0x07, 0xE8, 3, 15, 13, 30, 15, 0, '+', 1, 0},
+			"2024-3-15,13:30:15.0,+1:0"},
+		{"multi-octet decimal", "4d", []byte{0, 0, 1, 0}, "256"},
+		{"octal", "1o", []byte{8}, "10"},
+		{"repeat with terminator", "*1x:/1a", []byte{2, 0xab, 0xcd, 'z'}, "ab:cd/z"},
+		{"truncated final chunk", "2x ", []byte{0x01, 0x02, 0x03}, "0102 03"},
+		{"empty value", "1x:", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatOctetHint(tt.hint, tt.value)
+			if err != nil {
+				t.Fatalf("FormatOctetHint(%q) error: %v", tt.hint, err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatOctetHint(%q) = %q, want %q", tt.hint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOctetHintInvalid(t *testing.T) {
+	for _, hint := range []string{"", "x", "1q", "1", "0x"} {
+		if _, err := FormatOctetHint(hint, []byte{1}); err == nil {
+			t.Errorf("FormatOctetHint(%q) expected error", hint)
+		}
+	}
+}
+
+func TestFormatIntegerHint(t *testing.T) {
+	tests := []struct {
+		hint  string
+		value int64
+		want  string
+	}{
+		{"d", 42, "42"},
+		{"d-2", 1234, "12.34"},
+		{"d-2", 5, "0.05"},
+		{"d-2", -1234, "-12.34"},
+		{"d-1", -5, "-0.5"},
+		{"d-0", 7, "7"},
+		{"x", 255, "ff"},
+		{"o", 8, "10"},
+		{"b", 5, "101"},
+		{"x", -16, "-10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hint, func(t *testing.T) {
+			got, err := FormatIntegerHint(tt.hint, tt.value)
+			if err != nil {
+				t.Fatalf("FormatIntegerHint(%q, %d) error: %v", tt.hint, tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatIntegerHint(%q, %d) = %q, want %q", tt.hint, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatUnsignedHint(t *testing.T) {
+	tests := []struct {
+		hint  string
+		value uint64
+		want  string
+	}{
+		{"d", 18446744073709551615, "18446744073709551615"},
+		{"d-3", 9223372036854775808, "9223372036854775.808"},
+		{"x", 18446744073709551615, "ffffffffffffffff"},
+	}
+	for _, tt := range tests {
+		got, err := FormatUnsignedHint(tt.hint, tt.value)
+		if err != nil {
+			t.Fatalf("FormatUnsignedHint(%q, %d) error: %v", tt.hint, tt.value, err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatUnsignedHint(%q, %d) = %q, want %q", tt.hint, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatIntegerHintInvalid(t *testing.T) {
+	for _, hint := range []string{"", "q", "d2", "d-x"} {
+		if _, err := FormatIntegerHint(hint, 1); err == nil {
+			t.Errorf("FormatIntegerHint(%q) expected error", hint)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package mib
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatValue renders a value returned by an agent for this object in
+// human-readable form, using the object's enumeration labels, BITS
+// definitions, DISPLAY-HINT, and base type.
+//
+// Accepted value types are the Go integer types, []byte, string, OID,
+// []uint32 and net.IP. Enumerated integers render as "label(n)", BITS
+// as "{ a, b }", TimeTicks as "(n) d:hh:mm:ss.cc", and octet strings
+// without a display hint as text when printable or hex otherwise.
+func (o *Object) FormatValue(v any) string {
+	if o == nil {
+		return formatRawValue(v)
+	}
+	base := BaseUnknown
+	if o.typ != nil {
+		base = o.typ.EffectiveBase()
+	}
+
+	if u, ok := valueToUint64(v); ok && u > math.MaxInt64 {
+		if o.hint != "" && isIntegerBase(base) {
+			if s, err := FormatUnsignedHint(o.hint, u); err == nil {
+				return s
+			}
+		}
+		return formatRawValue(v)
+	}
+	if n, ok := Int64Value(v); ok {
+		if nv, ok := o.EnumByValue(n); ok {
+			return nv.Label + "(" + strconv.FormatInt(n, 10) + ")"
+		}
+		if base == BaseTimeTicks {
+			return FormatTimeTicks(uint32(n))
+		}
+		if o.hint != "" && isIntegerBase(base) {
+			if s, err := FormatIntegerHint(o.hint, n); err == nil {
+				return s
+			}
+		}
+		return formatRawValue(v)
+	}
+
+	b, ok := valueToBytes(v)
+	if !ok {
+		return formatRawValue(v)
+	}
+	if len(o.bits) > 0 {
+		return formatBits(o.bits, b)
+	}
+	if base == BaseIpAddress && len(b) == 4 {
+		return net.IP(b).String()
+	}
+	if o.hint != "" {
+		if s, err := FormatOctetHint(o.hint, b); err == nil {
+			return s
+		}
+	}
+	return formatOctets(b)
+}
+
+// EnumByValue looks up an enumeration label by its numeric value.
+func (o *Object) EnumByValue(v int64) (NamedValue, bool) {
+	for _, nv := range o.enums {
+		if nv.Value == v {
+			return nv, true
+		}
+	}
+	return NamedValue{}, false
+}
+
+// FormatTimeTicks renders hundredths of a second in the net-snmp style,
+// e.g. "(12345) 0:02:03.45" or "(8640000) 1 day, 0:00:00.00".
+func FormatTimeTicks(ticks uint32) string {
+	cs := ticks % 100
+	secs := ticks / 100
+	days := secs / 86400
+	secs %= 86400
+	h, m, s := secs/3600, (secs%3600)/60, secs%60
+
+	var b strings.Builder
+	b.WriteString("(" + strconv.FormatUint(uint64(ticks), 10) + ") ")
+	switch days {
+	case 0:
+	case 1:
+		b.WriteString("1 day, ")
+	default:
+		b.WriteString(strconv.FormatUint(uint64(days), 10) + " days, ")
+	}
+	fmt.Fprintf(&b, "%d:%02d:%02d.%02d", h, m, s, cs)
+	return b.String()
+}
+
+func formatBits(bits []NamedValue, b []byte) string {
+	var labels []string
+	for _, nv := range bits {
+		byteIdx := nv.Value / 8
+		if nv.Value < 0 || byteIdx >= int64(len(b)) {
+			continue
+		}
+		if b[byteIdx]&(0x80>>(nv.Value%8)) != 0 {
+			labels = append(labels, nv.Label)
+		}
+	}
+	if len(labels) == 0 {
+		return "{ }"
+	}
+	return "{ " + strings.Join(labels, ", ") + " }"
+}
+
+// formatOctets renders an octet string as text when it is printable
+// UTF-8, or as space-separated uppercase hex otherwise.
+func formatOctets(b []byte) string {
+	if utf8.Valid(b) {
+		printable := true
+		for _, r := range string(b) {
+			if (r < 0x20 && r != '\n' && r != '\r' && r != '\t') || r == 0x7f {
+				printable = false
+				break
+			}
+		}
+		if printable {
+			return string(b)
+		}
+	}
+	var sb strings.Builder
+	for i, c := range b {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strings.ToUpper(hex.EncodeToString([]byte{c})))
+	}
+	return sb.String()
+}
+
+func formatRawValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return formatOctets(x)
+	case OID:
+		return x.String()
+	case []uint32:
+		return OID(x).String()
+	case net.IP:
+		return x.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isIntegerBase(b BaseType) bool {
+	switch b {
+	case BaseInteger32, BaseUnsigned32, BaseGauge32, BaseCounter32, BaseCounter64:
+		return true
+	}
+	return false
+}
+
+// VarBind is one variable binding: an instance OID and its value, as
+// decoded by an SNMP stack. Value holds the types accepted by
+// [Object.FormatValue]: a Go integer type, []byte, string, OID or net.IP.
+type VarBind struct {
+	OID   OID
+	Value any
+}
+
+// Int64Value converts a value of any Go integer type to int64, reporting
+// whether v was an integer. Unsigned 64-bit values above MaxInt64 wrap.
+func Int64Value(v any) (int64, bool) {
+	switch x := v.(type) {
+	case int:
+		return int64(x), true
+	case int8:
+		return int64(x), true
+	case int16:
+		return int64(x), true
+	case int32:
+		return int64(x), true
+	case int64:
+		return x, true
+	case uint:
+		return int64(x), true
+	case uint8:
+		return int64(x), true
+	case uint16:
+		return int64(x), true
+	case uint32:
+		return int64(x), true
+	case uint64:
+		return int64(x), true
+	}
+	return 0, false
+}
+
+// valueToUint64 converts a value of an unsigned Go integer type to
+// uint64, so that values above MaxInt64 keep their magnitude.
+func valueToUint64(v any) (uint64, bool) {
+	switch x := v.(type) {
+	case uint:
+		return uint64(x), true
+	case uint8:
+		return uint64(x), true
+	case uint16:
+		return uint64(x), true
+	case uint32:
+		return uint64(x), true
+	case uint64:
+		return x, true
+	}
+	return 0, false
+}
+
+// valueToBytes converts octet-string-like values to a byte slice.
+func valueToBytes(v any) ([]byte, bool) {
+	switch x := v.(type) {
+	case []byte:
+		return x, true
+	case string:
+		return []byte(x), true
+	case net.IP:
+		if ip4 := x.To4(); ip4 != nil {
+			return ip4, true
+		}
+		return x, true
+	}
+	return nil, false
+}
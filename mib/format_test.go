@@ -0,0 +1,123 @@
+package mib
+
+import (
+	"net"
+	"testing"
+)
+
+func TestObjectFormatValue(t *testing.T) {
+	integer := &Type{name: "INTEGER", base: BaseInteger32}
+	octets := &Type{name: "OCTET STRING", base: BaseOctetString}
+	ticks := &Type{name: "TimeTicks", base: BaseTimeTicks}
+	ipaddr := &Type{name: "IpAddress", base: BaseIpAddress}
+	counter64 := &Type{name: "Counter64", base: BaseCounter64}
+
+	tests := []struct {
+		name  string
+		obj   *Object
+		value any
+		want  string
+	}{
+		{
+			name:  "enum label",
+			obj:   &Object{typ: integer, enums: []NamedValue{{"up", 1}, {"down", 2}}},
+			value: 2,
+			want:  "down(2)",
+		},
+		{
+			name:  "unknown enum value",
+			obj:   &Object{typ: integer, enums: []NamedValue{{"up", 1}}},
+			value: int64(7),
+			want:  "7",
+		},
+		{
+			name:  "integer hint",
+			obj:   &Object{typ: integer, hint: "d-1"},
+			value: int32(215),
+			want:  "21.5",
+		},
+		{
+			name:  "unsigned hint above int64",
+			obj:   &Object{typ: counter64, hint: "d-2"},
+			value: uint64(18446744073709551615),
+			want:  "184467440737095516.15",
+		},
+		{
+			name:  "unsigned above int64",
+			obj:   &Object{typ: counter64},
+			value: uint64(18446744073709551615),
+			want:  "18446744073709551615",
+		},
+		{
+			name:  "timeticks",
+			obj:   &Object{typ: ticks},
+			value: uint32(12345),
+			want:  "(12345) 0:02:03.45",
+		},
+		{
+			name:  "octet hint",
+			obj:   &Object{typ: octets, hint: "1x:"},
+			value: []byte{0xde, 0xad},
+			want:  "de:ad",
+		},
+		{
+			name:  "printable string",
+			obj:   &Object{typ: octets},
+			value: "hello",
+			want:  "hello",
+		},
+		{
+			name:  "binary string",
+			obj:   &Object{typ: octets},
+			value: []byte{0x00, 0xff},
+			want:  "00 FF",
+		},
+		{
+			name:  "bits",
+			obj:   &Object{typ: octets, bits: []NamedValue{{"a", 0}, {"b", 1}, {"c", 9}}},
+			value: []byte{0xC0, 0x40},
+			want:  "{ a, b, c }",
+		},
+		{
+			name:  "ip address",
+			obj:   &Object{typ: ipaddr},
+			value: net.ParseIP("192.0.2.1"),
+			want:  "192.0.2.1",
+		},
+		{
+			name:  "oid",
+			obj:   &Object{},
+			value: OID{1, 3, 6},
+			want:  "1.3.6",
+		},
+		{
+			name:  "nil object",
+			obj:   nil,
+			value: uint64(9),
+			want:  "9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.obj.FormatValue(tt.value); got != tt.want {
+				t.Errorf("FormatValue(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTimeTicks(t *testing.T) {
+	tests := []struct {
+		ticks uint32
+		want  string
+	}{
+		{0, "(0) 0:00:00.00"},
+		{8640000, "(8640000) 1 day, 0:00:00.00"},
+		{17280001, "(17280001) 2 days, 0:00:00.01"},
+	}
+	for _, tt := range tests {
+		if got := FormatTimeTicks(tt.ticks); got != tt.want {
+			t.Errorf("FormatTimeTicks(%d) = %q, want %q", tt.ticks, got, tt.want)
+		}
+	}
+}
@@ -12,6 +12,9 @@ type Group struct {
 	desc                string
 	ref                 string
 	isNotificationGroup bool
+
+	// Reverse references, filled in lazily by linkCrossReferences.
+	compliances []*Compliance
 }
 
 // newGroup returns a Group initialized with the given name.
@@ -0,0 +1,143 @@
+package mib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrIndexTruncated is returned by [Object.DecodeIndex] when an instance
+// suffix ends before all INDEX components are decoded.
+var ErrIndexTruncated = errors.New("instance index truncated")
+
+// IndexValue is one decoded component of a table instance index.
+type IndexValue struct {
+	Object *Object // the INDEX object this component belongs to
+	Arcs   OID     // the OID arcs consumed by this component
+	// Value holds int64 for integer-valued indexes, []byte for
+	// OCTET STRING and IpAddress indexes, and OID for OBJECT
+	// IDENTIFIER indexes.
+	Value any
+}
+
+// String renders the component using the index object's formatting rules.
+func (v IndexValue) String() string {
+	return v.Object.FormatValue(v.Value)
+}
+
+// DecodeIndex splits an instance suffix (the arcs after a column's OID)
+// into its INDEX components, following RFC 2578 section 7.7 encoding
+// rules: integers take one arc, fixed-size strings take their size in
+// arcs, variable-length strings and OIDs are length-prefixed unless
+// IMPLIED. DecodeIndex accepts a row or a column; other kinds return nil.
+func (o *Object) DecodeIndex(suffix OID) ([]IndexValue, error) {
+	row := o
+	if o.IsColumn() {
+		row = o.Row()
+	}
+	if !row.IsRow() {
+		return nil, nil
+	}
+
+	indexes := row.EffectiveIndexes()
+	values := make([]IndexValue, 0, len(indexes))
+	rest := suffix
+	for i, idx := range indexes {
+		implied := idx.Implied && i == len(indexes)-1
+		val, n, err := decodeIndexComponent(idx.Object, rest, implied)
+		if err != nil {
+			return values, fmt.Errorf("index %s: %w", idx.Object.Name(), err)
+		}
+		values = append(values, IndexValue{Object: idx.Object, Arcs: slices.Clone(rest[:n]), Value: val})
+		rest = rest[n:]
+	}
+	if len(rest) > 0 {
+		return values, fmt.Errorf("%d trailing arcs after index", len(rest))
+	}
+	return values, nil
+}
+
+// FormatIndex renders decoded index components as net-snmp style
+// subscripts, e.g. `[3]` or `["eth0"][1]`.
+func FormatIndex(values []IndexValue) string {
+	var b strings.Builder
+	for _, v := range values {
+		b.WriteByte('[')
+		switch v.Value.(type) {
+		case []byte:
+			if v.Object.EffectiveDisplayHint() == "" && v.Object.Type() != nil &&
+				v.Object.Type().EffectiveBase() == BaseOctetString {
+				b.WriteString(`"` + v.String() + `"`)
+			} else {
+				b.WriteString(v.String())
+			}
+		default:
+			b.WriteString(v.String())
+		}
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+func decodeIndexComponent(obj *Object, arcs OID, implied bool) (any, int, error) {
+	base := BaseInteger32
+	if obj.Type() != nil {
+		base = obj.Type().EffectiveBase()
+	}
+
+	switch base {
+	case BaseOctetString, BaseOpaque, BaseBits:
+		if size, ok := fixedSize(obj.EffectiveSizes()); ok && !implied {
+			return decodeOctets(arcs, size)
+		}
+		if implied {
+			return decodeOctets(arcs, len(arcs))
+		}
+		if len(arcs) == 0 {
+			return nil, 0, ErrIndexTruncated
+		}
+		b, n, err := decodeOctets(arcs[1:], int(arcs[0]))
+		return b, n + 1, err
+	case BaseIpAddress:
+		return decodeOctets(arcs, 4)
+	case BaseObjectIdentifier:
+		if implied {
+			return slices.Clone(arcs), len(arcs), nil
+		}
+		if len(arcs) == 0 || len(arcs)-1 < int(arcs[0]) {
+			return nil, 0, ErrIndexTruncated
+		}
+		n := int(arcs[0])
+		return slices.Clone(arcs[1 : n+1]), n + 1, nil
+	default:
+		if len(arcs) == 0 {
+			return nil, 0, ErrIndexTruncated
+		}
+		if base == BaseInteger32 {
+			return int64(int32(arcs[0])), 1, nil
+		}
+		return int64(arcs[0]), 1, nil
+	}
+}
+
+func decodeOctets(arcs OID, n int) ([]byte, int, error) {
+	if n > len(arcs) {
+		return nil, 0, ErrIndexTruncated
+	}
+	b := make([]byte, n)
+	for i := range n {
+		if arcs[i] > 255 {
+			return nil, 0, fmt.Errorf("arc %d out of octet range", arcs[i])
+		}
+		b[i] = byte(arcs[i])
+	}
+	return b, n, nil
+}
+
+func fixedSize(sizes []Range) (int, bool) {
+	if len(sizes) == 1 && sizes[0].Min == sizes[0].Max {
+		return int(sizes[0].Min), true
+	}
+	return 0, false
+}
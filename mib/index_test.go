@@ -0,0 +1,64 @@
+package mib
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeIndex(t *testing.T) {
+	_, rowObj, col1Obj, col2Obj := buildTableTree()
+	col1Obj.typ = &Type{name: "DisplayString", base: BaseOctetString}
+	col2Obj.typ = &Type{name: "Integer32", base: BaseInteger32}
+	rowObj.index = []IndexEntry{{Object: col1Obj}, {Object: col2Obj}}
+
+	values, err := col1Obj.DecodeIndex(OID{4, 'e', 't', 'h', '0', 7})
+	if err != nil {
+		t.Fatalf("DecodeIndex: %v", err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("got %d values, want 2", len(values))
+	}
+	if got := string(values[0].Value.([]byte)); got != "eth0" {
+		t.Errorf("first component = %q, want eth0", got)
+	}
+	if got := values[1].Value.(int64); got != 7 {
+		t.Errorf("second component = %d, want 7", got)
+	}
+	if got := FormatIndex(values); got != `["eth0"][7]` {
+		t.Errorf("FormatIndex = %s", got)
+	}
+
+	if _, err := rowObj.DecodeIndex(OID{4, 'e'}); !errors.Is(err, ErrIndexTruncated) {
+		t.Errorf("truncated suffix: got %v, want ErrIndexTruncated", err)
+	}
+	if _, err := rowObj.DecodeIndex(OID{1, 'a', 2, 3}); err == nil {
+		t.Error("expected error for trailing arcs")
+	}
+}
+
+func TestDecodeIndexImpliedAndFixed(t *testing.T) {
+	_, rowObj, col1Obj, col2Obj := buildTableTree()
+	col1Obj.typ = &Type{name: "MacAddress", base: BaseOctetString}
+	col1Obj.sizes = []Range{{6, 6}}
+	col2Obj.typ = &Type{name: "OBJECT IDENTIFIER", base: BaseObjectIdentifier}
+	rowObj.index = []IndexEntry{{Object: col1Obj}, {Object: col2Obj, Implied: true}}
+
+	values, err := rowObj.DecodeIndex(OID{0, 1, 2, 3, 4, 5, 1, 3, 6})
+	if err != nil {
+		t.Fatalf("DecodeIndex: %v", err)
+	}
+	if got := len(values[0].Value.([]byte)); got != 6 {
+		t.Errorf("fixed-size component length = %d, want 6", got)
+	}
+	if got := values[1].Value.(OID).String(); got != "1.3.6" {
+		t.Errorf("implied OID component = %s, want 1.3.6", got)
+	}
+}
+
+func TestDecodeIndexNonTable(t *testing.T) {
+	tableObj, _, _, _ := buildTableTree()
+	values, err := tableObj.DecodeIndex(OID{1})
+	if values != nil || err != nil {
+		t.Errorf("table DecodeIndex = %v, %v; want nil, nil", values, err)
+	}
+}
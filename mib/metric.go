@@ -0,0 +1,162 @@
+package mib
+
+import "strings"
+
+// MetricKind classifies an object for time-series export.
+type MetricKind int
+
+const (
+	MetricNone      MetricKind = iota // not exportable: tables, rows, non-readable objects
+	MetricCounter                     // monotonically increasing, wraps at Bits
+	MetricGauge                       // instantaneous value
+	MetricTimeTicks                   // TimeTicks: uptimes and timestamps in hundredths of a second
+	MetricEnum                        // enumerated state, e.g. ifOperStatus
+	MetricInfo                        // descriptive value exported as a label
+	MetricIndex                       // INDEX component of its own row
+)
+
+func (k MetricKind) String() string {
+	switch k {
+	case MetricNone:
+		return "none"
+	case MetricCounter:
+		return "counter"
+	case MetricGauge:
+		return "gauge"
+	case MetricTimeTicks:
+		return "timeticks"
+	case MetricEnum:
+		return "enum"
+	case MetricInfo:
+		return "info"
+	case MetricIndex:
+		return "index"
+	default:
+		return "unknown"
+	}
+}
+
+// Metric is the metric model of an object.
+type Metric struct {
+	Object *Object
+	Kind   MetricKind
+	Bits   int    // counter wrap width, 32 or 64; 0 for other kinds
+	Units  string // UNITS clause, or ""
+	// Twin is the paired counter of the other width: the 32-bit
+	// counter for a high-capacity counter (ifInOctets for ifHCInOctets)
+	// and vice versa. Exporters that collect both should prefer the
+	// 64-bit counter to avoid double counting.
+	Twin *Object
+}
+
+// Metric classifies o for time-series export. Counters are recognized
+// by base type, plus the HCNUM-TC and RMON2-MIB conventions
+// ZeroBasedCounter32/64; CounterBasedGauge64 is a gauge despite its
+// Counter64 base.
+func (o *Object) Metric() Metric {
+	if o == nil {
+		return Metric{}
+	}
+	m := Metric{Object: o, Units: o.units}
+	if o.node == nil || o.typ == nil {
+		return m
+	}
+	switch o.node.kind {
+	case KindScalar, KindColumn:
+	default:
+		return m
+	}
+	if o.isOwnIndex() {
+		m.Kind = MetricIndex
+		return m
+	}
+	if o.access == AccessNotAccessible || o.access == AccessWriteOnly {
+		return m
+	}
+
+	switch {
+	case typeChainHas(o.typ, TCKey{"HCNUM-TC", "CounterBasedGauge64"}):
+		m.Kind = MetricGauge
+	case counterBits(o.typ) > 0:
+		m.Kind, m.Bits = MetricCounter, counterBits(o.typ)
+		m.Twin = o.twin
+	case o.typ.EffectiveBase() == BaseTimeTicks:
+		m.Kind = MetricTimeTicks
+	case len(o.enums) > 0:
+		m.Kind = MetricEnum
+	case o.typ.IsGauge():
+		m.Kind = MetricGauge
+	default:
+		switch o.typ.EffectiveBase() {
+		case BaseInteger32, BaseUnsigned32:
+			m.Kind = MetricGauge
+		default:
+			m.Kind = MetricInfo
+		}
+	}
+	return m
+}
+
+// CounterTwin returns the counter of the other width paired with this
+// one by name, such as ifInOctets for ifHCInOctets, or nil.
+func (o *Object) CounterTwin() *Object {
+	if o == nil {
+		return nil
+	}
+	return o.twin
+}
+
+// counterBits returns the wrap width of a counter type, 32 or 64, or 0
+// if t is not a counter. ZeroBasedCounter32/64 count like counters;
+// CounterBasedGauge64 does not, despite its Counter64 base.
+func counterBits(t *Type) int {
+	switch {
+	case t == nil, typeChainHas(t, TCKey{"HCNUM-TC", "CounterBasedGauge64"}):
+		return 0
+	case typeChainHas(t, TCKey{"HCNUM-TC", "ZeroBasedCounter64"}):
+		return 64
+	case typeChainHas(t, TCKey{"RMON2-MIB", "ZeroBasedCounter32"}):
+		return 32
+	case t.EffectiveBase() == BaseCounter64:
+		return 64
+	case t.EffectiveBase() == BaseCounter32:
+		return 32
+	}
+	return 0
+}
+
+// isOwnIndex reports whether o is an INDEX component of its own row.
+func (o *Object) isOwnIndex() bool {
+	row := o.Row()
+	if row == nil {
+		return false
+	}
+	for _, idx := range row.EffectiveIndexes() {
+		if idx.Object != nil && idx.Object.node == o.node {
+			return true
+		}
+	}
+	return false
+}
+
+// hcMarkers are the name fragments that distinguish a high-capacity
+// counter from its 32-bit counterpart.
+var hcMarkers = []string{"HC", "HighCapacity"}
+
+// lowCapacityNames returns the candidate Counter32 names for a
+// high-capacity counter name, e.g. "ifInOctets" for "ifHCInOctets".
+func lowCapacityNames(name string) []string {
+	var out []string
+	for _, marker := range hcMarkers {
+		for i := 1; i < len(name); i++ {
+			j := i + len(marker)
+			if !strings.HasPrefix(name[i:], marker) || j >= len(name) {
+				continue
+			}
+			if c := name[j]; c >= 'A' && c <= 'Z' {
+				out = append(out, name[:i]+name[j:])
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package mib
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLowCapacityNames(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"ifHCInOctets", []string{"ifInOctets"}},
+		{"etherStatsHighCapacityOctets", []string{"etherStatsOctets"}},
+		{"dot1dTpHCPortInFrames", []string{"dot1dTpPortInFrames"}},
+		{"ifInOctets", nil},
+		{"fooHC", nil},
+		{"HCfoo", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lowCapacityNames(tt.name); !slices.Equal(got, tt.want) {
+				t.Errorf("lowCapacityNames(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricTextualConventions(t *testing.T) {
+	scalar := &Node{kind: KindScalar}
+	tests := []struct {
+		name string
+		typ  *Type
+		kind MetricKind
+		bits int
+	}{
+		{"CounterBasedGauge64", &Type{name: "CounterBasedGauge64", module: &Module{name: "HCNUM-TC"}, base: BaseCounter64}, MetricGauge, 0},
+		{"ZeroBasedCounter64", &Type{name: "ZeroBasedCounter64", module: &Module{name: "HCNUM-TC"}, base: BaseCounter64}, MetricCounter, 64},
+		{"ZeroBasedCounter32", &Type{name: "ZeroBasedCounter32", module: &Module{name: "RMON2-MIB"}, base: BaseGauge32}, MetricCounter, 32},
+		{"Unsigned32", &Type{name: "Unsigned32", base: BaseUnsigned32}, MetricGauge, 0},
+		{"OBJECT IDENTIFIER", &Type{name: "OBJECT IDENTIFIER", base: BaseObjectIdentifier}, MetricInfo, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Object{name: "x", node: scalar, typ: tt.typ, access: AccessReadOnly}
+			got := obj.Metric()
+			if got.Kind != tt.kind || got.Bits != tt.bits {
+				t.Errorf("Metric() = %s/%d, want %s/%d", got.Kind, got.Bits, tt.kind, tt.bits)
+			}
+		})
+	}
+
+	notAccessible := &Object{node: scalar, typ: &Type{base: BaseInteger32}, access: AccessNotAccessible}
+	if k := notAccessible.Metric().Kind; k != MetricNone {
+		t.Errorf("not-accessible scalar: got %s, want none", k)
+	}
+	var nilObj *Object
+	if got := nilObj.Metric(); got.Kind != MetricNone || got.Object != nil {
+		t.Errorf("nil object: got %+v, want zero Metric", got)
+	}
+}
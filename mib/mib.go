@@ -13,6 +13,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Mib is the top-level container returned by [gomib.Load]. It holds the
@@ -37,6 +38,8 @@ type Mib struct {
 	nodeCount   int
 	diagnostics []Diagnostic
 	unresolved  []UnresolvedRef
+
+	xrefOnce sync.Once // guards linkCrossReferences
 }
 
 // newMib returns an empty, initialized Mib.
@@ -242,6 +245,7 @@ func (m *Mib) HasErrors() bool {
 func (m *Mib) setNodeCount(n int) { m.nodeCount = n }
 
 func (m *Mib) addModule(mod *Module) {
+	mod.mib = m
 	m.modules = append(m.modules, mod)
 	if mod.name != "" {
 		m.moduleByName[mod.name] = mod
@@ -31,6 +31,11 @@ type Module struct {
 	compliancesByName   map[string]*Compliance
 	capabilitiesByName  map[string]*Capability
 	nodesByName         map[string]*Node
+
+	mib *Mib // the Mib the module is loaded into
+
+	// Reverse references, filled in lazily by linkCrossReferences.
+	importers []*Module
 }
 
 // newModule returns a Module initialized with the given name.
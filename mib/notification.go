@@ -12,6 +12,10 @@ type Notification struct {
 	desc     string
 	ref      string
 	trapInfo *TrapInfo
+
+	// Reverse references, filled in lazily by linkCrossReferences.
+	groups       []*Group
+	capabilities []*Capability
 }
 
 // newNotification returns a Notification initialized with the given name.
@@ -18,12 +18,20 @@ type Object struct {
 	defVal   *DefVal
 	augments *Object
 	index    []IndexEntry
+	discrim  *Object
+	twin     *Object
 
 	hint   string
 	sizes  []Range
 	ranges []Range
 	enums  []NamedValue
 	bits   []NamedValue
+
+	// Reverse references, filled in lazily by linkCrossReferences.
+	groups       []*Group
+	notifs       []*Notification
+	indexedBy    []*Object
+	capabilities []*Capability
 }
 
 func newObject(name string) *Object {
@@ -102,6 +110,20 @@ func (o *Object) EffectiveBits() []NamedValue { return slices.Clone(o.bits) }
 // Index returns the declared INDEX entries for this object.
 func (o *Object) Index() []IndexEntry { return slices.Clone(o.index) }
 
+// Discriminator returns the sibling object whose value determines how
+// this object's value is interpreted, or nil. It is set for objects whose
+// textual convention is a discriminated union: an InetAddress column
+// returns its InetAddressType column, an InetAddressPrefixLength returns
+// its InetAddress, a TransportAddress returns its TransportAddressType
+// or TransportDomain, and a TAddress returns its TDomain. Columns are
+// paired within the same row, scalars under the same parent node.
+func (o *Object) Discriminator() *Object {
+	if o == nil {
+		return nil
+	}
+	return o.discrim
+}
+
 // Enum looks up an enumeration value by label.
 func (o *Object) Enum(label string) (NamedValue, bool) { return findNamedValue(o.enums, label) }
 
@@ -242,6 +264,8 @@ func (o *Object) setUnits(u string)                { o.units = u }
 func (o *Object) setDefaultValue(d *DefVal)        { o.defVal = d }
 func (o *Object) setAugments(a *Object)            { o.augments = a }
 func (o *Object) setIndex(idx []IndexEntry)        { o.index = idx }
+func (o *Object) setDiscriminator(d *Object)       { o.discrim = d }
+func (o *Object) setCounterTwin(t *Object)         { o.twin = t }
 func (o *Object) setEffectiveHint(h string)        { o.hint = h }
 func (o *Object) setEffectiveSizes(s []Range)      { o.sizes = s }
 func (o *Object) setEffectiveRanges(r []Range)     { o.ranges = r }
@@ -24,50 +24,79 @@ import (
 type resolver struct {
 	types.Logger
 	diagConfig DiagnosticConfig
+	onPhase    PhaseFunc
 }
 
+// PhaseFunc is called at the start and end of each resolution phase:
+// "register", "imports", "types", "oids" and "semantics". At the end,
+// count is what the phase produced: modules registered, import symbols
+// resolved, types, OID nodes, and objects respectively.
+type PhaseFunc func(phase string, done bool, count int)
+
 // Resolve transforms parsed modules into a fully resolved Mib.
 // If logger is nil, logging is disabled. If diagConfig is nil,
 // defaults to Normal strictness.
 func Resolve(mods []*module.Module, logger *slog.Logger, diagConfig *DiagnosticConfig) *Mib {
+	return ResolveWithPhases(mods, logger, diagConfig, nil)
+}
+
+// ResolveWithPhases is like Resolve, and reports the progress of each
+// phase to onPhase if it is not nil.
+func ResolveWithPhases(mods []*module.Module, logger *slog.Logger, diagConfig *DiagnosticConfig, onPhase PhaseFunc) *Mib {
 	cfg := DefaultConfig()
 	if diagConfig != nil {
 		cfg = *diagConfig
 	}
-	r := &resolver{Logger: types.Logger{L: logger}, diagConfig: cfg}
+	r := &resolver{Logger: types.Logger{L: logger}, diagConfig: cfg, onPhase: onPhase}
 	return r.resolve(mods)
 }
 
+func (r *resolver) startPhase(phase string) {
+	r.Log(slog.LevelDebug, "starting phase", slog.String("phase", phase))
+	if r.onPhase != nil {
+		r.onPhase(phase, false, 0)
+	}
+}
+
+func (r *resolver) endPhase(phase, counted string, count int) {
+	r.Log(slog.LevelDebug, "phase complete", slog.String("phase", phase),
+		slog.Int(counted, count))
+	if r.onPhase != nil {
+		r.onPhase(phase, true, count)
+	}
+}
+
 func (r *resolver) resolve(mods []*module.Module) *Mib {
 	ctx := newResolverContext(mods, r.L, r.diagConfig)
 
-	r.Log(slog.LevelDebug, "starting phase", slog.String("phase", "register"))
+	r.startPhase("register")
 	registerModules(ctx)
-	r.Log(slog.LevelDebug, "phase complete", slog.String("phase", "register"),
-		slog.Int("modules", len(ctx.Mib.Modules())))
+	r.endPhase("register", "modules", len(ctx.Mib.modules))
 
-	r.Log(slog.LevelDebug, "starting phase", slog.String("phase", "imports"))
+	r.startPhase("imports")
 	resolveImports(ctx)
 	resolveTransitiveImports(ctx)
-	r.Log(slog.LevelDebug, "phase complete", slog.String("phase", "imports"))
+	imported := 0
+	for _, syms := range ctx.ModuleImports {
+		imported += len(syms)
+	}
+	r.endPhase("imports", "symbols", imported)
 
-	r.Log(slog.LevelDebug, "starting phase", slog.String("phase", "types"))
+	r.startPhase("types")
 	resolveTypes(ctx)
-	r.Log(slog.LevelDebug, "phase complete", slog.String("phase", "types"),
-		slog.Int("types", len(ctx.Mib.Types())))
+	r.endPhase("types", "types", len(ctx.Mib.types))
 
-	r.Log(slog.LevelDebug, "starting phase", slog.String("phase", "oids"))
+	r.startPhase("oids")
 	resolveOids(ctx)
 	nodeCount := 0
 	for range ctx.Mib.Nodes() {
 		nodeCount++
 	}
-	r.Log(slog.LevelDebug, "phase complete", slog.String("phase", "oids"),
-		slog.Int("nodes", nodeCount))
+	r.endPhase("oids", "nodes", nodeCount)
 
-	r.Log(slog.LevelDebug, "starting phase", slog.String("phase", "semantics"))
+	r.startPhase("semantics")
 	analyzeSemantics(ctx)
-	r.Log(slog.LevelDebug, "phase complete", slog.String("phase", "semantics"))
+	r.endPhase("semantics", "objects", len(ctx.Mib.objects))
 
 	ctx.DropModules()
 
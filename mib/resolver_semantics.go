@@ -3,6 +3,7 @@ package mib
 import (
 	"encoding/hex"
 	"log/slog"
+	"math"
 	"slices"
 	"strconv"
 	"strings"
@@ -193,6 +194,8 @@ func createResolvedObjects(ctx *resolverContext, objRefs []objectTypeRef) {
 	}
 
 	linkObjectIndexes(ctx, objRefs)
+	linkDiscriminators(ctx)
+	linkCounterTwins(ctx)
 
 	if ctx.TraceEnabled() {
 		ctx.Trace("created resolved objects", slog.Int("count", created))
@@ -240,6 +243,171 @@ func linkObjectIndexes(ctx *resolverContext, objRefs []objectTypeRef) {
 	}
 }
 
+// discriminatedTCs maps textual conventions whose values are interpreted
+// through a companion object to the TCs that companion may have, in
+// order of preference.
+var discriminatedTCs = []struct {
+	tc      TCKey
+	discrim []TCKey
+}{
+	{TCKey{"INET-ADDRESS-MIB", "InetAddress"}, []TCKey{{"INET-ADDRESS-MIB", "InetAddressType"}}},
+	{TCKey{"INET-ADDRESS-MIB", "InetAddressPrefixLength"}, []TCKey{{"INET-ADDRESS-MIB", "InetAddress"}}},
+	{TCKey{"TRANSPORT-ADDRESS-MIB", "TransportAddress"}, []TCKey{
+		{"TRANSPORT-ADDRESS-MIB", "TransportAddressType"},
+		{"TRANSPORT-ADDRESS-MIB", "TransportDomain"},
+	}},
+	{TCKey{"SNMPv2-TC", "TAddress"}, []TCKey{{"SNMPv2-TC", "TDomain"}}},
+}
+
+// linkDiscriminators pairs discriminated-union objects with the sibling
+// that determines their interpretation (RFC 4001 section 3, RFC 3419).
+func linkDiscriminators(ctx *resolverContext) {
+	linked := 0
+	for _, obj := range ctx.Mib.objects {
+		for _, dt := range discriminatedTCs {
+			if !typeChainHas(obj.typ, dt.tc) {
+				continue
+			}
+			if d := findDiscriminator(obj, dt.discrim); d != nil {
+				obj.setDiscriminator(d)
+				linked++
+			}
+			break
+		}
+	}
+	if ctx.TraceEnabled() {
+		ctx.Trace("linked discriminators", slog.Int("count", linked))
+	}
+}
+
+// findDiscriminator returns the candidate sibling whose type chain has
+// the first matching TC in tcs. When several siblings match, the one
+// sharing the longest name prefix with obj wins (ipAddressAddrType for
+// ipAddressAddr), then the nearest one preceding it.
+func findDiscriminator(obj *Object, tcs []TCKey) *Object {
+	candidates := discriminatorCandidates(obj)
+	for _, tc := range tcs {
+		var best *Object
+		bestLen, bestDist := -1, 0
+		for _, c := range candidates {
+			if c == obj || !typeChainHas(c.typ, tc) {
+				continue
+			}
+			n := commonPrefixLen(c.name, obj.name)
+			dist := arcDistance(c, obj)
+			if n > bestLen || (n == bestLen && dist < bestDist) {
+				best, bestLen, bestDist = c, n, dist
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// discriminatorCandidates returns the objects a discriminator may be
+// drawn from: the columns and index objects of a column's row, or the
+// scalars sharing a scalar's parent node. Objects from obj's own module
+// are preferred over other definitions at the same OID.
+func discriminatorCandidates(obj *Object) []*Object {
+	if obj.node == nil || obj.node.parent == nil {
+		return nil
+	}
+	var out []*Object
+	seen := make(map[*Object]struct{})
+	add := func(c *Object) {
+		if c == nil {
+			return
+		}
+		if obj.module != nil {
+			if own := obj.module.Object(c.name); own != nil && own.node == c.node {
+				c = own
+			}
+		}
+		if _, dup := seen[c]; !dup {
+			seen[c] = struct{}{}
+			out = append(out, c)
+		}
+	}
+	for _, child := range obj.node.parent.sortedChildren() {
+		if child.kind == obj.node.kind {
+			add(child.obj)
+		}
+	}
+	if obj.node.kind == KindColumn && obj.node.parent.obj != nil {
+		for _, idx := range obj.node.parent.obj.EffectiveIndexes() {
+			add(idx.Object)
+		}
+	}
+	return out
+}
+
+// arcDistance orders candidates by proximity: siblings preceding obj
+// come first, nearest first, followed by those after it.
+func arcDistance(c, obj *Object) int {
+	if c.node == nil || obj.node == nil || c.node.parent != obj.node.parent {
+		return math.MaxInt
+	}
+	if c.node.arc < obj.node.arc {
+		return int(obj.node.arc - c.node.arc)
+	}
+	return math.MaxInt32 + int(c.node.arc-obj.node.arc)
+}
+
+// linkCounterTwins pairs each 64-bit counter with the 32-bit counter
+// named like it without the high-capacity marker (ifHCInOctets and
+// ifInOctets), preferring a twin from the same module. Counters are
+// classified as for [Object.Metric], so ZeroBasedCounter64 objects are
+// paired and CounterBasedGauge64 objects are not.
+func linkCounterTwins(ctx *resolverContext) {
+	linked := 0
+	for _, obj := range ctx.Mib.objects {
+		if counterBits(obj.typ) != 64 {
+			continue
+		}
+		for _, name := range lowCapacityNames(obj.name) {
+			var twin *Object
+			if obj.module != nil {
+				twin = obj.module.Object(name)
+			}
+			if twin == nil {
+				twin = ctx.Mib.Object(name)
+			}
+			if twin == nil || counterBits(twin.typ) != 32 {
+				continue
+			}
+			obj.setCounterTwin(twin)
+			if twin.twin == nil {
+				twin.setCounterTwin(obj)
+			}
+			linked++
+			break
+		}
+	}
+	if ctx.TraceEnabled() {
+		ctx.Trace("linked counter twins", slog.Int("count", linked))
+	}
+}
+
+// typeChainHas reports whether tc appears in t's type chain.
+func typeChainHas(t *Type, tc TCKey) bool {
+	for current := t; current != nil; current = current.parent {
+		if current.name == tc.Name && current.module != nil && current.module.name == tc.Module {
+			return true
+		}
+	}
+	return false
+}
+
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
 // computeEffectiveValues fills in display hints, size/range constraints,
 // enums, and bits on the object by walking the type chain from child to root.
 // Object-level values (set from the OBJECT-TYPE syntax) take precedence;
@@ -466,6 +634,7 @@ func createResolvedCompliances(ctx *resolverContext) {
 		resolved.setDescription(comp.Description)
 		resolved.setReference(comp.Reference)
 		resolved.setModules(convertComplianceModules(ctx, ref.mod, comp.Modules))
+		resolved.setScopes(complianceScopes(ctx, comp.Modules))
 
 		ctx.Mib.addCompliance(resolved)
 		node.setCompliance(resolved)
@@ -517,6 +686,18 @@ func convertComplianceModules(ctx *resolverContext, mod *module.Module, modules
 	return result
 }
 
+// complianceScopes resolves the module named by each MODULE clause.
+// The current module ("") is left nil and taken from the compliance.
+func complianceScopes(ctx *resolverContext, modules []module.ComplianceModule) []*Module {
+	scopes := make([]*Module, len(modules))
+	for i, m := range modules {
+		if m.ModuleName != "" {
+			scopes[i] = ctx.Mib.Module(m.ModuleName)
+		}
+	}
+	return scopes
+}
+
 func createResolvedCapabilities(ctx *resolverContext) {
 	created := 0
 	for _, ref := range collectDefinitionRefs(ctx, func(mod *module.Module, def module.Definition) (capabilitiesRef, bool) {
@@ -584,23 +765,40 @@ func convertSupportsModules(ctx *resolverContext, mod *module.Module, modules []
 			}
 			result[i].ObjectVariations = vars
 		}
-		if len(m.NotificationVariations) > 0 {
-			vars := make([]NotificationVariation, len(m.NotificationVariations))
-			for j, v := range m.NotificationVariations {
-				vars[j] = NotificationVariation{
-					Notification: v.Notification,
-					Description:  v.Description,
-				}
-				if v.Access != nil {
-					vars[j].Access = v.Access
-				}
+		for _, v := range m.NotificationVariations {
+			// A VARIATION with only ACCESS and DESCRIPTION has no
+			// object-only clause, so it lowers as a notification
+			// variation even when it names an object.
+			if isObjectType(ctx, m.ModuleName, v.Notification) {
+				result[i].ObjectVariations = append(result[i].ObjectVariations, ObjectVariation{
+					Object:      v.Notification,
+					Access:      v.Access,
+					Description: v.Description,
+				})
+				continue
 			}
-			result[i].NotificationVariations = vars
+			result[i].NotificationVariations = append(result[i].NotificationVariations, NotificationVariation{
+				Notification: v.Notification,
+				Access:       v.Access,
+				Description:  v.Description,
+			})
 		}
 	}
 	return result
 }
 
+// isObjectType reports whether name is an OBJECT-TYPE in the named module.
+func isObjectType(ctx *resolverContext, moduleName, name string) bool {
+	for _, mod := range ctx.ModuleIndex[moduleName] {
+		for _, def := range mod.Definitions {
+			if obj, ok := def.(*module.ObjectType); ok && obj.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func lookupMemberNode(ctx *resolverContext, mod *module.Module, name string) (*Node, bool) {
 	node, ok := ctx.LookupNodeForModule(mod, name)
 	if ok {
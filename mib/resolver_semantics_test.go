@@ -1018,6 +1018,36 @@ func TestConvertSupportsModules(t *testing.T) {
 		}
 	})
 
+	t.Run("access-only variation naming an object", func(t *testing.T) {
+		notImpl := types.AccessNotImplemented
+		ifMib := &module.Module{Name: "IF-MIB", Definitions: []module.Definition{
+			&module.ObjectType{Name: "ifLinkUpDownTrapEnable"},
+			&module.Notification{Name: "linkDown"},
+		}}
+		ctx := newTestContext()
+		ctx.ModuleIndex["IF-MIB"] = []*module.Module{ifMib}
+		input := []module.SupportsModule{
+			{
+				ModuleName: "IF-MIB",
+				NotificationVariations: []module.NotificationVariation{
+					{Notification: "ifLinkUpDownTrapEnable", Access: &notImpl, Description: "always enabled"},
+					{Notification: "linkDown", Access: &notImpl},
+				},
+			},
+		}
+		result := convertSupportsModules(ctx, mod, input)
+		ovs, nvs := result[0].ObjectVariations, result[0].NotificationVariations
+		if len(ovs) != 1 || ovs[0].Object != "ifLinkUpDownTrapEnable" {
+			t.Fatalf("object variations = %+v, want ifLinkUpDownTrapEnable", ovs)
+		}
+		if ovs[0].Access == nil || *ovs[0].Access != AccessNotImplemented || ovs[0].Description != "always enabled" {
+			t.Errorf("object variation = %+v", ovs[0])
+		}
+		if len(nvs) != 1 || nvs[0].Notification != "linkDown" {
+			t.Errorf("notification variations = %+v, want linkDown", nvs)
+		}
+	})
+
 	t.Run("SPPI access values preserved in variations", func(t *testing.T) {
 		notImpl := types.AccessNotImplemented
 		input := []module.SupportsModule{
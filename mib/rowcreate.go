@@ -0,0 +1,212 @@
+package mib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrRowIncomplete is returned by [RowCreation.Plan] when values are
+// missing for columns that must be set before a row can become active.
+var ErrRowIncomplete = errors.New("row is missing required columns")
+
+// RowCreation describes how rows of a read-create table are created
+// through the SNMPv2-TC RowStatus convention (RFC 2579).
+//
+// Required lists the writable columns with no DEFVAL: with createAndGo
+// they must all be present in the creating SET; with createAndWait they
+// may be set afterwards but must be set before the row is activated.
+// Optional lists writable columns the agent can default.
+type RowCreation struct {
+	Row         *Object
+	RowStatus   *Object // the RowStatus column
+	StorageType *Object // the StorageType column, or nil
+	Required    []*Object
+	Optional    []*Object
+}
+
+// RowCreation returns the row-creation model for the table containing
+// o, which may be the table, its row, or one of its columns. It returns
+// nil if the table has no read-create RowStatus column.
+func (o *Object) RowCreation() *RowCreation {
+	row := o
+	switch {
+	case o.IsTable():
+		row = o.Entry()
+	case o.IsColumn():
+		row = o.Row()
+	case !o.IsRow():
+		return nil
+	}
+	if row == nil {
+		return nil
+	}
+
+	rc := &RowCreation{Row: row}
+	indexes := make(map[*Node]struct{})
+	for _, idx := range row.EffectiveIndexes() {
+		if idx.Object != nil {
+			indexes[idx.Object.node] = struct{}{}
+		}
+	}
+	for _, col := range row.Columns() {
+		if col.access != AccessReadCreate && col.access != AccessReadWrite {
+			continue
+		}
+		if _, isIndex := indexes[col.node]; isIndex {
+			continue
+		}
+		switch {
+		case rc.RowStatus == nil && typeChainHas(col.typ, TCKey{"SNMPv2-TC", "RowStatus"}):
+			rc.RowStatus = col
+		case rc.StorageType == nil && typeChainHas(col.typ, TCKey{"SNMPv2-TC", "StorageType"}):
+			rc.StorageType = col
+			rc.addColumn(col, col.defVal == nil)
+		default:
+			rc.addColumn(col, col.defVal == nil)
+		}
+	}
+	if rc.RowStatus == nil || rc.RowStatus.access != AccessReadCreate {
+		return nil
+	}
+	return rc
+}
+
+func (rc *RowCreation) addColumn(col *Object, required bool) {
+	if required {
+		rc.Required = append(rc.Required, col)
+	} else {
+		rc.Optional = append(rc.Optional, col)
+	}
+}
+
+// WithCapability returns a copy of rc refined by the VARIATION clauses
+// of an AGENT-CAPABILITIES statement: CREATION-REQUIRES on the row adds
+// required columns, a column VARIATION with a DEFVAL makes it optional,
+// and one restricting ACCESS below read-write removes it.
+func (rc *RowCreation) WithCapability(c *Capability) *RowCreation {
+	out := *rc
+	out.Required = slices.Clone(rc.Required)
+	out.Optional = slices.Clone(rc.Optional)
+	if c == nil || rc.Row.module == nil {
+		return &out
+	}
+
+	columns := make(map[string]*Object)
+	for _, col := range rc.Row.Columns() {
+		columns[col.name] = col
+	}
+	for _, sm := range c.supports {
+		if sm.ModuleName != rc.Row.module.name {
+			continue
+		}
+		for _, v := range sm.ObjectVariations {
+			if v.Object == rc.Row.name {
+				for _, name := range v.CreationRequires {
+					if col := columns[name]; col != nil && col != rc.RowStatus {
+						out.remove(col)
+						out.Required = append(out.Required, col)
+					}
+				}
+				continue
+			}
+			col := columns[v.Object]
+			if col == nil || col == rc.RowStatus {
+				continue
+			}
+			if v.Access != nil && *v.Access != AccessReadWrite && *v.Access != AccessReadCreate {
+				out.remove(col)
+				continue
+			}
+			if !v.DefVal.IsZero() && slices.Contains(out.Required, col) {
+				out.remove(col)
+				out.Optional = append(out.Optional, col)
+			}
+		}
+	}
+	sortByArc(out.Required)
+	sortByArc(out.Optional)
+	return &out
+}
+
+func (rc *RowCreation) remove(col *Object) {
+	rc.Required = slices.DeleteFunc(rc.Required, func(o *Object) bool { return o == col })
+	rc.Optional = slices.DeleteFunc(rc.Optional, func(o *Object) bool { return o == col })
+}
+
+func sortByArc(objs []*Object) {
+	slices.SortFunc(objs, func(a, b *Object) int { return a.OID().Compare(b.OID()) })
+}
+
+// SetRequest is one variable binding of a SET PDU.
+type SetRequest struct {
+	OID    OID
+	Object *Object
+	Value  any
+}
+
+// RowCreationPlan is an ordered sequence of SET PDUs that create a row.
+// Each step must succeed before the next is sent.
+type RowCreationPlan struct {
+	Steps [][]SetRequest
+}
+
+// Plan orders the SETs that create the row at instance (the index
+// suffix) using mode, which must be [RowStatusCreateAndGo] or
+// [RowStatusCreateAndWait]. values maps column names to values and may
+// not include the RowStatus column.
+//
+// For createAndGo the plan is a single PDU carrying every value with
+// the RowStatus binding last. For createAndWait it is three PDUs: the
+// createAndWait itself, the column values, then active. Either way,
+// every Required column must have a value, or an error wrapping
+// [ErrRowIncomplete] is returned.
+func (rc *RowCreation) Plan(instance OID, mode RowStatus, values map[string]any) (*RowCreationPlan, error) {
+	if mode != RowStatusCreateAndGo && mode != RowStatusCreateAndWait {
+		return nil, fmt.Errorf("row creation mode must be createAndGo or createAndWait, got %s", mode)
+	}
+
+	writable := make(map[string]*Object, len(rc.Required)+len(rc.Optional))
+	for _, col := range slices.Concat(rc.Required, rc.Optional) {
+		writable[col.name] = col
+	}
+	var sets []SetRequest
+	for name, v := range values {
+		col := writable[name]
+		if col == nil {
+			return nil, fmt.Errorf("%s is not a writable column of %s", name, rc.Row.name)
+		}
+		sets = append(sets, SetRequest{OID: instanceOID(col, instance), Object: col, Value: v})
+	}
+	slices.SortFunc(sets, func(a, b SetRequest) int { return a.OID.Compare(b.OID) })
+
+	var missing []string
+	for _, col := range rc.Required {
+		if _, ok := values[col.name]; !ok {
+			missing = append(missing, col.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrRowIncomplete, strings.Join(missing, ", "))
+	}
+
+	status := func(s RowStatus) SetRequest {
+		return SetRequest{OID: instanceOID(rc.RowStatus, instance), Object: rc.RowStatus, Value: int(s)}
+	}
+	plan := &RowCreationPlan{}
+	if mode == RowStatusCreateAndGo {
+		plan.Steps = [][]SetRequest{append(sets, status(RowStatusCreateAndGo))}
+		return plan, nil
+	}
+	plan.Steps = append(plan.Steps, []SetRequest{status(RowStatusCreateAndWait)})
+	if len(sets) > 0 {
+		plan.Steps = append(plan.Steps, sets)
+	}
+	plan.Steps = append(plan.Steps, []SetRequest{status(RowStatusActive)})
+	return plan, nil
+}
+
+func instanceOID(col *Object, instance OID) OID {
+	return slices.Concat(col.OID(), instance)
+}
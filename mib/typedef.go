@@ -20,6 +20,10 @@ type Type struct {
 	enums  []NamedValue
 	bits   []NamedValue
 	isTC   bool
+
+	// Reverse references, filled in lazily by linkCrossReferences.
+	users   []*Object
+	derived []*Type
 }
 
 func newType(name string) *Type {
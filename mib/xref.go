@@ -0,0 +1,189 @@
+package mib
+
+import "slices"
+
+// linkCrossReferences fills in the reverse references between resolved
+// definitions, so that impact questions ("what uses this?") can be
+// answered without scanning the whole Mib. It runs once, the first time
+// a reverse reference is asked for; see [Module.crossReferenced].
+func linkCrossReferences(m *Mib) {
+	typeSeen := make(map[*Type]bool)
+	linkDerived := func(t *Type) {
+		if typeSeen[t] {
+			return
+		}
+		typeSeen[t] = true
+		if t.parent != nil {
+			t.parent.derived = append(t.parent.derived, t)
+		}
+	}
+	for _, t := range m.types {
+		linkDerived(t)
+	}
+
+	for _, obj := range m.objects {
+		// Anonymous refinements such as DisplayString (SIZE (0..32)) are
+		// not registered types, so link them as they are found.
+		for t := obj.typ; t != nil; t = t.parent {
+			linkDerived(t)
+			t.users = append(t.users, obj)
+		}
+		for _, idx := range obj.EffectiveIndexes() {
+			if idx.Object != nil {
+				idx.Object.indexedBy = appendUnique(idx.Object.indexedBy, obj)
+			}
+		}
+	}
+
+	for _, n := range m.notifications {
+		for _, obj := range n.objects {
+			obj.notifs = appendUnique(obj.notifs, n)
+		}
+	}
+
+	for _, g := range m.groups {
+		for _, member := range g.members {
+			if member.obj != nil {
+				member.obj.groups = appendUnique(member.obj.groups, g)
+			}
+			if member.notif != nil {
+				member.notif.groups = appendUnique(member.notif.groups, g)
+			}
+		}
+	}
+
+	for _, c := range m.compliances {
+		for _, rm := range c.resolve().modules {
+			for _, rg := range rm.groups {
+				rg.group.compliances = appendUnique(rg.group.compliances, c)
+			}
+		}
+	}
+
+	for _, c := range m.capabilities {
+		for _, sm := range c.supports {
+			scope := m.Module(sm.ModuleName)
+			if scope == nil {
+				continue
+			}
+			for _, ov := range sm.ObjectVariations {
+				if obj := scope.Object(ov.Object); obj != nil {
+					obj.capabilities = appendUnique(obj.capabilities, c)
+				}
+			}
+			for _, nv := range sm.NotificationVariations {
+				if n := scope.Notification(nv.Notification); n != nil {
+					n.capabilities = appendUnique(n.capabilities, c)
+				}
+			}
+		}
+	}
+
+	for _, mod := range m.modules {
+		for _, imp := range mod.imports {
+			if src := m.Module(imp.Module); src != nil && src != mod {
+				src.importers = appendUnique(src.importers, mod)
+			}
+		}
+	}
+}
+
+// crossReferenced builds the cross-reference index of the Mib that m
+// belongs to, if it has not been built yet. Every accessor of a reverse
+// reference calls it first, through the definition's module.
+func (m *Module) crossReferenced() {
+	if m == nil || m.mib == nil {
+		return
+	}
+	m.mib.xrefOnce.Do(func() { linkCrossReferences(m.mib) })
+}
+
+func appendUnique[T comparable](s []T, v T) []T {
+	if slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
+
+// Groups returns the OBJECT-GROUPs that list this object.
+func (o *Object) Groups() []*Group {
+	o.module.crossReferenced()
+	return slices.Clone(o.groups)
+}
+
+// Notifications returns the notifications that carry this object in
+// their OBJECTS or VARIABLES clause.
+func (o *Object) Notifications() []*Notification {
+	o.module.crossReferenced()
+	return slices.Clone(o.notifs)
+}
+
+// IndexedRows returns the rows that use this object as an INDEX
+// component, including rows that inherit it through AUGMENTS.
+func (o *Object) IndexedRows() []*Object {
+	o.module.crossReferenced()
+	return slices.Clone(o.indexedBy)
+}
+
+// Capabilities returns the AGENT-CAPABILITIES statements with a
+// VARIATION for this object.
+func (o *Object) Capabilities() []*Capability {
+	o.module.crossReferenced()
+	return slices.Clone(o.capabilities)
+}
+
+// Groups returns the NOTIFICATION-GROUPs that list this notification.
+func (n *Notification) Groups() []*Group {
+	n.module.crossReferenced()
+	return slices.Clone(n.groups)
+}
+
+// Capabilities returns the AGENT-CAPABILITIES statements with a
+// VARIATION for this notification.
+func (n *Notification) Capabilities() []*Capability {
+	n.module.crossReferenced()
+	return slices.Clone(n.capabilities)
+}
+
+// Users returns the objects whose SYNTAX is this type or any type
+// derived from it, such as a textual convention refining it.
+func (t *Type) Users() []*Object {
+	t.module.crossReferenced()
+	return slices.Clone(t.users)
+}
+
+// Derived returns the types defined directly on this one: textual
+// conventions and inline refinements whose parent is t.
+func (t *Type) Derived() []*Type {
+	t.module.crossReferenced()
+	return slices.Clone(t.derived)
+}
+
+// Compliances returns the MODULE-COMPLIANCE statements that require
+// this group, through either MANDATORY-GROUPS or a GROUP clause.
+func (g *Group) Compliances() []*Compliance {
+	g.module.crossReferenced()
+	return slices.Clone(g.compliances)
+}
+
+// Importers returns the loaded modules that import from this module.
+func (m *Module) Importers() []*Module {
+	m.crossReferenced()
+	return slices.Clone(m.importers)
+}
+
+// SymbolImporters returns the loaded modules that import symbol from
+// this module.
+func (m *Module) SymbolImporters(symbol string) []*Module {
+	m.crossReferenced()
+	var result []*Module
+	for _, importer := range m.importers {
+		for _, imp := range importer.imports {
+			if imp.Module == m.name && slices.Contains(imp.Symbols, symbol) {
+				result = append(result, importer)
+				break
+			}
+		}
+	}
+	return result
+}
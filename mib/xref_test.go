@@ -0,0 +1,22 @@
+package mib
+
+import "testing"
+
+func TestCrossReferencesBuiltLazily(t *testing.T) {
+	m := Resolve(nil, nil, nil)
+	octets := m.Type("OCTET STRING")
+	if octets == nil {
+		t.Fatal("OCTET STRING not found")
+	}
+	if len(octets.derived) != 0 {
+		t.Fatal("cross references built during Resolve")
+	}
+
+	derived := octets.Derived()
+	if len(derived) == 0 {
+		t.Fatal("OCTET STRING has no derived types")
+	}
+	if got := len(m.Type("OCTET STRING").Derived()); got != len(derived) {
+		t.Errorf("second Derived() returned %d types, want %d", got, len(derived))
+	}
+}
@@ -0,0 +1,73 @@
+package gomib
+
+import (
+	"os"
+
+	"github.com/golangsnmp/gomib/internal/patch"
+)
+
+// Patch is a parsed MIB patch file. Patches correct broken modules as
+// they are loaded, after parsing and before resolution, so vendor files
+// stay pristine. Pass them to [Load] with [WithPatches].
+//
+// A patch file holds a block per module, one directive per line:
+//
+//	# Fixes for the 2019 ACME release.
+//	module ACME-MIB
+//	    import DisplayString, TruthValue FROM SNMPv2-TC
+//	    rename acme_fooTable acmeFooTable
+//	    oid acmeFoo { acmeProducts 5 }
+//	    syntax acmeFooSpeed Unsigned32 (0..100)
+//	    defval acmeFooMode { enabled }
+//	    drop acmeBrokenThing
+//
+// import adds imports, replacing any existing import of the same symbol.
+// rename changes an identifier everywhere in the module, including its
+// imports. oid replaces a definition's OID assignment, syntax the SYNTAX
+// of an OBJECT-TYPE or type, and defval an OBJECT-TYPE's DEFVAL. drop
+// removes a definition, or an import when no definition has the name.
+// Values are written in MIB syntax; a directive continues onto following
+// lines while braces or parentheses are open. Lines starting with # are
+// comments.
+//
+// Each directive applied is recorded as a patch-applied diagnostic at
+// info severity. A directive whose target does not exist is recorded as
+// patch-unmatched at minor severity, so stale patches are noticed once
+// a vendor fixes the module.
+type Patch struct {
+	file *patch.File
+}
+
+// ParsePatch parses a patch file. The name identifies the patch in
+// errors and diagnostics.
+func ParsePatch(name string, data []byte) (*Patch, error) {
+	f, err := patch.Parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return &Patch{file: f}, nil
+}
+
+// ReadPatch reads and parses the patch file at path.
+func ReadPatch(path string) (*Patch, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatch(path, data)
+}
+
+// Modules returns the names of the modules the patch changes.
+func (p *Patch) Modules() []string {
+	names := make([]string, 0, len(p.file.Blocks))
+	for _, b := range p.file.Blocks {
+		names = append(names, b.Module)
+	}
+	return names
+}
+
+// WithPatches applies patches to the modules they name as they are
+// loaded. Patches are applied in the order given.
+func WithPatches(patches ...*Patch) LoadOption {
+	return func(c *loadConfig) { c.patches = append(c.patches, patches...) }
+}
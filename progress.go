@@ -0,0 +1,114 @@
+package gomib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golangsnmp/gomib/internal/module"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// EventKind identifies a load progress event.
+type EventKind int
+
+const (
+	// EventModuleDiscovered reports a module to be loaded: listed by a
+	// source when loading everything, or requested or imported when
+	// loading by name. When loading everything, all modules are
+	// discovered before any is read, so the count is the total.
+	EventModuleDiscovered EventKind = iota
+	// EventModuleRead reports a module's file was found and read. Path
+	// and Bytes describe it; Duration is the time the source took.
+	EventModuleRead
+	// EventModuleParsed reports a module was parsed and lowered. Duration
+	// is the parse time, and Bytes the file size.
+	EventModuleParsed
+	// EventModuleSkipped reports a module that will not be loaded: Err is
+	// set when it could not be found or read, and nil when its content
+	// did not look like a MIB.
+	EventModuleSkipped
+	// EventPhaseStart reports a resolver phase starting.
+	EventPhaseStart
+	// EventPhaseEnd reports a resolver phase ending. Count is what the
+	// phase produced (see [mib.PhaseFunc]); Duration is its run time.
+	EventPhaseEnd
+	// EventComplete reports the end of loading, and is always the last
+	// event, whether or not Load succeeds. Count is the number of modules
+	// in the result, Duration the total load time, and Err the error Load
+	// returns, if any.
+	EventComplete
+)
+
+// String returns the event kind name, e.g. "module-parsed".
+func (k EventKind) String() string {
+	switch k {
+	case EventModuleDiscovered:
+		return "module-discovered"
+	case EventModuleRead:
+		return "module-read"
+	case EventModuleParsed:
+		return "module-parsed"
+	case EventModuleSkipped:
+		return "module-skipped"
+	case EventPhaseStart:
+		return "phase-start"
+	case EventPhaseEnd:
+		return "phase-end"
+	case EventComplete:
+		return "complete"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a load progress event. Which fields are set depends on Kind.
+type Event struct {
+	Kind     EventKind
+	Module   string // module name, as listed or requested
+	Path     string // file the module was read from
+	Phase    string // resolver phase: register, imports, types, oids, semantics
+	Bytes    int
+	Count    int
+	Duration time.Duration
+	Err      error
+}
+
+// WithProgress calls fn with structured events as loading proceeds:
+// modules discovered, read, parsed or skipped, each resolver phase, and
+// completion. Modules are read in parallel, but calls to fn are
+// serialized, so fn need not be safe for concurrent use. fn should
+// return quickly, since loading waits for it.
+func WithProgress(fn func(Event)) LoadOption {
+	return func(c *loadConfig) {
+		var mu sync.Mutex
+		c.progress = func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			fn(e)
+		}
+	}
+}
+
+func (c *loadConfig) emit(e Event) {
+	if c.progress != nil {
+		c.progress(e)
+	}
+}
+
+// resolveModules resolves mods, reporting resolver phases as progress
+// events.
+func resolveModules(mods []*module.Module, cfg loadConfig) *mib.Mib {
+	var onPhase mib.PhaseFunc
+	if cfg.progress != nil {
+		var start time.Time
+		onPhase = func(phase string, done bool, count int) {
+			if !done {
+				start = time.Now()
+				cfg.emit(Event{Kind: EventPhaseStart, Phase: phase})
+				return
+			}
+			cfg.emit(Event{Kind: EventPhaseEnd, Phase: phase, Count: count, Duration: time.Since(start)})
+		}
+	}
+	return mib.ResolveWithPhases(mods, componentLogger(cfg.logger, "resolver"), &cfg.diagConfig, onPhase)
+}
@@ -0,0 +1,315 @@
+package gomib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFetchTimeout = 10 * time.Second
+	maxRemoteMIBSize    = 16 << 20
+)
+
+// RemoteOption configures a [Remote] source.
+type RemoteOption func(*remoteConfig)
+
+type remoteConfig struct {
+	cacheDir string
+	timeout  time.Duration
+	client   *http.Client
+	offline  bool
+}
+
+// WithCacheDir sets the directory for the on-disk cache. The default is
+// gomib/remote under [os.UserCacheDir]. An empty dir disables the disk
+// cache; modules are then fetched once per source.
+func WithCacheDir(dir string) RemoteOption {
+	return func(c *remoteConfig) { c.cacheDir = dir }
+}
+
+// WithFetchTimeout bounds each HTTP request, including reading the
+// body. The default is 10 seconds.
+func WithFetchTimeout(d time.Duration) RemoteOption {
+	return func(c *remoteConfig) { c.timeout = d }
+}
+
+// WithHTTPClient sets the client used for requests, for proxies or
+// custom TLS settings. Its Timeout is replaced by the fetch timeout.
+func WithHTTPClient(client *http.Client) RemoteOption {
+	return func(c *remoteConfig) { c.client = client }
+}
+
+// WithOffline serves modules from the cache only, without network
+// access.
+func WithOffline() RemoteOption {
+	return func(c *remoteConfig) { c.offline = true }
+}
+
+type remoteSource struct {
+	template string
+	config   remoteConfig
+	client   *http.Client
+
+	mu      sync.Mutex
+	fetched map[string]*remoteFetch
+}
+
+// remoteFetch is the outcome of fetching one module, shared by every
+// Find for it. done is closed once result and err are set.
+type remoteFetch struct {
+	done   chan struct{}
+	result FindResult
+	err    error
+}
+
+// Remote creates a Source that fetches modules by name from an HTTP MIB
+// repository. The URL template must contain {name}, which is replaced
+// by the path-escaped module name, e.g. "https://mibs.example/asn1/{name}".
+//
+// Fetched files are kept in a content-addressed disk cache. Cached
+// modules are revalidated with their ETag, and served from the cache
+// when the repository cannot be reached, so a warm cache works offline.
+// A module the repository does not have, or that cannot be fetched and
+// is not cached, is reported as fs.ErrNotExist (wrapping the cause), so
+// loading continues with other sources. Responses that do not look like
+// a MIB, such as HTML error pages, are rejected the same way.
+//
+// Each module is requested at most once per source, and the outcome,
+// found or not, is remembered; create a new source to retry after a
+// failure. A repository cannot be listed, so ListModules returns
+// nothing: use Remote with [WithModules], typically after local sources.
+func Remote(urlTemplate string, opts ...RemoteOption) (Source, error) {
+	if !strings.Contains(urlTemplate, "{name}") {
+		return nil, fmt.Errorf("remote URL template %q has no {name} placeholder", urlTemplate)
+	}
+	u, err := url.Parse(strings.ReplaceAll(urlTemplate, "{name}", "IF-MIB"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("remote URL template %q is not http or https", urlTemplate)
+	}
+
+	cfg := remoteConfig{timeout: defaultFetchTimeout}
+	if dir, err := os.UserCacheDir(); err == nil {
+		cfg.cacheDir = filepath.Join(dir, "gomib", "remote")
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	client := &http.Client{}
+	if cfg.client != nil {
+		c := *cfg.client
+		client = &c
+	}
+	client.Timeout = cfg.timeout
+
+	return &remoteSource{
+		template: urlTemplate,
+		config:   cfg,
+		client:   client,
+		fetched:  make(map[string]*remoteFetch),
+	}, nil
+}
+
+// MustRemote is like Remote but panics on error.
+func MustRemote(urlTemplate string, opts ...RemoteOption) Source {
+	src, err := Remote(urlTemplate, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return src
+}
+
+// remoteRef records what the cache holds for one URL.
+type remoteRef struct {
+	URL    string `json:"url"`
+	ETag   string `json:"etag,omitempty"`
+	SHA256 string `json:"sha256"`
+}
+
+func (s *remoteSource) Find(name string) (FindResult, error) {
+	if name == "" || strings.ContainsAny(name, "/\\") || name == "." || name == ".." {
+		return FindResult{}, fs.ErrNotExist
+	}
+
+	// Each module is fetched at most once per source, whatever the
+	// outcome: the loader asks again for a missing module from every
+	// module that imports it.
+	s.mu.Lock()
+	f, ok := s.fetched[name]
+	if !ok {
+		f = &remoteFetch{done: make(chan struct{})}
+		s.fetched[name] = f
+	}
+	s.mu.Unlock()
+	if ok {
+		<-f.done
+		return f.result, f.err
+	}
+
+	u := strings.ReplaceAll(s.template, "{name}", url.PathEscape(name))
+	content, err := s.fetch(u)
+	f.result, f.err = FindResult{Content: content, Path: u}, err
+	close(f.done)
+	return f.result, f.err
+}
+
+func (s *remoteSource) fetch(u string) ([]byte, error) {
+	ref := s.readRef(u)
+	cached := func() ([]byte, bool) {
+		if ref == nil {
+			return nil, false
+		}
+		content, err := s.readObject(ref.SHA256)
+		return content, err == nil
+	}
+
+	if s.config.offline {
+		if content, ok := cached(); ok {
+			return content, nil
+		}
+		return nil, fs.ErrNotExist
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if ref != nil && ref.ETag != "" {
+		req.Header.Set("If-None-Match", ref.ETag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if content, ok := cached(); ok {
+			return content, nil
+		}
+		return nil, notFound(u, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		if content, ok := cached(); ok {
+			return content, nil
+		}
+		return nil, notFound(u, errors.New("304 Not Modified without a cached copy"))
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, fs.ErrNotExist
+	case resp.StatusCode != http.StatusOK:
+		if content, ok := cached(); ok {
+			return content, nil
+		}
+		return nil, notFound(u, fmt.Errorf("unexpected status %s", resp.Status))
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteMIBSize+1))
+	if err == nil && len(content) > maxRemoteMIBSize {
+		err = fmt.Errorf("larger than %d bytes", maxRemoteMIBSize)
+	}
+	if err != nil {
+		if content, ok := cached(); ok {
+			return content, nil
+		}
+		return nil, notFound(u, err)
+	}
+	if !looksLikeMIBContent(content) {
+		return nil, notFound(u, errors.New("response is not a MIB module"))
+	}
+	s.store(u, resp.Header.Get("ETag"), content)
+	return content, nil
+}
+
+func notFound(u string, cause error) error {
+	return fmt.Errorf("fetch %s: %w", u, errors.Join(fs.ErrNotExist, cause))
+}
+
+func (s *remoteSource) refPath(u string) string {
+	sum := sha256.Sum256([]byte(u))
+	return filepath.Join(s.config.cacheDir, "refs", hex.EncodeToString(sum[:])+".json")
+}
+
+func (s *remoteSource) objectPath(sum string) string {
+	return filepath.Join(s.config.cacheDir, "objects", sum[:2], sum)
+}
+
+func (s *remoteSource) readRef(u string) *remoteRef {
+	if s.config.cacheDir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.refPath(u))
+	if err != nil {
+		return nil
+	}
+	var ref remoteRef
+	if json.Unmarshal(data, &ref) != nil || ref.URL != u || len(ref.SHA256) != sha256.Size*2 {
+		return nil
+	}
+	return &ref
+}
+
+// readObject returns cached content, verifying it against its hash.
+func (s *remoteSource) readObject(sum string) ([]byte, error) {
+	content, err := os.ReadFile(s.objectPath(sum))
+	if err != nil {
+		return nil, err
+	}
+	got := sha256.Sum256(content)
+	if hex.EncodeToString(got[:]) != sum {
+		return nil, fmt.Errorf("cached object %s is corrupt", sum)
+	}
+	return content, nil
+}
+
+// store writes content and its ref to the cache. Failures only cost a
+// refetch later, so they are ignored.
+func (s *remoteSource) store(u, etag string, content []byte) {
+	if s.config.cacheDir == "" {
+		return
+	}
+	sum := sha256.Sum256(content)
+	ref := remoteRef{URL: u, ETag: etag, SHA256: hex.EncodeToString(sum[:])}
+	data, err := json.Marshal(ref)
+	if err != nil {
+		return
+	}
+	if writeFileAtomic(s.objectPath(ref.SHA256), content) == nil {
+		_ = writeFileAtomic(s.refPath(u), data)
+	}
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func (s *remoteSource) ListModules() ([]string, error) { return nil, nil }
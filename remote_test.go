@@ -0,0 +1,183 @@
+package gomib
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+const remoteTestMIB = `REMOTE-TEST-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    OBJECT-TYPE, Integer32, enterprises FROM SNMPv2-SMI;
+remoteTest OBJECT IDENTIFIER ::= { enterprises 99999 }
+remoteValue OBJECT-TYPE
+    SYNTAX Integer32
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Served over HTTP"
+    ::= { remoteTest 1 }
+END
+`
+
+// mibServer serves REMOTE-TEST-MIB under /asn1/ with an ETag, counting
+// full, conditional and not-found responses.
+type mibServer struct {
+	*httptest.Server
+	full, notModified, notFound atomic.Int32
+}
+
+func newMIBServer(t *testing.T) *mibServer {
+	s := &mibServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/asn1/") {
+		case "REMOTE-TEST-MIB":
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				s.notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			s.full.Add(1)
+			_, _ = w.Write([]byte(remoteTestMIB))
+		case "HTML-MIB":
+			_, _ = w.Write([]byte("<html><body>Not here</body></html>"))
+		default:
+			s.notFound.Add(1)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestRemoteTemplate(t *testing.T) {
+	_, err := Remote("https://mibs.example/asn1/")
+	testutil.Error(t, err, "template without {name}")
+	_, err = Remote("ftp://mibs.example/{name}")
+	testutil.Error(t, err, "non-HTTP template")
+}
+
+func TestRemoteSource(t *testing.T) {
+	srv := newMIBServer(t)
+	cache := t.TempDir()
+	template := srv.URL + "/asn1/{name}"
+
+	src, err := Remote(template, WithCacheDir(cache))
+	testutil.NoError(t, err, "Remote")
+	stdSrc, err := Dir("testdata/corpus/primary/ietf")
+	testutil.NoError(t, err, "Dir")
+
+	m, err := Load(context.Background(), WithSource(stdSrc, src), WithModules("REMOTE-TEST-MIB"))
+	testutil.NoError(t, err, "Load through remote source")
+	testutil.NotNil(t, m.Object("remoteValue"), "remoteValue")
+	testutil.Equal(t, int32(1), srv.full.Load(), "fetched once")
+
+	_, err = src.Find("NO-SUCH-MIB")
+	testutil.True(t, errors.Is(err, fs.ErrNotExist), "404 is not found: %v", err)
+	_, err = src.Find("HTML-MIB")
+	testutil.True(t, errors.Is(err, fs.ErrNotExist), "non-MIB response is not found: %v", err)
+
+	names, err := src.ListModules()
+	testutil.NoError(t, err, "ListModules")
+	testutil.Len(t, names, 0, "remote modules are not listed")
+
+	// A new source revalidates the cached copy with its ETag.
+	src, err = Remote(template, WithCacheDir(cache))
+	testutil.NoError(t, err, "Remote")
+	res, err := src.Find("REMOTE-TEST-MIB")
+	testutil.NoError(t, err, "Find after revalidation")
+	testutil.Equal(t, remoteTestMIB, string(res.Content), "content from cache")
+	testutil.Equal(t, srv.URL+"/asn1/REMOTE-TEST-MIB", res.Path, "path is the URL")
+	testutil.Equal(t, int32(1), srv.full.Load(), "no refetch")
+	testutil.Equal(t, int32(1), srv.notModified.Load(), "conditional request")
+
+	// With the repository gone, the cache still serves.
+	srv.Close()
+	src, err = Remote(template, WithCacheDir(cache))
+	testutil.NoError(t, err, "Remote")
+	res, err = src.Find("REMOTE-TEST-MIB")
+	testutil.NoError(t, err, "Find with server down")
+	testutil.Equal(t, remoteTestMIB, string(res.Content), "content from cache")
+	_, err = src.Find("OTHER-MIB")
+	testutil.True(t, errors.Is(err, fs.ErrNotExist), "unreachable and uncached is not found: %v", err)
+
+	src, err = Remote(template, WithCacheDir(cache), WithOffline())
+	testutil.NoError(t, err, "Remote")
+	_, err = src.Find("REMOTE-TEST-MIB")
+	testutil.NoError(t, err, "Find offline")
+}
+
+func TestRemoteFetchesOnce(t *testing.T) {
+	srv := newMIBServer(t)
+	src, err := Remote(srv.URL+"/asn1/{name}", WithCacheDir(""))
+	testutil.NoError(t, err, "Remote")
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := src.Find("NO-SUCH-MIB")
+			testutil.True(t, errors.Is(err, fs.ErrNotExist), "not found: %v", err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := src.Find("REMOTE-TEST-MIB")
+			testutil.NoError(t, err, "Find")
+		}()
+	}
+	wg.Wait()
+	testutil.Equal(t, int32(1), srv.notFound.Load(), "missing module requested once")
+	testutil.Equal(t, int32(1), srv.full.Load(), "found module requested once")
+}
+
+func TestRemoteCorruptCache(t *testing.T) {
+	srv := newMIBServer(t)
+	cache := t.TempDir()
+	template := srv.URL + "/asn1/{name}"
+
+	src, err := Remote(template, WithCacheDir(cache))
+	testutil.NoError(t, err, "Remote")
+	_, err = src.Find("REMOTE-TEST-MIB")
+	testutil.NoError(t, err, "Find")
+
+	// Truncate the cached object; it must not be served.
+	objects, err := fs.Glob(os.DirFS(cache), "objects/*/*")
+	testutil.NoError(t, err, "Glob")
+	testutil.Len(t, objects, 1, "cached objects")
+	testutil.NoError(t, os.WriteFile(cache+"/"+objects[0], []byte("REMOTE"), 0o644), "corrupt object")
+
+	src, err = Remote(template, WithCacheDir(cache), WithOffline())
+	testutil.NoError(t, err, "Remote")
+	_, err = src.Find("REMOTE-TEST-MIB")
+	testutil.True(t, errors.Is(err, fs.ErrNotExist), "corrupt object is not served: %v", err)
+}
+
+func TestRemoteTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	src, err := Remote(srv.URL+"/{name}", WithCacheDir(""), WithFetchTimeout(50*time.Millisecond))
+	testutil.NoError(t, err, "Remote")
+	start := time.Now()
+	_, err = src.Find("SLOW-MIB")
+	testutil.True(t, errors.Is(err, fs.ErrNotExist), "timed out fetch is not found: %v", err)
+	testutil.True(t, time.Since(start) < 5*time.Second, "fetch bounded by timeout, took %v", time.Since(start))
+}
@@ -0,0 +1,85 @@
+package gomib
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func TestCapabilityApply(t *testing.T) {
+	memFS := fstest.MapFS{
+		"TEST-IF-CAP-MIB.mib": &fstest.MapFile{Data: []byte(`TEST-IF-CAP-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, enterprises FROM SNMPv2-SMI
+    DisplayString FROM SNMPv2-TC
+    AGENT-CAPABILITIES FROM SNMPv2-CONF;
+testIfCap MODULE-IDENTITY
+    LAST-UPDATED "202501010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    ::= { enterprises 99994 }
+testIfAgent AGENT-CAPABILITIES
+    PRODUCT-RELEASE "Test agent"
+    STATUS current
+    DESCRIPTION "Test"
+    SUPPORTS IF-MIB
+        INCLUDES { ifGeneralInformationGroup, linkUpDownNotificationsGroup, noSuchGroup }
+        VARIATION ifAdminStatus
+            SYNTAX INTEGER { up(1), down(2) }
+            WRITE-SYNTAX INTEGER { up(1) }
+            DESCRIPTION "No testing."
+        VARIATION ifAlias
+            SYNTAX DisplayString (SIZE (0..32))
+            DEFVAL { "unnamed" }
+            DESCRIPTION "Short aliases."
+        VARIATION ifLinkUpDownTrapEnable
+            ACCESS not-implemented
+            DESCRIPTION "Always enabled."
+        VARIATION linkDown
+            ACCESS not-implemented
+            DESCRIPTION "Only linkUp is sent."
+        VARIATION ifRcvAddressEntry
+            CREATION-REQUIRES { ifRcvAddressType }
+            DESCRIPTION "Type is required."
+    ::= { testIfCap 1 }
+END
+`)},
+	}
+	m := loadTargetMIB(t, memFS, "IF-MIB", "TEST-IF-CAP-MIB")
+	c := m.Capability("testIfAgent")
+	testutil.NotNil(t, c, "capability")
+
+	v := c.Apply(m)
+	testutil.Len(t, v.Groups, 2, "resolved INCLUDES groups")
+	testutil.SliceEqual(t, []string{"IF-MIB::noSuchGroup"}, v.Unresolved, "unresolved")
+
+	admin := v.Object(m.Object("ifAdminStatus"))
+	testutil.NotNil(t, admin, "ifAdminStatus view")
+	testutil.Equal(t, mib.AccessReadWrite, admin.Access, "ifAdminStatus access")
+	testutil.Len(t, admin.Syntax.Enums, 2, "narrowed SYNTAX enums")
+	testutil.Len(t, admin.WriteSyntax.Enums, 1, "narrowed WRITE-SYNTAX enums")
+	testutil.Len(t, m.Object("ifAdminStatus").EffectiveEnums(), 3, "base object unchanged")
+
+	alias := v.Object(m.Object("ifAlias"))
+	testutil.SliceEqual(t, []mib.Range{{Min: 0, Max: 32}}, alias.Syntax.Sizes, "narrowed size")
+	testutil.Equal(t, `"unnamed"`, alias.DefaultValue.String(), "overridden DEFVAL")
+
+	testutil.False(t, v.Implements(m.Object("ifLinkUpDownTrapEnable")), "not-implemented object")
+	testutil.True(t, v.Implements(m.Object("ifDescr")), "included object")
+	testutil.False(t, v.Implements(m.Object("ifInOctets")), "object outside INCLUDES")
+
+	testutil.False(t, v.Notification(m.Notification("linkDown")).Implemented(), "linkDown")
+	testutil.True(t, v.Notification(m.Notification("linkUp")).Implemented(), "linkUp")
+
+	testutil.Len(t, m.Object("ifAdminStatus").Capabilities(), 1, "varied object")
+	testutil.Len(t, m.Object("ifLinkUpDownTrapEnable").Capabilities(), 1, "ACCESS-only variation")
+	testutil.Len(t, m.Notification("linkDown").Capabilities(), 1, "varied notification")
+	testutil.Len(t, m.Object("ifDescr").Capabilities(), 0, "unvaried object")
+
+	rcv := v.Object(m.Object("ifRcvAddressEntry"))
+	testutil.NotNil(t, rcv, "varied row outside INCLUDES")
+	testutil.SliceEqual(t, []string{"ifRcvAddressType"}, objectNames(rcv.CreationRequires), "CREATION-REQUIRES")
+}
@@ -2,6 +2,7 @@ package gomib
 
 import (
 	"testing"
+	"testing/fstest"
 
 	"github.com/golangsnmp/gomib/internal/testutil"
 	"github.com/golangsnmp/gomib/mib"
@@ -200,3 +201,154 @@ func TestComplianceNodeModule(t *testing.T) {
 	testutil.Equal(t, "SNMPv2-MIB", mod.Name(),
 		"snmpBasicComplianceRev2 node module should be SNMPv2-MIB")
 }
+
+func TestCheckCompliance(t *testing.T) {
+	m := loadTestMIB(t)
+	c := m.Compliance("ifCompliance3")
+	testutil.NotNil(t, c, "ifCompliance3")
+
+	general := []string{
+		"ifIndex", "ifDescr", "ifType", "ifSpeed", "ifPhysAddress", "ifAdminStatus",
+		"ifOperStatus", "ifLastChange", "ifLinkUpDownTrapEnable", "ifConnectorPresent",
+		"ifHighSpeed", "ifName", "ifNumber", "ifAlias", "ifTableLastChange",
+	}
+	walk := func(adminStatus int64, skip string) []mib.VarBind {
+		var vbs []mib.VarBind
+		for _, name := range general {
+			if name == skip {
+				continue
+			}
+			obj := m.Object(name)
+			var value any = int64(1)
+			if name == "ifAdminStatus" {
+				value = adminStatus
+			}
+			arc := uint32(1)
+			if obj.IsScalar() {
+				arc = 0
+			}
+			vbs = append(vbs, mib.VarBind{OID: append(obj.OID(), arc), Value: value})
+		}
+		return vbs
+	}
+
+	r := m.CheckCompliance(c, walk(3, "ifAlias"))
+	testutil.Equal(t, 0, len(r.Unresolved), "unresolved references")
+	testutil.False(t, r.Compliant(), "ifAlias missing and ifAdminStatus testing(3)")
+
+	states := make(map[string]mib.GroupState)
+	for _, g := range r.Groups {
+		states[g.Group.Name()] = g.State()
+		if g.Group.Name() == "ifGeneralInformationGroup" {
+			testutil.True(t, g.Mandatory, "general group is mandatory")
+			testutil.SliceEqual(t, []string{"ifAlias"}, objectNames(g.Missing), "missing objects")
+		}
+	}
+	testutil.Equal(t, mib.GroupPartial, states["ifGeneralInformationGroup"], "general group")
+	testutil.Equal(t, mib.GroupUnverifiable, states["linkUpDownNotificationsGroup"], "notification group")
+	testutil.Equal(t, mib.GroupMissing, states["ifFixedLengthGroup"], "conditional group")
+
+	testutil.Len(t, r.Violations, 1, "violations")
+	testutil.Equal(t, "ifAdminStatus", r.Violations[0].Object.Name(), "violating object")
+
+	r = m.CheckCompliance(c, walk(1, ""))
+	testutil.True(t, r.Compliant(), "complete walk")
+
+	vbs := walk(3, "")
+	for i := range vbs {
+		if vbs[i].OID.HasPrefix(m.Object("ifAdminStatus").OID()) {
+			vbs[i].Value = nil
+		}
+	}
+	r = m.CheckCompliance(c, vbs)
+	testutil.True(t, r.Compliant(), "an undecoded value counts as present and is not checked")
+}
+
+func TestComplianceRequirements(t *testing.T) {
+	t.Run("cross-module", func(t *testing.T) {
+		m := loadTargetMIB(t, nil, "SNMP-NOTIFICATION-MIB")
+		reqs := m.Compliance("snmpNotifyBasicCompliance").Requirements()
+		byName := make(map[string]mib.Requirement)
+		for _, r := range reqs {
+			byName[r.Node.Name()] = r
+		}
+
+		storage, ok := byName["snmpTargetParamsStorageType"]
+		testutil.True(t, ok, "SNMP-TARGET-MIB object resolved in its MODULE scope")
+		testutil.False(t, storage.Conditional, "mandatory group member")
+		testutil.Equal(t, "snmpTargetBasicGroup", storage.Group.Name(), "group")
+		testutil.Equal(t, mib.AccessReadOnly, storage.MinAccess, "MIN-ACCESS")
+		testutil.Len(t, storage.Syntax.Enums, 1, "refined SYNTAX")
+		testutil.NotNil(t, storage.Refinement, "OBJECT clause")
+
+		addr := byName["snmpTargetAddrTAddress"]
+		testutil.Equal(t, mib.AccessReadCreate, addr.MinAccess, "unrefined MIN-ACCESS is MAX-ACCESS")
+		testutil.Nil(t, addr.Refinement, "no OBJECT clause")
+
+		notifyType := byName["snmpNotifyType"]
+		testutil.Equal(t, "snmpNotifyGroup", notifyType.Group.Name(), "this-module group")
+		testutil.Len(t, notifyType.Syntax.Enums, 1, "refined SYNTAX")
+	})
+
+	t.Run("conditional groups", func(t *testing.T) {
+		m := loadTestMIB(t)
+		reqs := m.Compliance("ifCompliance3").Requirements()
+		byName := make(map[string]mib.Requirement)
+		for _, r := range reqs {
+			byName[r.Node.Name()] = r
+		}
+
+		testutil.False(t, byName["linkDown"].Conditional, "mandatory notification")
+		testutil.NotNil(t, byName["linkDown"].Node.Notification(), "notification node")
+
+		admin := byName["ifAdminStatus"]
+		testutil.False(t, admin.Conditional, "in ifGeneralInformationGroup")
+		testutil.Len(t, admin.Syntax.Enums, 2, "refined SYNTAX")
+		testutil.Equal(t, mib.AccessReadOnly, admin.MinAccess, "MIN-ACCESS")
+
+		octets := byName["ifInOctets"]
+		testutil.True(t, octets.Conditional, "only in GROUP clauses")
+		testutil.Equal(t, "ifFixedLengthGroup", octets.Group.Name(), "first requiring group")
+		testutil.Contains(t, octets.Description, "20,000,000", "GROUP description")
+
+		testutil.Equal(t, len(byName), len(reqs), "no duplicates")
+	})
+
+	t.Run("across MODULE clauses", func(t *testing.T) {
+		memFS := fstest.MapFS{
+			"TEST-MULTI-COMPLIANCE-MIB.mib": &fstest.MapFile{Data: []byte(`TEST-MULTI-COMPLIANCE-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, enterprises FROM SNMPv2-SMI
+    MODULE-COMPLIANCE FROM SNMPv2-CONF;
+testMultiCompliance MODULE-IDENTITY
+    LAST-UPDATED "202501010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    ::= { enterprises 99993 }
+testMultiCompliant MODULE-COMPLIANCE
+    STATUS current
+    DESCRIPTION "Test"
+    MODULE IF-MIB
+        GROUP ifGeneralInformationGroup
+        DESCRIPTION "Conditional here."
+    MODULE SNMPv2-MIB
+        MANDATORY-GROUPS { systemGroup }
+    MODULE IF-MIB
+        MANDATORY-GROUPS { ifGeneralInformationGroup }
+    ::= { testMultiCompliance 1 }
+END
+`)},
+		}
+		m := loadTargetMIB(t, memFS, "IF-MIB", "SNMPv2-MIB", "TEST-MULTI-COMPLIANCE-MIB")
+		c := m.Compliance("testMultiCompliant")
+		testutil.NotNil(t, c, "compliance")
+		reqs := c.Requirements()
+
+		testutil.Greater(t, len(reqs), 0, "requirements")
+		testutil.Equal(t, "systemGroup", reqs[0].Group.Name(), "mandatory groups of later clauses come first")
+		for _, r := range reqs {
+			testutil.False(t, r.Conditional, "%s is in a mandatory group of another clause", r.Node.Name())
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package gomib
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	memFS := fstest.MapFS{
+		"TEST-DEP-A-MIB.mib": &fstest.MapFile{Data: []byte(`TEST-DEP-A-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, enterprises FROM SNMPv2-SMI
+    testDepB FROM TEST-DEP-B-MIB;
+testDepA MODULE-IDENTITY
+    LAST-UPDATED "202501010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    ::= { testDepB 1 }
+END
+`)},
+		"TEST-DEP-B-MIB.mib": &fstest.MapFile{Data: []byte(`TEST-DEP-B-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, enterprises FROM SNMPv2-SMI
+    AcmeThing FROM ACME-MISSING-MIB;
+testDepB MODULE-IDENTITY
+    LAST-UPDATED "202501010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    ::= { enterprises 99993 }
+END
+`)},
+	}
+	m := loadTargetMIB(t, memFS, "TEST-DEP-A-MIB")
+	g := m.DependencyGraph()
+
+	testutil.SliceEqual(t, []string{"SNMPv2-SMI", "TEST-DEP-B-MIB"}, g.Dependencies("TEST-DEP-A-MIB"), "direct dependencies")
+	testutil.SliceEqual(t, []string{"TEST-DEP-A-MIB"}, g.Dependents("TEST-DEP-B-MIB"), "direct dependents")
+	testutil.True(t, slices.Contains(g.TransitiveDependencies("TEST-DEP-A-MIB"), "ACME-MISSING-MIB"), "transitive closure")
+	testutil.True(t, slices.Contains(g.TransitiveDependents("SNMPv2-SMI"), "TEST-DEP-A-MIB"), "reverse closure")
+
+	testutil.SliceEqual(t, []string{"ACME-MISSING-MIB"}, g.Missing(), "missing modules")
+	testutil.SliceEqual(t, []string{"AcmeThing"}, g.UnresolvedImports("TEST-DEP-B-MIB"), "unresolved imports")
+	testutil.SliceEqual(t,
+		[]string{"TEST-DEP-A-MIB", "TEST-DEP-B-MIB", "ACME-MISSING-MIB"},
+		g.Path("TEST-DEP-A-MIB", "ACME-MISSING-MIB"), "chain to the missing module")
+	testutil.Nil(t, g.Path("SNMPv2-SMI", "TEST-DEP-A-MIB"), "no reverse path")
+
+	order := g.Order()
+	testutil.True(t, slices.Index(order, "TEST-DEP-B-MIB") < slices.Index(order, "TEST-DEP-A-MIB"), "dependencies first")
+	testutil.Len(t, g.Cycles(), 0, "no cycles")
+}
@@ -0,0 +1,143 @@
+package gomib
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+)
+
+func TestDiscriminatorInetAddress(t *testing.T) {
+	m := loadTestMIB(t)
+
+	tests := []struct {
+		object string
+		want   string
+	}{
+		{"ipAddressAddr", "ipAddressAddrType"},
+		{"ipNetToPhysicalNetAddress", "ipNetToPhysicalNetAddressType"},
+		{"ipAddressPrefixPrefix", "ipAddressPrefixType"},
+		{"ipAddressPrefixLength", "ipAddressPrefixPrefix"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.object, func(t *testing.T) {
+			obj := m.Object(tt.object)
+			testutil.NotNil(t, obj, "Object(%s)", tt.object)
+			d := obj.Discriminator()
+			testutil.NotNil(t, d, "%s should have a discriminator", tt.object)
+			testutil.Equal(t, tt.want, d.Name(), "discriminator of %s", tt.object)
+		})
+	}
+
+	testutil.Nil(t, m.Object("ipAddressAddrType").Discriminator(), "InetAddressType has no discriminator")
+	testutil.Nil(t, m.Object("ifIndex").Discriminator(), "ifIndex has no discriminator")
+}
+
+func TestDiscriminatorTAddress(t *testing.T) {
+	src, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+	m, err := Load(context.Background(), WithSource(src), WithModules("SNMP-TARGET-MIB"))
+	testutil.NoError(t, err, "Load SNMP-TARGET-MIB")
+
+	d := m.Object("snmpTargetAddrTAddress").Discriminator()
+	testutil.NotNil(t, d, "snmpTargetAddrTAddress discriminator")
+	testutil.Equal(t, "snmpTargetAddrTDomain", d.Name(), "discriminator")
+}
+
+func TestDiscriminatorTransportAddress(t *testing.T) {
+	// A minimal stand-in for RFC 3419's TRANSPORT-ADDRESS-MIB, plus a
+	// table with two address columns to exercise name-prefix pairing.
+	memFS := fstest.MapFS{
+		"TRANSPORT-ADDRESS-MIB.mib": &fstest.MapFile{Data: []byte(`TRANSPORT-ADDRESS-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, mib-2 FROM SNMPv2-SMI
+    TEXTUAL-CONVENTION FROM SNMPv2-TC;
+transportAddressMIB MODULE-IDENTITY
+    LAST-UPDATED "200211010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    ::= { mib-2 100 }
+TransportAddressType ::= TEXTUAL-CONVENTION
+    STATUS current
+    DESCRIPTION "Transport address type."
+    SYNTAX INTEGER { unknown(0), udpIpv4(1), udpIpv6(2) }
+TransportAddress ::= TEXTUAL-CONVENTION
+    STATUS current
+    DESCRIPTION "Transport address."
+    SYNTAX OCTET STRING (SIZE (0..255))
+END
+`)},
+		"TEST-TRANSPORT-MIB.mib": &fstest.MapFile{Data: []byte(`TEST-TRANSPORT-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, OBJECT-TYPE, Integer32, enterprises FROM SNMPv2-SMI
+    TransportAddressType, TransportAddress FROM TRANSPORT-ADDRESS-MIB;
+testTransportMIB MODULE-IDENTITY
+    LAST-UPDATED "202501010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    ::= { enterprises 99996 }
+testPeerTable OBJECT-TYPE
+    SYNTAX SEQUENCE OF TestPeerEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Peers."
+    ::= { testTransportMIB 1 }
+testPeerEntry OBJECT-TYPE
+    SYNTAX TestPeerEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "A peer."
+    INDEX { testPeerIndex }
+    ::= { testPeerTable 1 }
+TestPeerEntry ::= SEQUENCE {
+    testPeerIndex Integer32,
+    testPeerLocalType TransportAddressType,
+    testPeerLocal TransportAddress,
+    testPeerRemoteType TransportAddressType,
+    testPeerRemote TransportAddress
+}
+testPeerIndex OBJECT-TYPE
+    SYNTAX Integer32 (1..100)
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Index."
+    ::= { testPeerEntry 1 }
+testPeerLocalType OBJECT-TYPE
+    SYNTAX TransportAddressType
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Local type."
+    ::= { testPeerEntry 2 }
+testPeerLocal OBJECT-TYPE
+    SYNTAX TransportAddress
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Local address."
+    ::= { testPeerEntry 3 }
+testPeerRemoteType OBJECT-TYPE
+    SYNTAX TransportAddressType
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Remote type."
+    ::= { testPeerEntry 4 }
+testPeerRemote OBJECT-TYPE
+    SYNTAX TransportAddress
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Remote address."
+    ::= { testPeerEntry 5 }
+END
+`)},
+	}
+	corpus, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+	m, err := Load(context.Background(),
+		WithSource(Multi(FS("test", memFS), corpus)), WithModules("TEST-TRANSPORT-MIB"))
+	testutil.NoError(t, err, "Load TEST-TRANSPORT-MIB")
+
+	testutil.Equal(t, "testPeerLocalType", m.Object("testPeerLocal").Discriminator().Name(), "local")
+	testutil.Equal(t, "testPeerRemoteType", m.Object("testPeerRemote").Discriminator().Name(), "remote")
+}
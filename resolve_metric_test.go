@@ -0,0 +1,87 @@
+package gomib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func TestObjectMetric(t *testing.T) {
+	m := loadTestMIB(t)
+
+	tests := []struct {
+		object string
+		kind   mib.MetricKind
+		bits   int
+		twin   string
+	}{
+		{"ifInOctets", mib.MetricCounter, 32, "ifHCInOctets"},
+		{"ifHCInOctets", mib.MetricCounter, 64, "ifInOctets"},
+		{"ifHCOutUcastPkts", mib.MetricCounter, 64, "ifOutUcastPkts"},
+		{"ipIfStatsHCInReceives", mib.MetricCounter, 64, "ipIfStatsInReceives"},
+		{"ifInErrors", mib.MetricCounter, 32, ""},
+		{"ifSpeed", mib.MetricGauge, 0, ""},
+		{"ifMtu", mib.MetricGauge, 0, ""},
+		{"ifOperStatus", mib.MetricEnum, 0, ""},
+		{"ifLastChange", mib.MetricTimeTicks, 0, ""},
+		{"sysUpTime", mib.MetricTimeTicks, 0, ""},
+		{"ifDescr", mib.MetricInfo, 0, ""},
+		{"ifPhysAddress", mib.MetricInfo, 0, ""},
+		{"ifIndex", mib.MetricIndex, 0, ""},
+		{"ifTable", mib.MetricNone, 0, ""},
+		{"ifEntry", mib.MetricNone, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.object, func(t *testing.T) {
+			obj := m.Object(tt.object)
+			testutil.NotNil(t, obj, "Object(%s)", tt.object)
+			got := obj.Metric()
+			testutil.Equal(t, tt.kind, got.Kind, "kind")
+			testutil.Equal(t, tt.bits, got.Bits, "bits")
+			twin := ""
+			if got.Twin != nil {
+				twin = got.Twin.Name()
+			}
+			testutil.Equal(t, tt.twin, twin, "twin")
+		})
+	}
+
+	testutil.Equal(t, "seconds", m.Object("ipReasmTimeout").Metric().Units, "units")
+}
+
+func TestCounterTwinsByKind(t *testing.T) {
+	src, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+	m, err := Load(context.Background(), WithSource(src), WithModules("HC-RMON-MIB", "RMON2-MIB", "RMON-MIB"))
+	testutil.NoError(t, err, "Load")
+
+	tests := []struct {
+		object string
+		twin   string
+	}{
+		// ZeroBasedCounter64 paired with RMON2 ZeroBasedCounter32.
+		{"protocolDistStatsHighCapacityPkts", "protocolDistStatsPkts"},
+		{"protocolDistStatsPkts", "protocolDistStatsHighCapacityPkts"},
+		{"nlHostHighCapacityInPkts", "nlHostInPkts"},
+		// CounterBasedGauge64 is a gauge, not the twin of a Counter32.
+		{"etherHistoryHighCapacityPkts", ""},
+		{"etherHistoryPkts", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.object, func(t *testing.T) {
+			obj := m.Object(tt.object)
+			testutil.NotNil(t, obj, "Object(%s)", tt.object)
+			twin := ""
+			if got := obj.Metric().Twin; got != nil {
+				twin = got.Name()
+			}
+			testutil.Equal(t, tt.twin, twin, "Metric().Twin")
+		})
+	}
+
+	var nilObj *mib.Object
+	testutil.Nil(t, nilObj.CounterTwin(), "nil CounterTwin")
+	testutil.Nil(t, nilObj.Discriminator(), "nil Discriminator")
+}
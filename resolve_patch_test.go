@@ -0,0 +1,136 @@
+package gomib
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// brokenVendorMIB has the usual vendor problems: a missing import, an
+// underscore in an identifier, an object under the wrong parent, a bad
+// SYNTAX, a DEFVAL that is not a valid label, and a stray definition
+// that references an undefined parent.
+const brokenVendorMIB = `TEST-PATCH-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, OBJECT-TYPE, Integer32, enterprises FROM SNMPv2-SMI;
+testPatch MODULE-IDENTITY
+    LAST-UPDATED "202501010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    REVISION "202501010000Z"
+    DESCRIPTION "Initial"
+    ::= { enterprises 99990 }
+testPatchObjects OBJECT IDENTIFIER ::= { testPatch 1 }
+test_patchName OBJECT-TYPE
+    SYNTAX DisplayString (SIZE (0..32))
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Name"
+    ::= { testPatchObjects 1 }
+testPatchMode OBJECT-TYPE
+    SYNTAX Integer32
+    MAX-ACCESS read-write
+    STATUS current
+    DESCRIPTION "Mode"
+    DEFVAL { auto }
+    ::= { testPatchObjectz 2 }
+testPatchStray OBJECT-TYPE
+    SYNTAX Integer32
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Stray"
+    ::= { noSuchParent 3 }
+END
+`
+
+const vendorFixes = `# Fixes for TEST-PATCH-MIB.
+module TEST-PATCH-MIB
+    import DisplayString FROM SNMPv2-TC
+    rename test_patchName testPatchName
+    oid testPatchMode { testPatchObjects 2 }
+    syntax testPatchMode INTEGER {
+        auto(1),
+        manual(2)
+    }
+    defval testPatchMode { manual }
+    drop testPatchStray
+`
+
+func TestPatchFixesVendorMIB(t *testing.T) {
+	src := MapSource(map[string][]byte{"TEST-PATCH-MIB": []byte(brokenVendorMIB)})
+	p, err := ParsePatch("fixes.patch", []byte(vendorFixes))
+	testutil.NoError(t, err, "ParsePatch")
+	testutil.SliceEqual(t, []string{"TEST-PATCH-MIB"}, p.Modules(), "patched modules")
+
+	ctx := context.Background()
+	m, _ := Load(ctx, WithSource(src), WithModules("TEST-PATCH-MIB"), WithStrictness(mib.StrictnessStrict))
+	testutil.Greater(t, countProblems(m), 0, "unpatched module has problems")
+	testutil.True(t, slices.ContainsFunc(m.Diagnostics(), func(d mib.Diagnostic) bool {
+		return d.Code == "identifier-underscore"
+	}), "unpatched identifier-underscore")
+
+	for range 2 { // patches are reusable across loads
+		m, err := Load(ctx, WithSource(src), WithModules("TEST-PATCH-MIB"),
+			WithStrictness(mib.StrictnessStrict), WithPatches(p))
+		testutil.NoError(t, err, "patched module loads at strict level")
+
+		var applied int
+		for _, d := range m.Diagnostics() {
+			if d.Code == "patch-applied" {
+				applied++
+				testutil.Equal(t, mib.SeverityInfo, d.Severity, "patch-applied severity")
+			}
+			if d.Code == "identifier-underscore" {
+				t.Errorf("renamed identifier still reported: %s", d)
+			}
+		}
+		testutil.Equal(t, 6, applied, "one diagnostic per directive")
+		testutil.Equal(t, 0, countProblems(m), "problems after patching")
+		testutil.Len(t, m.Unresolved(), 0, "unresolved after patching")
+
+		name := m.Object("testPatchName")
+		testutil.NotNil(t, name, "renamed object")
+		testutil.Nil(t, m.Object("test_patchName"), "old name")
+		testutil.Equal(t, "DisplayString", name.Type().Name(), "added import resolves")
+
+		mode := m.Object("testPatchMode")
+		testutil.NotNil(t, mode, "moved object")
+		testutil.Equal(t, "1.3.6.1.4.1.99990.1.2", mode.OID().String(), "new parent")
+		testutil.Len(t, mode.EffectiveEnums(), 2, "patched SYNTAX")
+		testutil.Equal(t, "manual", mode.DefaultValue().String(), "patched DEFVAL")
+
+		testutil.Nil(t, m.Object("testPatchStray"), "dropped definition")
+	}
+}
+
+func TestPatchUnmatched(t *testing.T) {
+	src := MapSource(map[string][]byte{"TEST-PATCH-MIB": []byte(brokenVendorMIB)})
+	p, err := ParsePatch("stale.patch", []byte("module TEST-PATCH-MIB\n    drop testPatchGone\n"))
+	testutil.NoError(t, err, "ParsePatch")
+
+	m, _ := Load(context.Background(), WithSource(src), WithModules("TEST-PATCH-MIB"), WithPatches(p))
+	var found bool
+	for _, d := range m.Diagnostics() {
+		if d.Code == "patch-unmatched" {
+			found = true
+			testutil.True(t, strings.Contains(d.Message, "stale.patch:2"), "message names the directive: %s", d.Message)
+		}
+	}
+	testutil.True(t, found, "patch-unmatched diagnostic")
+}
+
+// countProblems counts diagnostics of minor severity or worse.
+func countProblems(m *mib.Mib) int {
+	n := 0
+	for _, d := range m.Diagnostics() {
+		if d.Severity <= mib.SeverityMinor {
+			n++
+		}
+	}
+	return n
+}
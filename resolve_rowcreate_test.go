@@ -0,0 +1,146 @@
+package gomib
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func objectNames(objs []*mib.Object) []string {
+	names := make([]string, len(objs))
+	for i, o := range objs {
+		names[i] = o.Name()
+	}
+	return names
+}
+
+func loadTargetMIB(t *testing.T, extra fstest.MapFS, modules ...string) *mib.Mib {
+	t.Helper()
+	corpus, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+	src := corpus
+	if extra != nil {
+		src = Multi(FS("test", extra), corpus)
+	}
+	m, err := Load(context.Background(), WithSource(src), WithModules(modules...))
+	testutil.NoError(t, err, "Load")
+	return m
+}
+
+func TestRowCreation(t *testing.T) {
+	m := loadTargetMIB(t, nil, "SNMP-TARGET-MIB")
+
+	rc := m.Object("snmpTargetAddrTable").RowCreation()
+	testutil.NotNil(t, rc, "snmpTargetAddrTable should be creatable")
+	testutil.Equal(t, "snmpTargetAddrEntry", rc.Row.Name(), "row")
+	testutil.Equal(t, "snmpTargetAddrRowStatus", rc.RowStatus.Name(), "RowStatus column")
+	testutil.Equal(t, "snmpTargetAddrStorageType", rc.StorageType.Name(), "StorageType column")
+	testutil.SliceEqual(t,
+		[]string{"snmpTargetAddrTDomain", "snmpTargetAddrTAddress", "snmpTargetAddrParams"},
+		objectNames(rc.Required), "required columns")
+	testutil.SliceEqual(t,
+		[]string{"snmpTargetAddrTimeout", "snmpTargetAddrRetryCount", "snmpTargetAddrTagList", "snmpTargetAddrStorageType"},
+		objectNames(rc.Optional), "optional columns")
+
+	testutil.Equal(t, rc.Row, m.Object("snmpTargetAddrTAddress").RowCreation().Row, "from column")
+	testutil.Nil(t, m.Object("snmpTargetSpinLock").RowCreation(), "scalar")
+}
+
+func TestRowCreationPlan(t *testing.T) {
+	m := loadTargetMIB(t, nil, "SNMP-TARGET-MIB")
+	rc := m.Object("snmpTargetAddrEntry").RowCreation()
+	instance := mib.OID{3, 'a', 'b', 'c'}
+	values := map[string]any{
+		"snmpTargetAddrTDomain":  mib.OID{1, 3, 6, 1, 6, 1, 1},
+		"snmpTargetAddrTAddress": []byte{192, 0, 2, 1, 0, 162},
+		"snmpTargetAddrParams":   "p",
+		"snmpTargetAddrTimeout":  300,
+	}
+
+	t.Run("createAndGo", func(t *testing.T) {
+		plan, err := rc.Plan(instance, mib.RowStatusCreateAndGo, values)
+		testutil.NoError(t, err, "Plan")
+		testutil.Len(t, plan.Steps, 1, "steps")
+		step := plan.Steps[0]
+		testutil.Len(t, step, 5, "varbinds")
+		testutil.Equal(t, "snmpTargetAddrTDomain", step[0].Object.Name(), "first varbind")
+		testutil.Equal(t, "1.3.6.1.6.3.12.1.2.1.2.3.97.98.99", step[0].OID.String(), "instance OID")
+		testutil.Equal(t, rc.RowStatus, step[4].Object, "RowStatus last")
+		testutil.Equal(t, any(4), step[4].Value, "createAndGo value")
+	})
+
+	t.Run("createAndWait", func(t *testing.T) {
+		plan, err := rc.Plan(instance, mib.RowStatusCreateAndWait, values)
+		testutil.NoError(t, err, "Plan")
+		testutil.Len(t, plan.Steps, 3, "steps")
+		testutil.Equal(t, any(5), plan.Steps[0][0].Value, "createAndWait")
+		testutil.Len(t, plan.Steps[1], 4, "column values")
+		testutil.Equal(t, any(1), plan.Steps[2][0].Value, "active")
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := rc.Plan(instance, mib.RowStatusCreateAndGo, map[string]any{"snmpTargetAddrParams": "p"})
+		testutil.True(t, errors.Is(err, mib.ErrRowIncomplete), "want ErrRowIncomplete, got %v", err)
+		testutil.Contains(t, err.Error(), "snmpTargetAddrTDomain", "error names missing column")
+	})
+
+	t.Run("not writable", func(t *testing.T) {
+		_, err := rc.Plan(instance, mib.RowStatusCreateAndGo, map[string]any{"snmpTargetAddrRowStatus": 4})
+		testutil.Error(t, err, "RowStatus in values")
+	})
+
+	t.Run("bad mode", func(t *testing.T) {
+		_, err := rc.Plan(instance, mib.RowStatusActive, values)
+		testutil.Error(t, err, "active is not a creation mode")
+	})
+}
+
+func TestRowCreationWithCapability(t *testing.T) {
+	memFS := fstest.MapFS{
+		"TEST-TARGET-CAP-MIB.mib": &fstest.MapFile{Data: []byte(`TEST-TARGET-CAP-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    MODULE-IDENTITY, enterprises FROM SNMPv2-SMI
+    AGENT-CAPABILITIES FROM SNMPv2-CONF;
+testTargetCap MODULE-IDENTITY
+    LAST-UPDATED "202501010000Z"
+    ORGANIZATION "Test"
+    CONTACT-INFO "Test"
+    DESCRIPTION "Test"
+    ::= { enterprises 99995 }
+testTargetAgent AGENT-CAPABILITIES
+    PRODUCT-RELEASE "Test agent"
+    STATUS current
+    DESCRIPTION "Test"
+    SUPPORTS SNMP-TARGET-MIB
+        INCLUDES { snmpTargetBasicGroup }
+        VARIATION snmpTargetAddrEntry
+            CREATION-REQUIRES { snmpTargetAddrTagList }
+            DESCRIPTION "Tag list must be supplied."
+        VARIATION snmpTargetAddrParams
+            DEFVAL { "default" }
+            DESCRIPTION "Params default."
+        VARIATION snmpTargetAddrRetryCount
+            ACCESS read-only
+            DESCRIPTION "Fixed retry count."
+    ::= { testTargetCap 1 }
+END
+`)},
+	}
+	m := loadTargetMIB(t, memFS, "SNMP-TARGET-MIB", "TEST-TARGET-CAP-MIB")
+
+	rc := m.Object("snmpTargetAddrEntry").RowCreation()
+	testutil.NotNil(t, rc, "RowCreation")
+	refined := rc.WithCapability(m.Capability("testTargetAgent"))
+
+	testutil.SliceEqual(t,
+		[]string{"snmpTargetAddrTDomain", "snmpTargetAddrTAddress", "snmpTargetAddrTagList"},
+		objectNames(refined.Required), "required columns")
+	testutil.SliceEqual(t,
+		[]string{"snmpTargetAddrTimeout", "snmpTargetAddrParams", "snmpTargetAddrStorageType"},
+		objectNames(refined.Optional), "optional columns")
+	testutil.Len(t, rc.Required, 3, "original unchanged")
+}
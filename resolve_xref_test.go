@@ -0,0 +1,78 @@
+package gomib
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func moduleNames(mods []*mib.Module) []string {
+	names := make([]string, len(mods))
+	for i, mod := range mods {
+		names[i] = mod.Name()
+	}
+	return names
+}
+
+func TestCrossReferences(t *testing.T) {
+	m := loadTestMIB(t)
+
+	t.Run("type users", func(t *testing.T) {
+		users := objectNames(m.Module("SNMPv2-TC").Type("DisplayString").Users())
+		testutil.True(t, slices.Contains(users, "ifDescr"), "direct user")
+		testutil.True(t, slices.Contains(users, "ifAlias"), "user through an inline SIZE refinement")
+		testutil.False(t, slices.Contains(users, "ifIndex"), "unrelated object")
+
+		octets := objectNames(m.Type("OCTET STRING").Users())
+		testutil.True(t, slices.Contains(octets, "ifDescr"), "user through a derived TC")
+	})
+
+	t.Run("object references", func(t *testing.T) {
+		ifIndex := m.Object("ifIndex")
+
+		var groups []string
+		for _, g := range ifIndex.Groups() {
+			groups = append(groups, g.Name())
+		}
+		testutil.True(t, slices.Contains(groups, "ifGeneralInformationGroup"), "group membership")
+
+		var notifs []string
+		for _, n := range ifIndex.Notifications() {
+			notifs = append(notifs, n.Name())
+		}
+		testutil.True(t, slices.Contains(notifs, "linkDown"), "carried by linkDown")
+
+		rows := objectNames(ifIndex.IndexedRows())
+		testutil.True(t, slices.Contains(rows, "ifEntry"), "INDEX of ifEntry")
+		testutil.True(t, slices.Contains(rows, "ifXEntry"), "inherited by AUGMENTS")
+	})
+
+	t.Run("group compliances", func(t *testing.T) {
+		var names []string
+		for _, c := range m.Group("ifGeneralInformationGroup").Compliances() {
+			names = append(names, c.Name())
+		}
+		testutil.True(t, slices.Contains(names, "ifCompliance3"), "mandatory group")
+
+		names = nil
+		for _, c := range m.Group("ifFixedLengthGroup").Compliances() {
+			names = append(names, c.Name())
+		}
+		testutil.True(t, slices.Contains(names, "ifCompliance3"), "conditional group")
+
+		var linkGroups []string
+		for _, g := range m.Notification("linkDown").Groups() {
+			linkGroups = append(linkGroups, g.Name())
+		}
+		testutil.True(t, slices.Contains(linkGroups, "linkUpDownNotificationsGroup"), "notification group")
+	})
+
+	t.Run("importers", func(t *testing.T) {
+		tc := m.Module("SNMPv2-TC")
+		testutil.True(t, slices.Contains(moduleNames(tc.Importers()), "IF-MIB"), "IF-MIB imports SNMPv2-TC")
+		testutil.True(t, slices.Contains(moduleNames(tc.SymbolImporters("DisplayString")), "IF-MIB"), "symbol importer")
+		testutil.Len(t, tc.SymbolImporters("NoSuchSymbol"), 0, "unknown symbol")
+	})
+}
@@ -0,0 +1,78 @@
+package gomib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golangsnmp/gomib/internal/module"
+	"github.com/golangsnmp/gomib/internal/snapshot"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// WriteSnapshot finds and parses the modules that opts select, exactly
+// as [Load] does, and writes them to w in a form [LoadSnapshot] resolves
+// without reading or parsing any MIB file. Patches are applied before
+// the modules are written. Parse diagnostics are filtered by the
+// strictness given here; resolution, and its diagnostics, happen when
+// the snapshot is loaded.
+//
+// A snapshot is tied to the gomib version that wrote it. Regenerate it
+// when upgrading; LoadSnapshot rejects one in an older format.
+//
+// Requested modules that are not found are reported as
+// [ErrMissingModules], and nothing is written.
+func WriteSnapshot(ctx context.Context, w io.Writer, opts ...LoadOption) error {
+	cfg := newLoadConfig(opts)
+	modules, err := parseModules(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, name := range cfg.modules {
+		if modules[name] == nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingModules, strings.Join(missing, ", "))
+	}
+
+	// Base modules are built in, and added back when the snapshot is
+	// loaded, unless a source supplied its own copy.
+	var mods []*module.Module
+	for _, mod := range collectModules(modules) {
+		if mod != module.GetBaseModule(mod.Name) {
+			mods = append(mods, mod)
+		}
+	}
+	return snapshot.Encode(w, mods)
+}
+
+// LoadSnapshot resolves the modules in a snapshot written by
+// [WriteSnapshot]. The module set is fixed by the snapshot, so sources,
+// [WithModules], [WithSystemPaths] and [WithPatches] have no effect;
+// logging, strictness and progress options apply as they do for [Load].
+// No [EventModuleRead] or [EventModuleParsed] events are reported.
+func LoadSnapshot(ctx context.Context, r io.Reader, opts ...LoadOption) (*mib.Mib, error) {
+	return runLoad(newLoadConfig(opts), func(cfg loadConfig) (*mib.Mib, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		mods, err := snapshot.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("load snapshot: %w", err)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		modules := make(map[string]*module.Module, len(mods))
+		for _, mod := range mods {
+			modules[mod.Name] = mod
+		}
+		m := resolveModules(collectModules(modules), cfg)
+		return m, checkLoadResult(m, cfg, nil)
+	})
+}
@@ -0,0 +1,113 @@
+package gomib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping corpus load in short mode")
+	}
+
+	src, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+	opts := []LoadOption{WithSource(src), WithStrictness(mib.StrictnessPermissive)}
+
+	want, err := Load(context.Background(), opts...)
+	testutil.NoError(t, err, "Load")
+
+	var buf bytes.Buffer
+	testutil.NoError(t, WriteSnapshot(context.Background(), &buf, opts...), "WriteSnapshot")
+
+	var events []Event
+	got, err := LoadSnapshot(context.Background(), bytes.NewReader(buf.Bytes()),
+		WithStrictness(mib.StrictnessPermissive),
+		WithProgress(func(e Event) { events = append(events, e) }))
+	testutil.NoError(t, err, "LoadSnapshot")
+
+	counts := make(map[EventKind]int)
+	for _, e := range events {
+		counts[e.Kind]++
+	}
+	testutil.Equal(t, 0, counts[EventModuleRead], "modules read")
+	testutil.Equal(t, 0, counts[EventModuleParsed], "modules parsed")
+	testutil.Equal(t, 5, counts[EventPhaseEnd], "resolver phases")
+	testutil.Equal(t, EventComplete, events[len(events)-1].Kind, "last event")
+
+	testutil.SliceEqual(t, moduleNames(want.Modules()), moduleNames(got.Modules()), "modules")
+	testutil.Equal(t, len(want.Objects()), len(got.Objects()), "objects")
+	testutil.Equal(t, len(want.Types()), len(got.Types()), "types")
+	testutil.Equal(t, len(want.Notifications()), len(got.Notifications()), "notifications")
+	for _, obj := range want.Objects() {
+		other := got.Module(obj.Module().Name()).Object(obj.Name())
+		if other == nil {
+			t.Errorf("%s::%s missing from snapshot", obj.Module().Name(), obj.Name())
+			continue
+		}
+		testutil.Equal(t, obj.OID().String(), other.OID().String(), "%s OID", obj.Name())
+		testutil.Equal(t, obj.Type().String(), other.Type().String(), "%s type", obj.Name())
+		testutil.Equal(t, obj.Description(), other.Description(), "%s description", obj.Name())
+		testutil.Equal(t, obj.DefaultValue().String(), other.DefaultValue().String(), "%s DEFVAL", obj.Name())
+	}
+
+	// The resolver reports diagnostics in no particular order.
+	testutil.SliceEqual(t, diagnosticStrings(want), diagnosticStrings(got), "diagnostics")
+}
+
+func diagnosticStrings(m *mib.Mib) []string {
+	var s []string
+	for _, d := range m.Diagnostics() {
+		s = append(s, d.String())
+	}
+	slices.Sort(s)
+	return s
+}
+
+func TestSnapshotModules(t *testing.T) {
+	src, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+
+	var buf bytes.Buffer
+	err = WriteSnapshot(context.Background(), &buf, WithSource(src), WithModules("IF-MIB"))
+	testutil.NoError(t, err, "WriteSnapshot")
+	m, err := LoadSnapshot(context.Background(), &buf)
+	testutil.NoError(t, err, "LoadSnapshot")
+	testutil.NotNil(t, m.Object("ifIndex"), "ifIndex")
+	testutil.NotNil(t, m.Module("SNMPv2-SMI"), "base module added back")
+	testutil.Nil(t, m.Module("IP-MIB"), "only the closure is written")
+
+	buf.Reset()
+	err = WriteSnapshot(context.Background(), &buf, WithSource(src), WithModules("IF-MIB", "NO-SUCH-MIB"))
+	testutil.True(t, errors.Is(err, ErrMissingModules), "missing module: %v", err)
+	testutil.Equal(t, 0, buf.Len(), "nothing written")
+}
+
+func TestLoadSnapshotInvalid(t *testing.T) {
+	src, err := DirTree("testdata/corpus/primary")
+	testutil.NoError(t, err, "DirTree")
+	var buf bytes.Buffer
+	testutil.NoError(t, WriteSnapshot(context.Background(), &buf, WithSource(src), WithModules("SNMPv2-MIB")), "WriteSnapshot")
+	data := buf.Bytes()
+
+	for _, tt := range []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"MIB source", []byte("IF-MIB DEFINITIONS ::= BEGIN END")},
+		{"truncated", data[:len(data)/2]},
+		{"trailing data", append(bytes.Clone(data), 0)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSnapshot(context.Background(), bytes.NewReader(tt.data))
+			testutil.Error(t, err, "LoadSnapshot")
+		})
+	}
+}
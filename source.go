@@ -27,7 +27,8 @@ type FindResult struct {
 // Source provides access to MIB files for the loading pipeline.
 // Implementations are passed to [WithSource] and searched in order
 // during [Load] to locate module files by name. The standard
-// implementations are [Dir], [DirTree], [FS], and [Multi].
+// implementations are [Dir], [DirTree], [FS], [MapSource], [Multi] and
+// [Overlay].
 type Source interface {
 	// Find returns the MIB content for the named module,
 	// or fs.ErrNotExist if the module is not available.
@@ -290,6 +291,128 @@ func (s *multiSource) ListModules() ([]string, error) {
 	return names, nil
 }
 
+type mapSource struct {
+	modules map[string][]byte
+}
+
+// MapSource creates a Source over module content held in memory, keyed
+// by module name, such as modules uploaded through an API or written
+// inline in tests. The map is copied; the byte slices are not and must
+// not be modified while the source is in use. Paths are reported as
+// "mem:NAME".
+func MapSource(modules map[string][]byte) Source {
+	return &mapSource{modules: maps.Clone(modules)}
+}
+
+func (s *mapSource) Find(name string) (FindResult, error) {
+	content, ok := s.modules[name]
+	if !ok {
+		return FindResult{}, fs.ErrNotExist
+	}
+	return FindResult{Content: content, Path: "mem:" + name}, nil
+}
+
+func (s *mapSource) ListModules() ([]string, error) {
+	return slices.Sorted(maps.Keys(s.modules)), nil
+}
+
+// Override records a module that an [OverlaySource] served from its
+// patches in place of the base source's copy.
+type Override struct {
+	Module   string
+	Path     string // path of the replacement in the patches source
+	Original string // path of the replaced module in the base source
+}
+
+// OverlaySource is a Source in which modules from a patches source
+// replace modules of the same name in a base source. It records every
+// patched module it serves, so that a load can report which vendor files
+// were overridden. Create one with [Overlay].
+type OverlaySource struct {
+	base    Source
+	patches Source
+
+	mu        sync.Mutex
+	served    map[string]string    // patched module name -> replacement path
+	overrides map[string]*Override // resolved by Overrides; nil if base lacks the module
+}
+
+// Overlay creates a source that finds modules in patches first and in
+// base otherwise, so that a corrected copy of a module replaces the
+// original without touching the base directory. Modules only present in
+// patches are added.
+func Overlay(base, patches Source) *OverlaySource {
+	return &OverlaySource{
+		base:      base,
+		patches:   patches,
+		served:    make(map[string]string),
+		overrides: make(map[string]*Override),
+	}
+}
+
+// Find returns the patched module if there is one, and the base module
+// otherwise. Base is not consulted for patched modules; Overrides does
+// that later, only if asked.
+func (s *OverlaySource) Find(name string) (FindResult, error) {
+	result, err := s.patches.Find(name)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return result, err
+		}
+		return s.base.Find(name)
+	}
+	s.mu.Lock()
+	s.served[name] = result.Path
+	s.mu.Unlock()
+	return result, nil
+}
+
+// ListModules returns the modules of both sources.
+func (s *OverlaySource) ListModules() ([]string, error) {
+	return Multi(s.patches, s.base).ListModules()
+}
+
+// Overrides returns the base modules that Find has replaced so far,
+// sorted by module name. Patched modules that base does not have are
+// additions, not overrides, and are left out.
+//
+// Sources only report a module's path by finding it, so the first call
+// looks up each patched module in base, reading the file it replaces (or
+// fetching it, for a [Remote] base). Results are kept, so later calls
+// only look up modules served since.
+func (s *OverlaySource) Overrides() []Override {
+	s.mu.Lock()
+	pending := make(map[string]string)
+	for name, path := range s.served {
+		if _, ok := s.overrides[name]; !ok {
+			pending[name] = path
+		}
+	}
+	s.mu.Unlock()
+
+	// Look up without holding the lock, which Find also takes.
+	resolved := make(map[string]*Override, len(pending))
+	for name, path := range pending {
+		if original, err := s.base.Find(name); err == nil {
+			resolved[name] = &Override{Module: name, Path: path, Original: original.Path}
+		} else {
+			resolved[name] = nil
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	maps.Copy(s.overrides, resolved)
+	var out []Override
+	for _, o := range s.overrides {
+		if o != nil {
+			out = append(out, *o)
+		}
+	}
+	slices.SortFunc(out, func(a, b Override) int { return strings.Compare(a.Module, b.Module) })
+	return out
+}
+
 func makeExtensionSet(extensions []string) map[string]struct{} {
 	set := make(map[string]struct{}, len(extensions))
 	for _, ext := range extensions {
@@ -5,6 +5,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"testing/fstest"
 
@@ -258,6 +259,98 @@ func TestMultiSourceFindNotExist(t *testing.T) {
 	testutil.True(t, err == fs.ErrNotExist, "Multi.Find should return fs.ErrNotExist")
 }
 
+func TestMapSource(t *testing.T) {
+	modules := map[string][]byte{
+		"TEST-MAP-MIB": []byte(`TEST-MAP-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    OBJECT-TYPE, Integer32, enterprises FROM SNMPv2-SMI;
+testMapScalar OBJECT-TYPE
+    SYNTAX Integer32
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Test scalar"
+    ::= { enterprises 99991 1 }
+END
+`),
+	}
+	src := MapSource(modules)
+	delete(modules, "TEST-MAP-MIB")
+
+	names, err := src.ListModules()
+	testutil.NoError(t, err, "ListModules")
+	testutil.SliceEqual(t, []string{"TEST-MAP-MIB"}, names, "map is copied")
+
+	_, err = src.Find("OTHER-MIB")
+	testutil.True(t, err == fs.ErrNotExist, "Find should return fs.ErrNotExist")
+
+	m, err := Load(context.Background(), WithSource(src), WithModules("TEST-MAP-MIB"))
+	testutil.NoError(t, err, "Load from MapSource")
+	testutil.NotNil(t, m.Object("testMapScalar"), "testMapScalar")
+	testutil.Equal(t, "mem:TEST-MAP-MIB", m.Module("TEST-MAP-MIB").SourcePath(), "source path")
+}
+
+func TestOverlaySource(t *testing.T) {
+	base, err := Dir("testdata/corpus/primary/ietf")
+	testutil.NoError(t, err, "Dir")
+
+	// A corrected IF-MIB that drops everything but ifNumber.
+	patched := MapSource(map[string][]byte{
+		"IF-MIB": []byte(`IF-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    OBJECT-TYPE, Integer32, mib-2 FROM SNMPv2-SMI;
+interfaces OBJECT IDENTIFIER ::= { mib-2 2 }
+ifNumber OBJECT-TYPE
+    SYNTAX Integer32
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Patched"
+    ::= { interfaces 1 }
+END
+`),
+	})
+	counted := &countingSource{Source: base}
+	src := Overlay(counted, patched)
+
+	m, err := Load(context.Background(), WithSource(src), WithModules("IF-MIB", "TCP-MIB"))
+	testutil.NoError(t, err, "Load through overlay")
+	testutil.NotNil(t, m.Object("ifNumber"), "ifNumber from the patch")
+	testutil.Nil(t, m.Object("ifIndex"), "ifIndex from the replaced original")
+	testutil.NotNil(t, m.Object("tcpRtoAlgorithm"), "TCP-MIB from base")
+
+	testutil.Equal(t, 0, counted.finds["IF-MIB"], "base not read for a patched module")
+
+	overrides := src.Overrides()
+	testutil.Len(t, overrides, 1, "overrides")
+	testutil.Equal(t, "IF-MIB", overrides[0].Module, "override module")
+	testutil.Equal(t, "mem:IF-MIB", overrides[0].Path, "override path")
+	testutil.Equal(t, filepath.Join("testdata/corpus/primary/ietf", "IF-MIB.mib"), overrides[0].Original, "original path")
+
+	src.Overrides()
+	testutil.Equal(t, 1, counted.finds["IF-MIB"], "original looked up once")
+
+	names, err := src.ListModules()
+	testutil.NoError(t, err, "ListModules")
+	baseNames, _ := base.ListModules()
+	testutil.Equal(t, len(baseNames), len(names), "patched module listed once")
+}
+
+// countingSource counts Find calls per module.
+type countingSource struct {
+	Source
+	mu    sync.Mutex
+	finds map[string]int
+}
+
+func (s *countingSource) Find(name string) (FindResult, error) {
+	s.mu.Lock()
+	if s.finds == nil {
+		s.finds = make(map[string]int)
+	}
+	s.finds[name]++
+	s.mu.Unlock()
+	return s.Source.Find(name)
+}
+
 func TestWithExtensions(t *testing.T) {
 	tmpDir := t.TempDir()
 	content := `EXT-TEST-MIB DEFINITIONS ::= BEGIN
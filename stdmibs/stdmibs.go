@@ -0,0 +1,68 @@
+// Package stdmibs bundles the IETF and IANA standard MIB modules, so that
+// programs can load IF-MIB, ENTITY-MIB, LLDP-MIB and friends on hosts
+// without a system MIB directory, such as minimal containers.
+//
+// The files, and a snapshot of them, are embedded in the binary (about
+// 7 MB). Use [Source] to
+// combine them with other sources, typically after vendor MIBs so that
+// vendor imports of standard modules resolve:
+//
+//	m, err := gomib.Load(ctx,
+//	    gomib.WithSource(vendorSrc, stdmibs.Source()),
+//	    gomib.WithModules("ACME-MIB"),
+//	)
+//
+// [Mib] returns all bundled modules resolved. It starts from a pre-built
+// snapshot of the parsed modules (see [gomib.WriteSnapshot]), so no MIB
+// file is parsed at startup; only resolution runs, on the first call,
+// and later calls share the result.
+package stdmibs
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"io/fs"
+	"sync"
+
+	"github.com/golangsnmp/gomib"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+//go:embed ietf iana
+var files embed.FS
+
+// FS returns the bundled MIB files, laid out as ietf/MODULE.mib and
+// iana/MODULE.mib.
+func FS() fs.FS { return files }
+
+// Source returns a source over the bundled MIB files.
+func Source() gomib.Source { return gomib.FS("stdmibs", files) }
+
+// snapshot holds every bundled module, parsed at permissive strictness.
+// Regenerate it with go test -run Snapshot -update after changing the
+// bundled files or upgrading the parser.
+//
+//go:embed stdmibs.snapshot
+var snapshot []byte
+
+var loadAll = sync.OnceValues(func() (*mib.Mib, error) { return loadSnapshot() })
+
+func loadSnapshot(opts ...gomib.LoadOption) (*mib.Mib, error) {
+	opts = append([]gomib.LoadOption{gomib.WithStrictness(mib.StrictnessPermissive)}, opts...)
+	return gomib.LoadSnapshot(context.Background(), bytes.NewReader(snapshot), opts...)
+}
+
+// Mib returns every bundled module, resolved at permissive strictness.
+// The modules come from the embedded snapshot, so nothing is parsed; the
+// first call resolves them and the result is shared by all callers. A
+// Mib is safe for concurrent reads and must not be modified.
+func Mib() (*mib.Mib, error) { return loadAll() }
+
+// Load loads the named bundled modules and their imports into a new Mib.
+// Options such as [gomib.WithStrictness] are passed through; use
+// [gomib.Load] with [Source] directly to mix in other sources.
+func Load(ctx context.Context, modules []string, opts ...gomib.LoadOption) (*mib.Mib, error) {
+	opts = append([]gomib.LoadOption{gomib.WithSource(Source()), gomib.WithModules(modules...)}, opts...)
+	return gomib.Load(ctx, opts...)
+}
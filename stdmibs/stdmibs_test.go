@@ -0,0 +1,98 @@
+package stdmibs
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golangsnmp/gomib"
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+var updateSnapshot = flag.Bool("update", false, "rewrite stdmibs.snapshot")
+
+func TestMib(t *testing.T) {
+	m, err := Mib()
+	testutil.NoError(t, err, "Mib")
+	for _, name := range []string{"IF-MIB", "ENTITY-MIB", "LLDP-MIB", "IANAifType-MIB", "INET-ADDRESS-MIB"} {
+		testutil.NotNil(t, m.Module(name), name)
+	}
+	// Every bundled module imports only bundled modules. The corpus
+	// MPLS-LSR-MIB, a pre-standard revision importing private draft
+	// modules, is left out for that reason.
+	for _, ref := range m.Unresolved() {
+		t.Errorf("unresolved %s %s in %s", ref.Kind, ref.Symbol, ref.Module)
+	}
+	testutil.Nil(t, m.Module("MPLS-LSR-MIB"), "MPLS-LSR-MIB is not bundled")
+
+	again, err := Mib()
+	testutil.NoError(t, err, "Mib again")
+	testutil.True(t, m == again, "Mib should be shared")
+}
+
+func TestLoad(t *testing.T) {
+	m, err := Load(context.Background(), []string{"IF-MIB"})
+	testutil.NoError(t, err, "Load")
+	testutil.NotNil(t, m.Object("ifIndex"), "ifIndex")
+	testutil.Nil(t, m.Module("ENTITY-MIB"), "only the closure is loaded")
+}
+
+// TestMatchesCorpus keeps the bundled copies in step with the test corpus
+// they are taken from.
+func TestMatchesCorpus(t *testing.T) {
+	err := fs.WalkDir(files, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		bundled, err := fs.ReadFile(files, path)
+		if err != nil {
+			return err
+		}
+		corpus, err := os.ReadFile(filepath.Join("..", "testdata", "corpus", "primary", filepath.FromSlash(path)))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bundled, corpus) {
+			t.Errorf("%s differs from the test corpus", path)
+		}
+		return nil
+	})
+	testutil.NoError(t, err, "walk")
+}
+
+// TestSnapshot checks that the embedded snapshot matches the bundled
+// files and that loading it parses nothing. After changing the files or
+// the parser, regenerate it with go test -run Snapshot -update.
+func TestSnapshot(t *testing.T) {
+	var buf bytes.Buffer
+	err := gomib.WriteSnapshot(context.Background(), &buf,
+		gomib.WithSource(Source()),
+		gomib.WithStrictness(mib.StrictnessPermissive),
+	)
+	testutil.NoError(t, err, "WriteSnapshot")
+	if *updateSnapshot {
+		testutil.NoError(t, os.WriteFile("stdmibs.snapshot", buf.Bytes(), 0o644), "write snapshot")
+		snapshot = bytes.Clone(buf.Bytes())
+	}
+	testutil.True(t, bytes.Equal(buf.Bytes(), snapshot), "stdmibs.snapshot is stale; run go test -run Snapshot -update")
+
+	counts := make(map[gomib.EventKind]int)
+	m, err := loadSnapshot(gomib.WithProgress(func(e gomib.Event) { counts[e.Kind]++ }))
+	testutil.NoError(t, err, "loadSnapshot")
+	testutil.Equal(t, 0, counts[gomib.EventModuleRead], "modules read")
+	testutil.Equal(t, 0, counts[gomib.EventModuleParsed], "modules parsed")
+
+	parsed, err := gomib.Load(context.Background(),
+		gomib.WithSource(Source()),
+		gomib.WithStrictness(mib.StrictnessPermissive),
+	)
+	testutil.NoError(t, err, "Load")
+	testutil.Equal(t, len(parsed.Modules()), len(m.Modules()), "modules")
+	testutil.Equal(t, len(parsed.Objects()), len(m.Objects()), "objects")
+	testutil.Equal(t, len(parsed.Diagnostics()), len(m.Diagnostics()), "diagnostics")
+}
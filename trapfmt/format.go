@@ -0,0 +1,264 @@
+package trapfmt
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFormat mirrors snmptrapd's default output for SNMPv2 notifications,
+// with the notification name added to the header line.
+const DefaultFormat = `%.4y-%.2m-%.2l %.2h:%.2j:%.2k %B [%b]: %n\n%v\n`
+
+// Format renders t using an snmptrapd -F style format string.
+//
+// Supported specifiers, each accepting optional printf-style "-", "0",
+// width and ".precision" modifiers:
+//
+//	%%  a literal percent sign
+//	%a  agent address           %A  agent hostname
+//	%b  transport source         %B  source hostname (same as %b)
+//	%t  receive time, seconds since the epoch
+//	%T  sysUpTime in seconds
+//	%y %m %l %h %j %k   receive year, month, day, hour, minute, second
+//	%Y %M %L %H %J %K   the same fields computed from sysUpTime
+//	%N  enterprise OID (SNMPv1) or the notification's module
+//	%w  SNMPv1 generic trap number
+//	%q  SNMPv1 specific trap number
+//	%W  SNMPv1 generic trap description, or the notification name
+//	%P  security information
+//	%v  variable bindings, "SYMBOL = VALUE", tab-separated
+//	%V  sets the %v separator to the following character
+//
+// gomib adds these specifiers for MIB metadata:
+//
+//	%n  notification name
+//	%o  notification OID, numeric
+//	%O  notification OID, symbolic (MODULE::name)
+//	%D  notification description
+//
+// Backslash escapes \n, \t, \r and \\ are expanded. Unknown specifiers
+// are copied to the output unchanged.
+func (f *Formatter) Format(format string, t Trap) string {
+	ev := f.Event(t)
+	var b strings.Builder
+	sep := "\t"
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c == '\\' && i+1 < len(format) {
+			i++
+			switch format[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '\\':
+				b.WriteByte('\\')
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(format[i])
+			}
+			continue
+		}
+		if c != '%' || i+1 >= len(format) {
+			b.WriteByte(c)
+			continue
+		}
+
+		start := i
+		i++
+		var spec fieldSpec
+		for ; i < len(format) && (format[i] == '-' || format[i] == '0'); i++ {
+			if format[i] == '-' {
+				spec.left = true
+			} else {
+				spec.zero = true
+			}
+		}
+		spec.width, i = readNumber(format, i)
+		if i < len(format) && format[i] == '.' {
+			spec.precision, i = readNumber(format, i+1)
+			spec.hasPrecision = true
+		}
+		if i >= len(format) {
+			b.WriteString(format[start:])
+			break
+		}
+
+		verb := format[i]
+		if verb == 'V' {
+			if i+1 < len(format) {
+				i++
+				sep = string(format[i])
+				if format[i] == '\\' && i+1 < len(format) {
+					i++
+					sep = unescape(format[i])
+				}
+			}
+			continue
+		}
+		s, numeric, ok := ev.field(verb, sep)
+		if !ok {
+			b.WriteString(format[start : i+1])
+			continue
+		}
+		b.WriteString(spec.apply(s, numeric))
+	}
+	return b.String()
+}
+
+func (ev *Event) field(verb byte, sep string) (value string, numeric, ok bool) {
+	t := ev.Trap
+	uptime := time.Unix(int64(t.Uptime/100), 0).UTC()
+	itoa := func(n int) (string, bool, bool) { return strconv.Itoa(n), true, true }
+
+	switch verb {
+	case '%':
+		return "%", false, true
+	case 'a':
+		return t.Agent, false, true
+	case 'A':
+		if t.Host != "" {
+			return t.Host, false, true
+		}
+		return t.Agent, false, true
+	case 'b', 'B':
+		return t.Source, false, true
+	case 't':
+		return strconv.FormatInt(t.Time.Unix(), 10), true, true
+	case 'T':
+		return strconv.FormatUint(uint64(t.Uptime/100), 10), true, true
+	case 'y':
+		return itoa(t.Time.Year())
+	case 'm':
+		return itoa(int(t.Time.Month()))
+	case 'l':
+		return itoa(t.Time.Day())
+	case 'h':
+		return itoa(t.Time.Hour())
+	case 'j':
+		return itoa(t.Time.Minute())
+	case 'k':
+		return itoa(t.Time.Second())
+	case 'Y':
+		return itoa(uptime.Year())
+	case 'M':
+		return itoa(int(uptime.Month()))
+	case 'L':
+		return itoa(uptime.Day())
+	case 'H':
+		return itoa(uptime.Hour())
+	case 'J':
+		return itoa(uptime.Minute())
+	case 'K':
+		return itoa(uptime.Second())
+	case 'N':
+		if t.V1 != nil {
+			return t.V1.Enterprise.String(), false, true
+		}
+		return ev.Module, false, true
+	case 'w':
+		if t.V1 != nil {
+			return itoa(t.V1.Generic)
+		}
+		return "", false, true
+	case 'q':
+		if t.V1 != nil {
+			return itoa(t.V1.Specific)
+		}
+		return "", false, true
+	case 'W':
+		if t.V1 != nil && t.V1.Generic >= 0 && t.V1.Generic < len(genericTrapNames) {
+			return genericTrapNames[t.V1.Generic], false, true
+		}
+		return ev.Name, false, true
+	case 'P':
+		return t.Security, false, true
+	case 'v':
+		parts := make([]string, len(ev.VarBinds))
+		for i, vb := range ev.VarBinds {
+			parts[i] = vb.Symbol + " = " + vb.Formatted
+		}
+		return strings.Join(parts, sep), false, true
+	case 'n':
+		return ev.Name, false, true
+	case 'o':
+		return t.OID.String(), false, true
+	case 'O':
+		if ev.Module != "" && ev.Notification != nil {
+			return ev.Module + "::" + ev.Name, false, true
+		}
+		return ev.Name, false, true
+	case 'D':
+		return ev.Description, false, true
+	}
+	return "", false, false
+}
+
+// genericTrapNames are the snmptrapd descriptions of SNMPv1 generic traps.
+var genericTrapNames = []string{
+	"Cold Start",
+	"Warm Start",
+	"Link Down",
+	"Link Up",
+	"Authentication Failure",
+	"EGP Neighbor Loss",
+	"Enterprise Specific",
+}
+
+type fieldSpec struct {
+	left, zero   bool
+	width        int
+	precision    int
+	hasPrecision bool
+}
+
+// apply pads or truncates s. For numeric fields precision is the minimum
+// number of digits; for strings it is the maximum length.
+func (s fieldSpec) apply(v string, numeric bool) string {
+	if s.hasPrecision {
+		if numeric {
+			if pad := s.precision - len(v); pad > 0 {
+				v = strings.Repeat("0", pad) + v
+			}
+		} else if len(v) > s.precision {
+			v = v[:s.precision]
+		}
+	}
+	pad := s.width - len(v)
+	if pad <= 0 {
+		return v
+	}
+	switch {
+	case s.left:
+		return v + strings.Repeat(" ", pad)
+	case s.zero && numeric:
+		return strings.Repeat("0", pad) + v
+	default:
+		return strings.Repeat(" ", pad) + v
+	}
+}
+
+func readNumber(s string, i int) (int, int) {
+	n := 0
+	for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, i
+}
+
+func unescape(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 't':
+		return "\t"
+	case 'r':
+		return "\r"
+	default:
+		return string(c)
+	}
+}
@@ -0,0 +1,94 @@
+package trapfmt
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// Parse parses a text/template for rendering events. The template is
+// executed with an *[Event] as its data and has the [FuncMap] helpers
+// for the Formatter's Mib available.
+//
+// Example:
+//
+//	tmpl, err := f.Parse(`{{.Module}}::{{.Name}} from {{.Trap.Agent}}
+//	{{range .VarBinds}}  {{.Name}}{{.Instance}} = {{.Formatted}}
+//	{{end}}`)
+func (f *Formatter) Parse(text string) (*template.Template, error) {
+	return template.New("trap").Funcs(FuncMap(f.mib)).Parse(text)
+}
+
+// Render executes tmpl with the resolved Event for t.
+func (f *Formatter) Render(tmpl *template.Template, t Trap) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, f.Event(t)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// FuncMap returns template helpers backed by m:
+//
+//	formatOID OID           symbolic form, e.g. "IF-MIB::ifIndex.3"
+//	object NAME             the named *mib.Object, or nil
+//	notification NAME       the named *mib.Notification, or nil
+//	description NAME        description of the named object or notification
+//	enumLabel NAME VALUE    enumeration label for an object's value, or ""
+//	formatValue NAME VALUE  value rendered with the object's enums and hints
+//	firstLine TEXT          first non-empty line of TEXT, trimmed
+//	oneLine TEXT            TEXT with whitespace runs collapsed to one space
+func FuncMap(m *mib.Mib) template.FuncMap {
+	return template.FuncMap{
+		"formatOID": func(v any) string {
+			switch oid := v.(type) {
+			case mib.OID:
+				return m.FormatOID(oid)
+			case []uint32:
+				return m.FormatOID(mib.OID(oid))
+			case string:
+				parsed, err := mib.ParseOID(oid)
+				if err != nil {
+					return oid
+				}
+				return m.FormatOID(parsed)
+			}
+			return ""
+		},
+		"object":       m.Object,
+		"notification": m.Notification,
+		"description": func(name string) string {
+			if obj := m.Object(name); obj != nil {
+				return obj.Description()
+			}
+			if n := m.Notification(name); n != nil {
+				return n.Description()
+			}
+			return ""
+		},
+		"enumLabel": func(name string, v any) string {
+			obj := m.Object(name)
+			n, ok := mib.Int64Value(v)
+			if obj == nil || !ok {
+				return ""
+			}
+			nv, _ := obj.EnumByValue(n)
+			return nv.Label
+		},
+		"formatValue": func(name string, v any) string {
+			return m.Object(name).FormatValue(v)
+		},
+		"firstLine": func(s string) string {
+			for line := range strings.SplitSeq(s, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					return line
+				}
+			}
+			return ""
+		},
+		"oneLine": func(s string) string {
+			return strings.Join(strings.Fields(s), " ")
+		},
+	}
+}
@@ -0,0 +1,176 @@
+// Package trapfmt renders received SNMP notifications as human-readable
+// text using MIB metadata from a resolved [mib.Mib].
+//
+// A [Formatter] resolves a [Trap] (the notification OID and its variable
+// bindings, as decoded by an SNMP stack) into an [Event] carrying the
+// notification name, module and description, and for each variable
+// binding the object name, decoded instance index, formatted value and
+// enumeration label. Events can be rendered with snmptrapd-style -F
+// format strings via [Formatter.Format], or with Go text/template via
+// [Formatter.Parse] and [FuncMap].
+package trapfmt
+
+import (
+	"time"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// Well-known OIDs carried in SNMPv2 notification varbind lists.
+var (
+	sysUpTimeInstance   = mib.OID{1, 3, 6, 1, 2, 1, 1, 3, 0}
+	snmpTrapOIDInstance = mib.OID{1, 3, 6, 1, 6, 3, 1, 1, 4, 1, 0}
+	snmpTrapsOID        = mib.OID{1, 3, 6, 1, 6, 3, 1, 1, 5}
+)
+
+// V1Fields holds the SNMPv1 Trap-PDU header fields.
+type V1Fields struct {
+	Enterprise mib.OID
+	Generic    int
+	Specific   int
+}
+
+// Trap is a received notification as decoded by an SNMP stack.
+type Trap struct {
+	OID      mib.OID   // notification OID (snmpTrapOID.0)
+	Uptime   uint32    // sysUpTime.0 in hundredths of a second
+	Time     time.Time // time the notification was received
+	Agent    string    // agent address (%a)
+	Host     string    // agent hostname (%A), falls back to Agent
+	Source   string    // transport source address (%b)
+	Security string    // security or community summary (%P)
+	V1       *V1Fields // SNMPv1 header fields, nil for SNMPv2c/v3
+	VarBinds []mib.VarBind
+}
+
+// FromVarBinds builds a Trap from an SNMPv2 notification varbind list,
+// extracting sysUpTime.0 and snmpTrapOID.0 into Uptime and OID and
+// keeping the remaining bindings.
+func FromVarBinds(vbs []mib.VarBind) Trap {
+	var t Trap
+	for _, vb := range vbs {
+		switch {
+		case vb.OID.Equal(sysUpTimeInstance):
+			if n, ok := mib.Int64Value(vb.Value); ok {
+				t.Uptime = uint32(n)
+			}
+		case vb.OID.Equal(snmpTrapOIDInstance):
+			switch v := vb.Value.(type) {
+			case mib.OID:
+				t.OID = v
+			case []uint32:
+				t.OID = mib.OID(v)
+			}
+		default:
+			t.VarBinds = append(t.VarBinds, vb)
+		}
+	}
+	return t
+}
+
+// V1TrapOID maps SNMPv1 Trap-PDU fields to the equivalent SNMPv2
+// notification OID as described in RFC 3584 section 3.1.
+func V1TrapOID(enterprise mib.OID, generic, specific int) mib.OID {
+	if generic >= 0 && generic < 6 {
+		return snmpTrapsOID.Child(uint32(generic + 1))
+	}
+	return enterprise.Child(0).Child(uint32(specific))
+}
+
+// Event is a Trap resolved against MIB metadata.
+type Event struct {
+	Trap         Trap
+	Notification *mib.Notification // nil if the OID is not a known notification
+	Name         string            // notification name, or the formatted OID if unknown
+	Module       string            // defining module, or ""
+	Description  string
+	VarBinds     []VarBindInfo
+}
+
+// VarBindInfo is a variable binding resolved against MIB metadata.
+type VarBindInfo struct {
+	OID       mib.OID
+	Object    *mib.Object // nil if no loaded object covers the OID
+	Symbol    string      // e.g. "IF-MIB::ifAdminStatus.3"
+	Name      string      // object name, or ""
+	Module    string      // defining module, or ""
+	Index     []mib.IndexValue
+	Instance  string // formatted index, e.g. "[3]", or ".0" for scalars
+	Value     any
+	Formatted string // value rendered with enums and display hints
+	EnumLabel string // enumeration label, or ""
+	Units     string
+}
+
+// Formatter resolves and renders notifications against a Mib.
+type Formatter struct {
+	mib *mib.Mib
+}
+
+// New returns a Formatter backed by m.
+func New(m *mib.Mib) *Formatter {
+	return &Formatter{mib: m}
+}
+
+// Event resolves t against the Formatter's Mib. For SNMPv1 traps with
+// no OID set, the OID is derived from the V1 header fields.
+func (f *Formatter) Event(t Trap) *Event {
+	if len(t.OID) == 0 && t.V1 != nil {
+		t.OID = V1TrapOID(t.V1.Enterprise, t.V1.Generic, t.V1.Specific)
+	}
+	ev := &Event{Trap: t, Name: f.mib.FormatOID(t.OID)}
+	if nd := f.mib.NodeByOID(t.OID); nd != nil && nd.Notification() != nil {
+		n := nd.Notification()
+		ev.Notification = n
+		ev.Name = n.Name()
+		ev.Description = n.Description()
+		if n.Module() != nil {
+			ev.Module = n.Module().Name()
+		}
+	}
+	ev.VarBinds = make([]VarBindInfo, len(t.VarBinds))
+	for i, vb := range t.VarBinds {
+		ev.VarBinds[i] = f.resolveVarBind(vb)
+	}
+	return ev
+}
+
+func (f *Formatter) resolveVarBind(vb mib.VarBind) VarBindInfo {
+	info := VarBindInfo{
+		OID:    vb.OID,
+		Symbol: f.mib.FormatOID(vb.OID),
+		Value:  vb.Value,
+	}
+	nd := f.mib.LongestPrefixByOID(vb.OID)
+	if nd == nil || nd.Object() == nil {
+		info.Formatted = (*mib.Object)(nil).FormatValue(vb.Value)
+		return info
+	}
+
+	obj := nd.Object()
+	info.Object = obj
+	info.Name = obj.Name()
+	info.Units = obj.Units()
+	if obj.Module() != nil {
+		info.Module = obj.Module().Name()
+	}
+
+	suffix := vb.OID[len(nd.OID()):]
+	if obj.IsColumn() {
+		if idx, err := obj.DecodeIndex(suffix); err == nil {
+			info.Index = idx
+			info.Instance = mib.FormatIndex(idx)
+		}
+	}
+	if info.Instance == "" && len(suffix) > 0 {
+		info.Instance = "." + suffix.String()
+	}
+
+	info.Formatted = obj.FormatValue(vb.Value)
+	if n, ok := mib.Int64Value(vb.Value); ok {
+		if nv, ok := obj.EnumByValue(n); ok {
+			info.EnumLabel = nv.Label
+		}
+	}
+	return info
+}
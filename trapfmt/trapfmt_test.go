@@ -0,0 +1,156 @@
+package trapfmt
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golangsnmp/gomib"
+	"github.com/golangsnmp/gomib/internal/testutil"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+var (
+	loadOnce  sync.Once
+	loadedMib *mib.Mib
+	loadErr   error
+)
+
+func loadTestMIB(t testing.TB) *mib.Mib {
+	t.Helper()
+	loadOnce.Do(func() {
+		src, err := gomib.DirTree("../testdata/corpus/primary")
+		if err != nil {
+			loadErr = err
+			return
+		}
+		loadedMib, loadErr = gomib.Load(context.Background(),
+			gomib.WithSource(src), gomib.WithModules("IF-MIB", "SNMPv2-MIB"))
+	})
+	if loadErr != nil {
+		t.Fatalf("failed to load test MIBs: %v", loadErr)
+	}
+	return loadedMib
+}
+
+// linkDownTrap is an SNMPv2 linkDown notification for interface 3.
+func linkDownTrap() Trap {
+	t := FromVarBinds([]mib.VarBind{
+		{OID: mib.OID{1, 3, 6, 1, 2, 1, 1, 3, 0}, Value: uint32(12345)},
+		{OID: mib.OID{1, 3, 6, 1, 6, 3, 1, 1, 4, 1, 0}, Value: mib.OID{1, 3, 6, 1, 6, 3, 1, 1, 5, 3}},
+		{OID: mib.OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 1, 3}, Value: 3},
+		{OID: mib.OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 7, 3}, Value: 1},
+		{OID: mib.OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 8, 3}, Value: 2},
+	})
+	t.Agent = "192.0.2.1"
+	t.Source = "UDP: [192.0.2.1]:161"
+	t.Time = time.Date(2024, 3, 5, 7, 8, 9, 0, time.UTC)
+	return t
+}
+
+func TestFromVarBinds(t *testing.T) {
+	tr := linkDownTrap()
+	testutil.Equal(t, uint32(12345), tr.Uptime, "uptime")
+	testutil.Equal(t, "1.3.6.1.6.3.1.1.5.3", tr.OID.String(), "trap OID")
+	testutil.Len(t, tr.VarBinds, 3, "remaining varbinds")
+}
+
+func TestEvent(t *testing.T) {
+	f := New(loadTestMIB(t))
+	ev := f.Event(linkDownTrap())
+
+	testutil.Equal(t, "linkDown", ev.Name, "name")
+	testutil.Equal(t, "IF-MIB", ev.Module, "module")
+	testutil.True(t, ev.Description != "", "description should be set")
+	testutil.Len(t, ev.VarBinds, 3, "varbinds")
+
+	admin := ev.VarBinds[1]
+	testutil.Equal(t, "ifAdminStatus", admin.Name, "varbind name")
+	testutil.Equal(t, "[3]", admin.Instance, "instance")
+	testutil.Equal(t, "up", admin.EnumLabel, "enum label")
+	testutil.Equal(t, "up(1)", admin.Formatted, "formatted value")
+	testutil.Len(t, admin.Index, 1, "decoded index")
+	testutil.Equal(t, "ifIndex", admin.Index[0].Object.Name(), "index object")
+}
+
+func TestEventSmallIntegerTypes(t *testing.T) {
+	f := New(loadTestMIB(t))
+	ifOperStatus := mib.OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 8, 3}
+	for _, value := range []any{int8(2), int16(2), uint8(2), uint16(2)} {
+		ev := f.Event(Trap{VarBinds: []mib.VarBind{{OID: ifOperStatus, Value: value}}})
+		vb := ev.VarBinds[0]
+		testutil.Equal(t, "down(2)", vb.Formatted, "formatted %T", value)
+		testutil.Equal(t, "down", vb.EnumLabel, "enum label %T", value)
+	}
+}
+
+func TestEventUnknownOID(t *testing.T) {
+	f := New(loadTestMIB(t))
+	ev := f.Event(Trap{
+		OID:      mib.OID{1, 3, 6, 1, 4, 1, 99999, 1},
+		VarBinds: []mib.VarBind{{OID: mib.OID{1, 3, 6, 1, 4, 1, 99999, 2, 0}, Value: []byte{0, 1}}},
+	})
+	testutil.Nil(t, ev.Notification, "unknown notification")
+	testutil.Nil(t, ev.VarBinds[0].Object, "unknown object")
+	testutil.Equal(t, "00 01", ev.VarBinds[0].Formatted, "raw formatting")
+}
+
+func TestV1TrapOID(t *testing.T) {
+	ent := mib.OID{1, 3, 6, 1, 4, 1, 9}
+	testutil.Equal(t, "1.3.6.1.6.3.1.1.5.3", V1TrapOID(ent, 2, 0).String(), "generic linkDown")
+	testutil.Equal(t, "1.3.6.1.4.1.9.0.17", V1TrapOID(ent, 6, 17).String(), "enterprise specific")
+}
+
+func TestFormat(t *testing.T) {
+	f := New(loadTestMIB(t))
+	tr := linkDownTrap()
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%n", "linkDown"},
+		{"%O", "IF-MIB::linkDown"},
+		{"%o", "1.3.6.1.6.3.1.1.5.3"},
+		{"%a", "192.0.2.1"},
+		{"%T", "123"},
+		{"%.4y-%.2m-%.2l %.2h:%.2j:%.2k", "2024-03-05 07:08:09"},
+		{"[%-6n]", "[linkDown]"},
+		{"[%10n]", "[  linkDown]"},
+		{"%.4n", "link"},
+		{"%%", "%"},
+		{"%Z", "%Z"},
+		{`%V|%v`, "IF-MIB::ifIndex.3 = 3|IF-MIB::ifAdminStatus.3 = up(1)|IF-MIB::ifOperStatus.3 = down(2)"},
+		{`a\tb`, "a\tb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			testutil.Equal(t, tt.want, f.Format(tt.format, tr), "Format(%q)", tt.format)
+		})
+	}
+}
+
+func TestFormatV1(t *testing.T) {
+	f := New(loadTestMIB(t))
+	tr := Trap{V1: &V1Fields{Enterprise: mib.OID{1, 3, 6, 1, 4, 1, 9}, Generic: 3}}
+	testutil.Equal(t, "Link Up 3 linkUp", f.Format("%W %w %n", tr), "v1 generic")
+}
+
+func TestTemplate(t *testing.T) {
+	f := New(loadTestMIB(t))
+	tmpl, err := f.Parse(`{{.Module}}::{{.Name}}: {{firstLine .Description}}
+{{range .VarBinds}}{{.Name}}{{.Instance}}={{.Formatted}};{{end}}
+{{formatOID "1.3.6.1.2.1.2.2.1.1.7"}} {{enumLabel "ifOperStatus" 2}}`)
+	testutil.NoError(t, err, "Parse")
+
+	out, err := f.Render(tmpl, linkDownTrap())
+	testutil.NoError(t, err, "Render")
+
+	lines := strings.Split(out, "\n")
+	testutil.Len(t, lines, 3, "output lines")
+	testutil.True(t, strings.HasPrefix(lines[0], "IF-MIB::linkDown: A linkDown trap"), "header: %s", lines[0])
+	testutil.Equal(t, "ifIndex[3]=3;ifAdminStatus[3]=up(1);ifOperStatus[3]=down(2);", lines[1], "varbinds")
+	testutil.Equal(t, "IF-MIB::ifIndex.7 down", lines[2], "helpers")
+}